@@ -0,0 +1,54 @@
+package numfmt
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// basePrefixes are the prefixes written by BasePrefix. They are the prefixes of Go integer literals.
+var basePrefixes = map[int]string{2: "0b", 8: "0o", 16: "0x"}
+
+// NewGoLiteralFormatter returns a Formatter that formats numbers as Go numeric literals in base with underscores
+// separating groups of digits. e.g. 1_000_000 in base 10 and 0x1f_ffff in base 16. Numbers in bases other than 10 are
+// written with a prefix such as 0x.
+func NewGoLiteralFormatter(base int) *Formatter {
+	f := &Formatter{GroupSeparator: "_"}
+	if base != 10 {
+		f.Base = base
+		f.BasePrefix = true
+	}
+	return f
+}
+
+// base returns the base numbers are written in. Bases outside of 2 to 36 are treated as 10.
+func (f *Formatter) base() int {
+	if f.Base < 2 || f.Base > 36 {
+		return 10
+	}
+	return f.Base
+}
+
+// baseState returns the formatState for d rounded to an integer and written in f.Base.
+func (f *Formatter) baseState(d decimal.Decimal) *formatState {
+	d = d.Round(0)
+	base := f.base()
+
+	intPart := d.Abs().BigInt().Text(base)
+	if f.UppercaseDigits {
+		intPart = strings.ToUpper(intPart)
+	}
+
+	fs := &formatState{
+		f:       f,
+		neg:     d.Sign() < 0,
+		zero:    d.IsZero(),
+		intPart: intPart,
+
+		fillIndex: -1,
+	}
+	if f.BasePrefix {
+		fs.prefix = basePrefixes[base]
+	}
+	return fs
+}
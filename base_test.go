@@ -0,0 +1,60 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterBase(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Base: 16}, 255, "ff"},
+		{&numfmt.Formatter{Base: 16}, 4294967295, "ffff_ffff"},
+		{&numfmt.Formatter{Base: 16, BasePrefix: true, UppercaseDigits: true}, 4294967295, "0xFFFF_FFFF"},
+		{&numfmt.Formatter{Base: 16, BasePrefix: true}, -255, "-0xff"},
+		{&numfmt.Formatter{Base: 16, BasePrefix: true, Template: "n"}, -255, "0xff"},
+		{&numfmt.Formatter{Base: 2, GroupSeparator: " "}, 170, "1010 1010"},
+		{&numfmt.Formatter{Base: 2, BasePrefix: true, GroupSize: 8}, 65535, "0b11111111_11111111"},
+		{&numfmt.Formatter{Base: 8, BasePrefix: true}, 511, "0o777"},
+		{&numfmt.Formatter{Base: 8}, 4095, "7_777"},
+		{&numfmt.Formatter{Base: 16}, "255.6", "100"},
+		{&numfmt.Formatter{Base: 16}, 0, "0"},
+		{&numfmt.Formatter{Base: 16}, "18446744073709551616", "1_0000_0000_0000_0000"},
+		{&numfmt.Formatter{Base: 36, UppercaseDigits: true}, 35, "Z"},
+		{&numfmt.Formatter{Base: 16, Template: "#n"}, 255, "#ff"},
+		{&numfmt.Formatter{Base: 16, Digits: numfmt.ArabicIndicDigits}, 171, "ab"},
+		{&numfmt.Formatter{Base: 1}, 1234, "1,234"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestNewGoLiteralFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		base     int
+		arg      interface{}
+		expected string
+	}{
+		{10, 1000000, "1_000_000"},
+		{10, 999, "999"},
+		{10, "-1234.5678", "-1_234.5678"},
+		{16, 8191, "0x1fff"},
+		{16, 131071, "0x1_ffff"},
+		{8, 8, "0o10"},
+		{2, 5, "0b101"},
+	} {
+		actual := numfmt.NewGoLiteralFormatter(tt.base).Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := numfmt.NewGoLiteralFormatter(16)
+	f.UppercaseDigits = true
+	f.GroupSize = 2
+	assert.Equal(t, "0x1F_FF", f.Format(8191))
+}
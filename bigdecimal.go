@@ -0,0 +1,99 @@
+package numfmt
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
+
+// BigDecimal is an arbitrary-precision decimal that can report its exact value. Values that implement it such as
+// *decimal.Big from github.com/ericlagergren/decimal are converted without a round-trip through a string. If the value
+// also has an IsFinite method that returns false it is converted with fmt.Sprint like other non-numbers. If it has
+// Precision and Scale methods such as those of *decimal.Big, InputLimits are checked with them before the value is
+// converted.
+type BigDecimal interface {
+	// Rat sets z to the value and returns z. If z is nil a new *big.Rat is allocated.
+	Rat(z *big.Rat) *big.Rat
+}
+
+// UnscaledBigDecimal is a BigDecimal that reports its value as an unscaled integer and a scale. The value is
+// Unscaled × 10^-Scale. Values that implement it are converted directly instead of through a *big.Rat. An adapter for
+// *decimal.Big can use decimal.Raw.
+type UnscaledBigDecimal interface {
+	BigDecimal
+
+	// Unscaled sets z to the unscaled value and returns z. If z is nil a new *big.Int is allocated.
+	Unscaled(z *big.Int) *big.Int
+
+	// Scale is the number of digits after the decimal point. It is negative for multiples of powers of ten.
+	Scale() int
+}
+
+// bigDecimalToDecimal converts v to a decimal.Decimal.
+func bigDecimalToDecimal(v BigDecimal) (decimal.Decimal, bool) {
+	if finite, ok := v.(interface{ IsFinite() bool }); ok && !finite.IsFinite() {
+		return decimal.Decimal{}, false
+	}
+
+	if u, ok := v.(UnscaledBigDecimal); ok {
+		scale := u.Scale()
+		if scale > math.MaxInt32 || scale <= math.MinInt32 {
+			return decimal.Decimal{}, false
+		}
+		unscaled := u.Unscaled(nil)
+		if unscaled == nil {
+			return decimal.Decimal{}, false
+		}
+		return decimal.NewFromBigInt(unscaled, int32(-scale)), true
+	}
+
+	r := v.Rat(nil)
+	if r == nil {
+		return decimal.Decimal{}, false
+	}
+	return ratToDecimal(r), true
+}
+
+// bigDecimalExponent returns the exponent of v in scientific notation if v has Precision and Scale methods.
+func bigDecimalExponent(v BigDecimal) (exponent int64, ok bool) {
+	ps, ok := v.(interface {
+		Precision() int
+		Scale() int
+	})
+	if !ok {
+		return 0, false
+	}
+	return int64(ps.Precision()) - int64(ps.Scale()) - 1, true
+}
+
+// log2Of5 is used to estimate the power of 5 of a denominator from its length in bits.
+var log2Of5 = math.Log2(5)
+
+// ratToDecimal converts r to a decimal.Decimal. It is exact when the denominator of r has no prime factors other than 2
+// and 5 as is the case for any decimal. Otherwise the result is rounded to decimal.DivisionPrecision places.
+func ratToDecimal(r *big.Rat) decimal.Decimal {
+	num := r.Num()
+	den := r.Denom()
+
+	// den is 2^twos × 5^fives for any decimal. The power of 5 is estimated from the length of the odd part and checked
+	// with a single multiplication instead of dividing by 5 repeatedly.
+	twos := int32(den.TrailingZeroBits())
+	odd := new(big.Int).Rsh(den, uint(twos))
+	fives := int32(math.Round(float64(odd.BitLen()-1) / log2Of5))
+	if fives < 0 || new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(fives)), nil).Cmp(odd) != 0 {
+		fives = int32(math.Round(float64(odd.BitLen()) / log2Of5))
+		if new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(fives)), nil).Cmp(odd) != 0 {
+			return decimal.NewFromBigInt(num, 0).Div(decimal.NewFromBigInt(den, 0))
+		}
+	}
+
+	// num / (2^twos × 5^fives) = num × 2^(exp-twos) × 5^(exp-fives) / 10^exp
+	exp := twos
+	if fives > exp {
+		exp = fives
+	}
+	scaled := new(big.Int).Lsh(num, uint(exp-twos))
+	scaled.Mul(scaled, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(exp-fives)), nil))
+	return decimal.NewFromBigInt(scaled, -exp)
+}
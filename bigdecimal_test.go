@@ -0,0 +1,106 @@
+package numfmt_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+// bigDecimal is a minimal arbitrary-precision decimal like *decimal.Big from github.com/ericlagergren/decimal.
+type bigDecimal struct {
+	unscaled *big.Int
+	scale    int64
+	inf      bool
+}
+
+func newBigDecimal(unscaled string, scale int64) *bigDecimal {
+	i, _ := new(big.Int).SetString(unscaled, 10)
+	return &bigDecimal{unscaled: i, scale: scale}
+}
+
+func (x *bigDecimal) Rat(z *big.Rat) *big.Rat {
+	if z == nil {
+		z = new(big.Rat)
+	}
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(x.scale), nil)
+	return z.SetFrac(x.unscaled, den)
+}
+
+func (x *bigDecimal) Unscaled(z *big.Int) *big.Int {
+	if z == nil {
+		z = new(big.Int)
+	}
+	return z.Set(x.unscaled)
+}
+
+func (x *bigDecimal) Scale() int {
+	return int(x.scale)
+}
+
+func (x *bigDecimal) Precision() int {
+	if x.unscaled.Sign() == 0 {
+		return 1
+	}
+	return len(new(big.Int).Abs(x.unscaled).String())
+}
+
+func (x *bigDecimal) IsFinite() bool {
+	return !x.inf
+}
+
+func (x *bigDecimal) String() string {
+	if x.inf {
+		return "Infinity"
+	}
+	return "not used"
+}
+
+// ratDecimal only implements numfmt.BigDecimal.
+type ratDecimal struct {
+	r *big.Rat
+}
+
+func (x ratDecimal) Rat(z *big.Rat) *big.Rat {
+	if z == nil {
+		z = new(big.Rat)
+	}
+	return z.Set(x.r)
+}
+
+// hugeDecimal reports a huge exponent through Precision and Scale and must not be converted.
+type hugeDecimal struct{}
+
+func (hugeDecimal) Rat(z *big.Rat) *big.Rat      { panic("Rat called") }
+func (hugeDecimal) Unscaled(z *big.Int) *big.Int { panic("Unscaled called") }
+func (hugeDecimal) Scale() int                   { return 1000000 }
+func (hugeDecimal) Precision() int               { return 1 }
+
+func TestFormatterFormatBigDecimal(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{}, newBigDecimal("123456", 2), "1,234.56"},
+		{&numfmt.Formatter{}, newBigDecimal("-5", 0), "-5"},
+		{&numfmt.Formatter{}, newBigDecimal("1234567890123456789012345", 22), "123.4567890123456789012345"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}, newBigDecimal("1005", 3), "1.01"},
+		{&numfmt.Formatter{}, &bigDecimal{inf: true}, "Infinity"},
+		{&numfmt.Formatter{}, (*bigDecimal)(nil), ""},
+		{&numfmt.Formatter{}, newBigDecimal("15", -3), "15,000"},
+		{&numfmt.Formatter{}, ratDecimal{big.NewRat(1, 8)}, "0.125"},
+		{&numfmt.Formatter{}, ratDecimal{big.NewRat(-3, 2000)}, "-0.0015"},
+		{&numfmt.Formatter{}, ratDecimal{big.NewRat(12345, 100)}, "123.45"},
+		{&numfmt.Formatter{}, ratDecimal{big.NewRat(1, 95367431640625)}, "0.00000000000001048576"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 4}}, ratDecimal{big.NewRat(1, 3)}, "0.3333"},
+		{&numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxExponent: 100}}, hugeDecimal{}, "—"},
+		{&numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxExponent: 100}}, newBigDecimal("1", 101), "—"},
+		{&numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxIntegerDigits: 3}}, newBigDecimal("99999", 2), "999.99"},
+		{&numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxIntegerDigits: 3}}, newBigDecimal("100000", 2), "—"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,27 @@
+package numfmt
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// clamp returns d limited to ClampMin and ClampMax and the marker to write if it was changed.
+func (f *Formatter) clamp(d decimal.Decimal) (clamped decimal.Decimal, marker string, ok bool) {
+	if f.ClampMin != nil && d.Cmp(*f.ClampMin) < 0 {
+		return *f.ClampMin, f.ClampBelowMarker, true
+	}
+	if f.ClampMax != nil && d.Cmp(*f.ClampMax) > 0 {
+		return *f.ClampMax, f.ClampAboveMarker, true
+	}
+	return d, "", false
+}
+
+// clampedNumberState is numberState for d limited to ClampMin and ClampMax.
+func (f *Formatter) clampedNumberState(d decimal.Decimal, exponentForm bool) *formatState {
+	d, marker, ok := f.clamp(d)
+	fs := f.unclampedNumberState(d, exponentForm)
+	if ok {
+		fs.clamped = true
+		fs.clampMarker = marker
+	}
+	return fs
+}
@@ -0,0 +1,56 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterClamp(t *testing.T) {
+	zero := decimal.Zero
+	hundred := decimal.New(100, 0)
+	one := decimal.New(1, 0)
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{ClampMin: &zero, ClampMax: &hundred}, 42, "42"},
+		{&numfmt.Formatter{ClampMin: &zero, ClampMax: &hundred}, 130, "100"},
+		{&numfmt.Formatter{ClampMin: &zero, ClampMax: &hundred}, -5, "0"},
+		{&numfmt.Formatter{ClampMin: &zero, ClampMax: &hundred}, "100.5", "100"},
+		{&numfmt.Formatter{ClampMax: &hundred, ClampAboveMarker: ">"}, 130, ">100"},
+		{&numfmt.Formatter{ClampMax: &hundred, ClampAboveMarker: ">"}, 100, "100"},
+		{&numfmt.Formatter{ClampMin: &zero, ClampBelowMarker: "<"}, -0.2, "<0"},
+		{&numfmt.Formatter{ClampMin: &zero, ClampBelowMarker: "<"}, -1e6, "<0"},
+		{
+			&numfmt.Formatter{ClampMin: &zero, ClampMax: &one, ClampAboveMarker: ">", Shift: 2, Template: "n%"},
+			1.5, ">100%",
+		},
+		{
+			&numfmt.Formatter{ClampMax: &hundred, ClampAboveMarker: "≥", Currency: "USD"},
+			1000, "≥$100.00",
+		},
+		{&numfmt.Formatter{ClampMax: &hundred, MinDecimalPlaces: 1}, int64(1) << 62, "100.0"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	r := (&numfmt.Formatter{ClampMax: &hundred}).FormatResult(250)
+	assert.True(t, r.Clamped)
+
+	d, err := (&numfmt.Formatter{ClampMax: &hundred, ClampAboveMarker: ">"}).Parse(">100")
+	require.NoError(t, err)
+	assert.Equal(t, "100", d.String())
+}
+
+func TestTemplateFuncClamp(t *testing.T) {
+	actual, err := numfmt.TemplateFunc("ClampMin", "0", "ClampMax", "5", "ClampAboveMarker", "+", 7)
+	require.NoError(t, err)
+	assert.Equal(t, "+5", actual)
+}
@@ -0,0 +1,140 @@
+package numfmt
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Clock configures a Formatter to write a quantity of time as hours, minutes, and seconds separated by colons such as
+// 1:02:03 or 02:03.450. Hours are not limited to 24. The sign is written by the template.
+type Clock struct {
+	// Unit is the unit of the number formatted. e.g. time.Minute formats 90 as 1:30:00 and time.Nanosecond formats
+	// the nanoseconds of a time.Duration converted with int64. Default: time.Second
+	Unit time.Duration
+
+	// Smallest is the smallest unit written: time.Hour, time.Minute, or time.Second. e.g. 3723 seconds is 1:02 with
+	// time.Minute. Default: time.Second
+	Smallest time.Duration
+
+	// Places is the number of decimal places of the smallest unit. e.g. 123.45 seconds is 2:03.450 with 3. Default: 0
+	Places int32
+
+	// AlwaysHours writes the hours even when they are zero. e.g. 0:02:03 instead of 2:03.
+	AlwaysHours bool
+
+	// PadFirst writes the first component with at least two digits like the others. e.g. 02:03 instead of 2:03.
+	PadFirst bool
+}
+
+// NewClockFormatter returns a Formatter that formats a number of seconds as H:MM:SS with places decimal places of
+// seconds. e.g. 3723 => 1:02:03 and 123.45 => 2:03.5 with places 1. Hours are omitted when they are zero.
+func NewClockFormatter(places int32) *Formatter {
+	return &Formatter{Clock: &Clock{Places: places}}
+}
+
+func (c *Clock) unit() time.Duration {
+	if c.Unit <= 0 {
+		return time.Second
+	}
+	return c.Unit
+}
+
+// components returns the number of components from hours down to Smallest.
+func (c *Clock) components() int {
+	switch c.Smallest {
+	case time.Hour:
+		return 1
+	case time.Minute:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// smallest returns the length of the smallest unit written.
+func (c *Clock) smallest() time.Duration {
+	return []time.Duration{time.Hour, time.Minute, time.Second}[c.components()-1]
+}
+
+// clockState returns the formatState for d written as hours, minutes, and seconds. The first component written is the
+// integer digits and the others are written as a common fraction is.
+func (f *Formatter) clockState(d decimal.Decimal) *formatState {
+	c := f.Clock
+	n := c.components()
+	smallest := d.Abs().Mul(decimal.NewFromInt(int64(c.unit()))).Div(decimal.NewFromInt(int64(c.smallest())))
+	values, rounded := splitBase60(smallest, n, c.Places)
+
+	start := 0
+	if n == 3 && values[0].IsZero() && !c.AlwaysHours {
+		start = 1
+	}
+
+	fs := &formatState{
+		f:         f,
+		neg:       d.Sign() < 0 && !allZero(values),
+		zero:      allZero(values),
+		ungrouped: true,
+		rounded:   rounded,
+
+		fillIndex: -1,
+	}
+
+	places := int32(0)
+	if start == n-1 {
+		places = c.Places
+	}
+	s := values[start].StringFixed(places)
+	fs.intPart = s
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		fs.intPart, fs.fracPart = s[:dot], s[dot+1:]
+	}
+	if c.PadFirst && len(fs.intPart) < 2 {
+		fs.intPart = "0" + fs.intPart
+	}
+
+	sb := &strings.Builder{}
+	for i := start + 1; i < n; i++ {
+		sb.WriteByte(':')
+		places := int32(0)
+		if i == n-1 {
+			places = c.Places
+		}
+		f.writeBase60Component(sb, values[i], places, true)
+	}
+	fs.fraction = sb.String()
+
+	return fs
+}
+
+// consumeClock consumes colon separated hours, minutes, and seconds such as 1:02:03 and writes the number in Clock
+// units. The components are aligned to the smallest unit so 2:03 is minutes and seconds when Smallest is time.Second.
+func (ps *parseState) consumeClock() bool {
+	c := ps.f.Clock
+	prefix := ps.num.String()
+	total := decimal.Decimal{}
+
+	for n := 0; ; n++ {
+		ps.num.Reset()
+		if !ps.consumeDigits() {
+			return ps.fail(ps.pos, ErrMissingDigits)
+		}
+		component, err := decimal.NewFromString(ps.num.String())
+		if err != nil {
+			return ps.fail(ps.pos, ErrUnexpectedCharacter)
+		}
+		total = total.Mul(decimal.New(60, 0)).Add(component)
+
+		if !strings.HasPrefix(ps.rest(), ":") || !ps.digitAt(ps.pos+1) || n == c.components()-1 {
+			break
+		}
+		ps.pos++
+	}
+
+	value := total.Mul(decimal.NewFromInt(int64(c.smallest()))).Div(decimal.NewFromInt(int64(c.unit())))
+	ps.num.Reset()
+	ps.num.WriteString(prefix)
+	ps.num.WriteString(value.String())
+	return true
+}
@@ -0,0 +1,77 @@
+package numfmt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterClock(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{numfmt.NewClockFormatter(0), 3723, "1:02:03"},
+		{numfmt.NewClockFormatter(0), 123, "2:03"},
+		{numfmt.NewClockFormatter(0), 0, "0:00"},
+		{numfmt.NewClockFormatter(0), -65, "-1:05"},
+		{numfmt.NewClockFormatter(0), 359999, "99:59:59"},
+		{numfmt.NewClockFormatter(0), 1234567, "342:56:07"},
+		{numfmt.NewClockFormatter(0), 59.6, "1:00"},
+		{numfmt.NewClockFormatter(1), 3599.96, "1:00:00.0"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Places: 3, PadFirst: true}}, 123.45, "02:03.450"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{AlwaysHours: true}}, 123, "0:02:03"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Smallest: time.Minute}}, 3723, "1:02"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Smallest: time.Minute}}, 59, "0:01"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Smallest: time.Hour, Places: 2}}, 5400, "1.50"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Unit: time.Minute}}, 90.5, "1:30:30"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Unit: time.Nanosecond, Places: 1}}, int64(90*time.Second + 250*time.Millisecond), "1:30.3"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Places: 2}, DecimalSeparator: ","}, 5.5, "0:05,50"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{}, Digits: numfmt.ArabicIndicDigits}, 3723, "١:٠٢:٠٣"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{}, Template: "n elapsed"}, 75, "1:15 elapsed"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterClockParse(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		s         string
+		expected  string
+	}{
+		{numfmt.NewClockFormatter(0), "1:02:03", "3723"},
+		{numfmt.NewClockFormatter(0), "2:03", "123"},
+		{numfmt.NewClockFormatter(0), "-1:05", "-65"},
+		{numfmt.NewClockFormatter(0), "45", "45"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Places: 3, PadFirst: true}}, "02:03.450", "123.45"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Smallest: time.Minute}}, "1:02", "3720"},
+		{&numfmt.Formatter{Clock: &numfmt.Clock{Unit: time.Minute}}, "1:30:30", "90.5"},
+	} {
+		actual, err := tt.formatter.Parse(tt.s)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, actual.String(), "%d", i)
+	}
+
+	_, err := numfmt.NewClockFormatter(0).Parse("1:02:03:04")
+	require.Error(t, err)
+}
+
+func TestTemplateFuncClock(t *testing.T) {
+	actual, err := numfmt.TemplateFunc("ClockPlaces", "3", "ClockPadFirst", "true", 123.45)
+	require.NoError(t, err)
+	assert.Equal(t, "02:03.450", actual)
+
+	actual, err = numfmt.TemplateFunc("ClockUnit", "1m", "ClockSmallest", "1m", 90)
+	require.NoError(t, err)
+	assert.Equal(t, "1:30", actual)
+
+	actual, err = numfmt.TemplateFunc("Preset", "clock", 3723)
+	require.NoError(t, err)
+	assert.Equal(t, "1:02:03", actual)
+}
@@ -0,0 +1,322 @@
+// Command numfmt-locales writes Go source that registers number formatting data for a selected set of locales with
+// numfmt.RegisterLocale. The data is read from the CLDR JSON distribution. Build with the numfmt_nolocales tag to
+// leave out numfmt's built-in locales so that a binary embeds only the locales it needs. Without the tag the generated
+// locales are added to the built-in ones. e.g.
+//
+//   //go:generate go run github.com/jackc/numfmt/cmd/numfmt-locales -cldr ../cldr-json/cldr-numbers-full/main -o locales.go de-CH fr-CA
+//
+// -cldr is the main directory of cldr-numbers-full (or cldr-numbers-modern). The optional -units flag is the main
+// directory of cldr-units-full and is used to set the separator between numbers and units. -pkg defaults to the
+// package go generate is run for.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/numfmt"
+)
+
+func main() {
+	cldrDir := flag.String("cldr", "", "main directory of cldr-numbers-full")
+	unitsDir := flag.String("units", "", "main directory of cldr-units-full (optional)")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated file")
+	out := flag.String("o", "numfmt_locales.go", "output file or - for stdout")
+	flag.Parse()
+
+	if err := run(*cldrDir, *unitsDir, *pkg, *out, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "numfmt-locales:", err)
+		os.Exit(1)
+	}
+}
+
+func run(cldrDir, unitsDir, pkg, out string, ids []string) error {
+	if cldrDir == "" {
+		return errors.New("-cldr is required")
+	}
+	if pkg == "" {
+		pkg = "main"
+	}
+	if len(ids) == 0 {
+		return errors.New("no locales given")
+	}
+
+	src, err := generate(cldrDir, unitsDir, pkg, ids)
+	if err != nil {
+		return err
+	}
+
+	if out == "-" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
+
+// localeData mirrors numfmt.LocaleData.
+type localeData struct {
+	id                string
+	groupSeparator    string
+	decimalSeparator  string
+	digits            string // Name of a numfmt digits constant.
+	unitSeparator     string
+	currencySuffix    bool
+	currencySeparator string
+	grouping          []int
+	minGroupingDigits int
+	negativeTemplate  string
+}
+
+// generate returns the formatted Go source registering the locales ids.
+func generate(cldrDir, unitsDir, pkg string, ids []string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by numfmt-locales; DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package %s\n\n", pkg)
+	fmt.Fprintf(buf, "import (\n\"github.com/jackc/numfmt\"\n\"golang.org/x/text/language\"\n)\n\n")
+	fmt.Fprintf(buf, "func init() {\n")
+	for _, id := range ids {
+		l, err := readLocale(cldrDir, unitsDir, id)
+		if err != nil {
+			return nil, err
+		}
+		writeLocale(buf, l)
+	}
+	fmt.Fprintf(buf, "}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+func writeLocale(buf *bytes.Buffer, l *localeData) {
+	fmt.Fprintf(buf, "numfmt.RegisterLocale(numfmt.LocaleData{\nTag: language.MustParse(%q),\n", l.id)
+	fmt.Fprintf(buf, "GroupSeparator: %s,\n", goString(l.groupSeparator))
+	fmt.Fprintf(buf, "DecimalSeparator: %s,\n", goString(l.decimalSeparator))
+	if l.digits != "" {
+		fmt.Fprintf(buf, "Digits: numfmt.%s,\n", l.digits)
+	}
+	if l.unitSeparator != "" {
+		fmt.Fprintf(buf, "UnitSeparator: %s,\n", goString(l.unitSeparator))
+	}
+	if l.currencySuffix {
+		fmt.Fprintf(buf, "CurrencySuffix: true,\n")
+	}
+	if l.currencySeparator != "" {
+		fmt.Fprintf(buf, "CurrencySeparator: %s,\n", goString(l.currencySeparator))
+	}
+	if l.grouping != nil {
+		fmt.Fprintf(buf, "Grouping: numfmt.GroupingPattern{%d, %d},\n", l.grouping[0], l.grouping[1])
+	}
+	if l.minGroupingDigits != 0 {
+		fmt.Fprintf(buf, "MinGroupingDigits: %d,\n", l.minGroupingDigits)
+	}
+	if l.negativeTemplate != "" {
+		fmt.Fprintf(buf, "NegativeTemplate: %q,\n", l.negativeTemplate)
+	}
+	fmt.Fprintf(buf, "})\n")
+}
+
+// goString returns s as a Go expression using the numfmt space constants where possible.
+func goString(s string) string {
+	switch s {
+	case "\u00a0":
+		return "numfmt.NoBreakSpace"
+	case "\u202f":
+		return "numfmt.NarrowNoBreakSpace"
+	default:
+		return fmt.Sprintf("%q", s)
+	}
+}
+
+// digitsConstants maps CLDR numbering systems to the numfmt constants for their digits. latn uses the default digits.
+var digitsConstants = map[string]string{
+	"latn":    "",
+	"arab":    "ArabicIndicDigits",
+	"arabext": "ExtendedArabicIndicDigits",
+	"deva":    "DevanagariDigits",
+	"beng":    "BengaliDigits",
+	"thai":    "ThaiDigits",
+}
+
+// readLocale reads the data for the locale id from the numbers.json file in cldrDir and, if unitsDir is not empty,
+// the units.json file in unitsDir.
+func readLocale(cldrDir, unitsDir, id string) (*localeData, error) {
+	var numbers map[string]json.RawMessage
+	if err := readMain(filepath.Join(cldrDir, id, "numbers.json"), id, "numbers", &numbers); err != nil {
+		return nil, err
+	}
+
+	var system string
+	if err := json.Unmarshal(numbers["defaultNumberingSystem"], &system); err != nil {
+		return nil, fmt.Errorf("%s: defaultNumberingSystem: %v", id, err)
+	}
+	digits, ok := digitsConstants[system]
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported numbering system: %s", id, system)
+	}
+
+	var symbols struct {
+		Decimal   string `json:"decimal"`
+		Group     string `json:"group"`
+		MinusSign string `json:"minusSign"`
+	}
+	if err := json.Unmarshal(numbers["symbols-numberSystem-"+system], &symbols); err != nil {
+		return nil, fmt.Errorf("%s: symbols: %v", id, err)
+	}
+
+	l := &localeData{
+		id:               id,
+		groupSeparator:   symbols.Group,
+		decimalSeparator: symbols.Decimal,
+		digits:           digits,
+	}
+
+	decimalFormats := struct {
+		Standard string `json:"standard"`
+	}{Standard: "#,##0.###"}
+	if raw, ok := numbers["decimalFormats-numberSystem-"+system]; ok {
+		if err := json.Unmarshal(raw, &decimalFormats); err != nil {
+			return nil, fmt.Errorf("%s: decimalFormats: %v", id, err)
+		}
+	}
+	primary, secondary := parseGroupingPattern(decimalFormats.Standard)
+	if secondary != primary {
+		l.grouping = []int{primary, secondary}
+	}
+	if raw, ok := numbers["minimumGroupingDigits"]; ok {
+		var minimum string
+		if err := json.Unmarshal(raw, &minimum); err != nil {
+			return nil, fmt.Errorf("%s: minimumGroupingDigits: %v", id, err)
+		}
+		n, err := strconv.Atoi(minimum)
+		if err != nil {
+			return nil, fmt.Errorf("%s: minimumGroupingDigits: %v", id, err)
+		}
+		if n > 1 {
+			l.minGroupingDigits = primary + n
+		}
+	}
+	l.negativeTemplate = negativeTemplate(decimalFormats.Standard, symbols.MinusSign)
+
+	var currencyFormats struct {
+		Standard string `json:"standard"`
+	}
+	if raw, ok := numbers["currencyFormats-numberSystem-"+system]; ok {
+		if err := json.Unmarshal(raw, &currencyFormats); err != nil {
+			return nil, fmt.Errorf("%s: currencyFormats: %v", id, err)
+		}
+		l.currencySuffix, l.currencySeparator = parseCurrencyPattern(currencyFormats.Standard)
+	}
+
+	if unitsDir != "" {
+		var units struct {
+			Short map[string]map[string]interface{} `json:"short"`
+		}
+		if err := readMain(filepath.Join(unitsDir, id, "units.json"), id, "units", &units); err != nil {
+			return nil, err
+		}
+		if pattern, ok := units.Short["length-meter"]["unitPattern-count-other"].(string); ok {
+			l.unitSeparator = unitSeparator(pattern)
+		}
+	}
+
+	return l, nil
+}
+
+// readMain decodes main.<id>.<key> of the CLDR JSON file at path into v.
+func readMain(path, id, key string, v interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var doc struct {
+		Main map[string]map[string]json.RawMessage `json:"main"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	raw, ok := doc.Main[id][key]
+	if !ok {
+		return fmt.Errorf("%s: missing main.%s.%s", path, id, key)
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// parseCurrencyPattern returns whether the currency sign ¤ follows the number in the positive part of the CLDR
+// pattern and the separator between them. The separator is empty if it is the Formatter default: NoBreakSpace when
+// the symbol follows the number and nothing when it precedes it.
+func parseCurrencyPattern(pattern string) (suffix bool, separator string) {
+	pattern = strings.SplitN(pattern, ";", 2)[0]
+	sign := strings.IndexRune(pattern, '¤')
+	first := strings.IndexAny(pattern, "#0")
+	last := strings.LastIndexAny(pattern, "#0")
+	if sign < 0 || first < 0 {
+		return false, ""
+	}
+
+	if sign > first {
+		separator = pattern[last+1 : sign]
+		if separator == "\u00a0" {
+			separator = ""
+		}
+		return true, separator
+	}
+	return false, pattern[sign+len("¤") : first]
+}
+
+// parseGroupingPattern returns the primary and secondary group widths of the positive part of the CLDR pattern. e.g.
+// 3 and 2 for "#,##,##0.###". Both are 3 if the pattern has no group separator.
+func parseGroupingPattern(pattern string) (primary, secondary int) {
+	integer := strings.SplitN(strings.SplitN(pattern, ";", 2)[0], ".", 2)[0]
+	integer = strings.TrimRight(integer[:strings.LastIndexAny(integer, "#0")+1], " ")
+	groups := strings.Split(integer, ",")
+	if len(groups) < 2 {
+		return 3, 3
+	}
+	primary = len(groups[len(groups)-1])
+	secondary = primary
+	if len(groups) > 2 {
+		secondary = len(groups[len(groups)-2])
+	}
+	return primary, secondary
+}
+
+// negativeTemplate returns the numfmt template for negative numbers of the CLDR pattern with the minus sign minusSign.
+// It is empty if it is the numfmt default of a minus sign before the number.
+func negativeTemplate(pattern, minusSign string) string {
+	parts := strings.SplitN(pattern, ";", 2)
+	negative := "-" + parts[0]
+	if len(parts) == 2 {
+		negative = parts[1]
+	}
+	first := strings.IndexAny(negative, "#0")
+	last := strings.LastIndexAny(negative, "#0")
+	if first < 0 {
+		return ""
+	}
+
+	prefix := strings.ReplaceAll(negative[:first], "-", minusSign)
+	suffix := strings.ReplaceAll(negative[last+1:], "-", minusSign)
+	if prefix == "-" && suffix == "" {
+		return ""
+	}
+	return numfmt.EscapeTemplate(prefix) + "n" + numfmt.EscapeTemplate(suffix)
+}
+
+// unitSeparator returns the text between the number and the unit of a CLDR unit pattern such as "{0} m".
+func unitSeparator(pattern string) string {
+	i := strings.Index(pattern, "{0}")
+	if i != 0 {
+		return ""
+	}
+	rest := pattern[len("{0}"):]
+	return rest[:len(rest)-len(strings.TrimLeft(rest, " \u00a0\u202f"))]
+}
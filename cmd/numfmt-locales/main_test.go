@@ -0,0 +1,87 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	expected, err := ioutil.ReadFile("testdata/locales.golden")
+	require.NoError(t, err)
+
+	actual, err := generate("testdata/numbers", "", "locales", []string{"de-CH", "fr-CA", "ar-EG", "hi-IN", "pl"})
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestGenerateUnits(t *testing.T) {
+	actual, err := generate("testdata/numbers", "testdata/units", "locales", []string{"fr-CA"})
+	require.NoError(t, err)
+	assert.Contains(t, string(actual), "UnitSeparator:    numfmt.NoBreakSpace,")
+}
+
+func TestGenerateError(t *testing.T) {
+	_, err := generate("testdata/numbers", "", "locales", []string{"xx"})
+	assert.Error(t, err)
+
+	err = run("", "", "locales", "-", []string{"de-CH"})
+	assert.Error(t, err)
+
+	err = run("testdata/numbers", "", "locales", "-", nil)
+	assert.Error(t, err)
+}
+
+func TestParseCurrencyPattern(t *testing.T) {
+	for i, tt := range []struct {
+		pattern   string
+		suffix    bool
+		separator string
+	}{
+		{"¤#,##0.00", false, ""},
+		{"¤\u00a0#,##0.00;¤-#,##0.00", false, "\u00a0"},
+		{"#,##0.00\u00a0¤", true, ""},
+		{"#,##0.00 ¤", true, " "},
+		{"#,##0", false, ""},
+	} {
+		suffix, separator := parseCurrencyPattern(tt.pattern)
+		assert.Equalf(t, tt.suffix, suffix, "%d", i)
+		assert.Equalf(t, tt.separator, separator, "%d", i)
+	}
+}
+
+func TestParseGroupingPattern(t *testing.T) {
+	for i, tt := range []struct {
+		pattern   string
+		primary   int
+		secondary int
+	}{
+		{"#,##0.###", 3, 3},
+		{"#,##,##0.###", 3, 2},
+		{"#,###0.###", 4, 4},
+		{"#0.###", 3, 3},
+		{"#,##0.00 ¤", 3, 3},
+	} {
+		primary, secondary := parseGroupingPattern(tt.pattern)
+		assert.Equalf(t, tt.primary, primary, "%d", i)
+		assert.Equalf(t, tt.secondary, secondary, "%d", i)
+	}
+}
+
+func TestNegativeTemplate(t *testing.T) {
+	for i, tt := range []struct {
+		pattern   string
+		minusSign string
+		expected  string
+	}{
+		{"#,##0.###", "-", ""},
+		{"#,##0.###", "\u2212", "\u2212n"},
+		{"#,##0.###", "\u200e-", "\u200e\\-n"},
+		{"#,##0.###;(#,##0.###)", "-", "(n)"},
+		{"#,##0.###;#,##0.###-", "-", "n\\-"},
+	} {
+		assert.Equalf(t, tt.expected, negativeTemplate(tt.pattern, tt.minusSign), "%d", i)
+	}
+}
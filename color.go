@@ -0,0 +1,65 @@
+package numfmt
+
+import (
+	"os"
+	"strings"
+)
+
+// ANSIColors are ANSI SGR parameters used to color formatted numbers for terminals. e.g. "31" is red and "1;32" is
+// bold green. An empty string leaves those numbers uncolored.
+type ANSIColors struct {
+	Positive string
+	Negative string
+	Zero     string
+}
+
+// DefaultANSIColors colors positive numbers green and negative numbers red.
+var DefaultANSIColors = &ANSIColors{Positive: "32", Negative: "31"}
+
+// colorsEnabled reports whether ANSI colors should be written. Following https://no-color.org colors are disabled
+// when the NO_COLOR environment variable is set to a non-empty value.
+func colorsEnabled() bool {
+	return os.Getenv("NO_COLOR") == ""
+}
+
+// colorize wraps s in the ANSI escape sequences for the color of fs.
+func (c *ANSIColors) colorize(s string, fs *formatState) string {
+	var sgr string
+	switch {
+	case fs.zero:
+		sgr = c.Zero
+	case fs.neg:
+		sgr = c.Negative
+	default:
+		sgr = c.Positive
+	}
+	if sgr == "" || !colorsEnabled() {
+		return s
+	}
+
+	return "\x1b[" + sgr + "m" + s + "\x1b[0m"
+}
+
+// stripANSI removes ANSI SGR escape sequences from s.
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b[") {
+		return s
+	}
+
+	sb := &strings.Builder{}
+	for {
+		start := strings.Index(s, "\x1b[")
+		if start == -1 {
+			break
+		}
+		end := strings.IndexByte(s[start:], 'm')
+		if end == -1 {
+			break
+		}
+		sb.WriteString(s[:start])
+		s = s[start+end+1:]
+	}
+	sb.WriteString(s)
+
+	return sb.String()
+}
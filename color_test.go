@@ -0,0 +1,43 @@
+package numfmt_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterColors(t *testing.T) {
+	noColor, noColorSet := os.LookupEnv("NO_COLOR")
+	os.Unsetenv("NO_COLOR")
+	defer func() {
+		if noColorSet {
+			os.Setenv("NO_COLOR", noColor)
+		}
+	}()
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Colors: numfmt.DefaultANSIColors}, "1234", "\x1b[32m1,234\x1b[0m"},
+		{&numfmt.Formatter{Colors: numfmt.DefaultANSIColors}, "-1234", "\x1b[31m-1,234\x1b[0m"},
+		{&numfmt.Formatter{Colors: numfmt.DefaultANSIColors}, "0", "0"},
+		{&numfmt.Formatter{Colors: &numfmt.ANSIColors{Zero: "2"}}, "0", "\x1b[2m0\x1b[0m"},
+		{&numfmt.Formatter{Colors: numfmt.DefaultANSIColors, MinWidth: 4}, "-1", "\x1b[31m  -1\x1b[0m"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	d, err := (&numfmt.Formatter{}).Parse("\x1b[31m-1,234\x1b[0m")
+	require.NoError(t, err)
+	assert.Equal(t, "-1234", d.String())
+
+	os.Setenv("NO_COLOR", "1")
+	defer os.Unsetenv("NO_COLOR")
+	assert.Equal(t, "-1,234", (&numfmt.Formatter{Colors: numfmt.DefaultANSIColors}).Format("-1234"))
+}
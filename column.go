@@ -0,0 +1,140 @@
+package numfmt
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// FormatColumn formats values and pads the results with spaces so they are all the same width and their decimal
+// separators line up. Values without a decimal separator are aligned as if one followed their last digit. Widths are
+// measured in runes, so it is intended for monospaced output such as terminal tables.
+func (f *Formatter) FormatColumn(values []interface{}) []string {
+	f = f.compiled()
+
+	lefts := make([]string, len(values))
+	rights := make([]string, len(values))
+	maxLeft, maxRight := 0, 0
+	for i, v := range values {
+		s := f.Format(v)
+		split := f.alignmentIndex(s)
+		lefts[i], rights[i] = s[:split], s[split:]
+		if n := utf8.RuneCountInString(lefts[i]); n > maxLeft {
+			maxLeft = n
+		}
+		if n := utf8.RuneCountInString(rights[i]); n > maxRight {
+			maxRight = n
+		}
+	}
+
+	results := make([]string, len(values))
+	sb := &strings.Builder{}
+	for i := range values {
+		sb.Reset()
+		writePadding(sb, " ", maxLeft-utf8.RuneCountInString(lefts[i]))
+		sb.WriteString(lefts[i])
+		sb.WriteString(rights[i])
+		writePadding(sb, " ", maxRight-utf8.RuneCountInString(rights[i]))
+		results[i] = sb.String()
+	}
+
+	return results
+}
+
+// alignmentIndex returns the index in s of the decimal separator. If s does not contain the decimal separator it
+// returns the index after the last digit in s. If s does not contain any digits it returns len(s).
+func (f *Formatter) alignmentIndex(s string) int {
+	if idx := strings.LastIndex(s, f.decimalSeparator()); idx != -1 {
+		return idx
+	}
+
+	for i := len(s); i > 0; {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		if f.isDigit(r) {
+			return i
+		}
+		i -= size
+	}
+
+	return len(s)
+}
+
+func (f *Formatter) isDigit(r rune) bool {
+	if r >= '0' && r <= '9' {
+		return true
+	}
+	for _, d := range f.digits {
+		if string(r) == d {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Formatter) fill() string {
+	if f.Fill != "" {
+		return f.Fill
+	}
+	return " "
+}
+
+// pad pads s with Fill to f.MinWidth according to f.Alignment.
+func (f *Formatter) pad(s string) string {
+	width := utf8.RuneCountInString(s)
+	if width >= f.MinWidth {
+		return s
+	}
+
+	fill := f.fill()
+	sb := &strings.Builder{}
+	switch f.Alignment {
+	case AlignLeft:
+		sb.WriteString(s)
+		writePadding(sb, fill, f.MinWidth-width)
+	case AlignDecimal:
+		places := int(f.minDecimalPlaces())
+		if r := f.rounder(); r != nil && int(r.Places) > places {
+			places = int(r.Places)
+		}
+		split := f.alignmentIndex(s)
+		rightWidth := utf8.RuneCountInString(s[split:])
+		rightPadding := 0
+		if places > 0 {
+			rightPadding = utf8.RuneCountInString(f.decimalSeparator()) + places - rightWidth
+			if rightPadding < 0 {
+				rightPadding = 0
+			}
+		}
+		leftPadding := f.MinWidth - width - rightPadding
+		if leftPadding < 0 {
+			leftPadding = 0
+		}
+		writePadding(sb, fill, leftPadding)
+		sb.WriteString(s)
+		writePadding(sb, fill, rightPadding)
+	default:
+		writePadding(sb, fill, f.MinWidth-width)
+		sb.WriteString(s)
+	}
+
+	return sb.String()
+}
+
+// padAt pads s with Fill to f.MinWidth by inserting the padding at byte index idx.
+func (f *Formatter) padAt(s string, idx int) string {
+	width := utf8.RuneCountInString(s)
+	if width >= f.MinWidth {
+		return s
+	}
+
+	sb := &strings.Builder{}
+	sb.WriteString(s[:idx])
+	writePadding(sb, f.fill(), f.MinWidth-width)
+	sb.WriteString(s[idx:])
+	return sb.String()
+}
+
+func writePadding(sb *strings.Builder, pad string, n int) {
+	for i := 0; i < n; i++ {
+		sb.WriteString(pad)
+	}
+}
@@ -0,0 +1,50 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFormatColumn(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		args      []interface{}
+		expected  []string
+	}{
+		{
+			&numfmt.Formatter{},
+			[]interface{}{"1234.5", "-1", "0.125", "12"},
+			[]string{"1,234.5  ", "   -1    ", "    0.125", "   12    "},
+		},
+		{
+			numfmt.NewPercentFormatter(),
+			[]interface{}{"0.015", "1", "0.2"},
+			[]string{"  1.5%", "100%  ", " 20%  "},
+		},
+		{
+			&numfmt.Formatter{DecimalSeparator: ",", GroupSeparator: numfmt.NarrowNoBreakSpace},
+			[]interface{}{"1234.5", "1.25"},
+			[]string{"1\u202f234,5 ", "    1,25"},
+		},
+		{
+			&numfmt.Formatter{Digits: numfmt.ArabicIndicDigits, DecimalSeparator: "٫"},
+			[]interface{}{"12", "1.5"},
+			[]string{"١٢  ", " ١٫٥"},
+		},
+		{
+			&numfmt.Formatter{},
+			[]interface{}{"n/a", "1.5"},
+			[]string{"n/a  ", "  1.5"},
+		},
+		{
+			&numfmt.Formatter{},
+			[]interface{}{},
+			[]string{},
+		},
+	} {
+		actual := tt.formatter.FormatColumn(tt.args)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,58 @@
+package numfmt
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// CompiledFormatter is an immutable compiled copy of a Formatter returned by Formatter.Compile. Unlike Formatter it
+// cannot be changed, so it is a small value that can be copied, embedded in structs, and passed between goroutines
+// after use. The zero value formats the same as the zero value Formatter.
+type CompiledFormatter struct {
+	f *Formatter
+}
+
+// zeroFormatter is used by the zero value CompiledFormatter.
+var zeroFormatter = &Formatter{}
+
+// Compile returns a CompiledFormatter with the configuration of f. f is not used and may still be changed. Pointer
+// fields such as Rounder and Humanizer are shared with f and must not be changed.
+func (f *Formatter) Compile() CompiledFormatter {
+	return CompiledFormatter{f: NewFormatter(f)}
+}
+
+func (cf CompiledFormatter) formatter() *Formatter {
+	if cf.f == nil {
+		return zeroFormatter
+	}
+	return cf.f
+}
+
+// Formatter returns a new Formatter with the configuration of cf that can be changed.
+func (cf CompiledFormatter) Formatter() *Formatter {
+	return cf.formatter().clone()
+}
+
+// Format formats v. See Formatter.Format.
+func (cf CompiledFormatter) Format(v interface{}) string {
+	return cf.formatter().Format(v)
+}
+
+// FormatParts formats v and returns its components. See Formatter.FormatParts.
+func (cf CompiledFormatter) FormatParts(v interface{}) (Parts, bool) {
+	return cf.formatter().FormatParts(v)
+}
+
+// FormatResult formats v and returns metadata about the formatting. See Formatter.FormatResult.
+func (cf CompiledFormatter) FormatResult(v interface{}) Result {
+	return cf.formatter().FormatResult(v)
+}
+
+// Parse parses s. See Formatter.Parse.
+func (cf CompiledFormatter) Parse(s string) (decimal.Decimal, error) {
+	return cf.formatter().Parse(s)
+}
+
+// ParseStrict parses s and requires it to match exactly. See Formatter.ParseStrict.
+func (cf CompiledFormatter) ParseStrict(s string) (decimal.Decimal, error) {
+	return cf.formatter().ParseStrict(s)
+}
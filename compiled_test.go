@@ -0,0 +1,107 @@
+package numfmt_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterCompile(t *testing.T) {
+	f := numfmt.NewUSDFormatter()
+	cf := f.Compile()
+
+	f.Template = "n"
+	assert.Equal(t, "$1,234.50", cf.Format("1234.5"))
+	assert.Equal(t, "1,234.50", f.Format("1234.5"))
+
+	copied := cf
+	assert.Equal(t, "-$1,234.50", copied.Format("-1234.5"))
+
+	d, err := cf.Parse("-$1,234.50")
+	require.NoError(t, err)
+	assert.Equal(t, "-1234.5", d.String())
+
+	c := cf.Formatter()
+	c.MinDecimalPlaces = 0
+	assert.Equal(t, "$7", c.Format(7))
+	assert.Equal(t, "$7.00", cf.Format(7))
+}
+
+func TestCompiledFormatterZeroValue(t *testing.T) {
+	var cf numfmt.CompiledFormatter
+	assert.Equal(t, "1,234.5", cf.Format("1234.5"))
+}
+
+func TestCompiledFormatterConcurrent(t *testing.T) {
+	type row struct {
+		amount numfmt.CompiledFormatter
+	}
+	r := row{amount: numfmt.NewUSDFormatter().Compile()}
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(r row) {
+			defer wg.Done()
+			assert.Equal(t, "$1.00", r.amount.Format(1))
+		}(r)
+	}
+	wg.Wait()
+}
+
+func TestNewFormatter(t *testing.T) {
+	cfg := numfmt.NewUSDFormatter()
+	f := numfmt.NewFormatter(cfg)
+
+	cfg.Template = "n"
+	assert.Equal(t, "$1,234.50", f.Format("1234.5"))
+	assert.Equal(t, "-$1,234.50", f.Format(-1234.5))
+	assert.Equal(t, "$7.00", f.Format(7))
+
+	d, err := f.Parse("$1,234.50")
+	require.NoError(t, err)
+	assert.Equal(t, "1234.5", d.String())
+
+	assert.Equal(t, "1,234.5", numfmt.NewFormatter(nil).Format("1234.5"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.Equal(t, "$12.00", f.Format(12))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFormatterCopy(t *testing.T) {
+	type config struct {
+		Amount numfmt.Formatter
+	}
+
+	a := config{Amount: numfmt.Formatter{Template: "$n", MinDecimalPlaces: 2}}
+	assert.Equal(t, "$1,234.50", a.Amount.Format("1234.5"))
+
+	b := a
+	b.Amount.Template = "n €"
+	b.Amount.GroupSeparator = "."
+	b.Amount.DecimalSeparator = ","
+	assert.Equal(t, "1.234,50 €", b.Amount.Format("1234.5"))
+	assert.Equal(t, "$1,234.50", a.Amount.Format("1234.5"))
+
+	d, err := b.Amount.Parse("1.234,50 €")
+	require.NoError(t, err)
+	assert.Equal(t, "1234.5", d.String())
+
+	compiled := numfmt.NewFormatter(&numfmt.Formatter{Template: "n%"})
+	c := *compiled
+	c.Template = "(n)"
+	assert.Equal(t, "(5)", c.Format(5))
+	assert.Equal(t, "5%", compiled.Format(5))
+}
@@ -0,0 +1,57 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// LoadFormatters reads a JSON object that maps names to Formatter definitions and returns the Formatters by name. A
+// definition is either a spec string as parsed by ParseSpec or an object of TemplateFunc keys and values.
+//
+//   {
+//     "price": {"Preset": "usd", "RoundPlaces": 0},
+//     "share": "preset=percent;round=1"
+//   }
+//
+// The Formatters can be made available to TemplateFunc and FormatStruct with Register.
+func LoadFormatters(r io.Reader) (map[string]*Formatter, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var defs map[string]interface{}
+	err := dec.Decode(&defs)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFormatters(defs)
+}
+
+// NewFormatters returns Formatters by name built from definitions in the form read by LoadFormatters. It can be used
+// with documents in other formats such as YAML or TOML after they have been decoded into a map.
+func NewFormatters(defs map[string]interface{}) (map[string]*Formatter, error) {
+	formatters := make(map[string]*Formatter, len(defs))
+	for name, def := range defs {
+		f, err := newFormatterFromDefinition(def)
+		if err != nil {
+			return nil, fmt.Errorf("formatter %s: %w", name, err)
+		}
+		formatters[name] = f
+	}
+	return formatters, nil
+}
+
+func newFormatterFromDefinition(def interface{}) (*Formatter, error) {
+	switch def := def.(type) {
+	case string:
+		return ParseSpec(def)
+	case map[string]interface{}:
+		return newFormatterFromArgs(mapArgs(def))
+	default:
+		return nil, fmt.Errorf("invalid definition: %v", def)
+	}
+}
@@ -0,0 +1,37 @@
+package numfmt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFormatters(t *testing.T) {
+	formatters, err := numfmt.LoadFormatters(strings.NewReader(`{
+		"price": {"RoundPlaces": 0, "MinDecimalPlaces": 0, "Preset": "usd"},
+		"share": "preset=percent;round=1",
+		"exact": {"GroupSeparator": " ", "Shift": 2, "MinDecimalPlaces": 2, "PreserveScale": true}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, formatters, 3)
+
+	assert.Equal(t, "$1,235", formatters["price"].Format("1234.5"))
+	assert.Equal(t, "12.3%", formatters["share"].Format("0.12345"))
+	assert.Equal(t, "123 400.00", formatters["exact"].Format("1234"))
+}
+
+func TestLoadFormattersError(t *testing.T) {
+	for i, doc := range []string{
+		`[]`,
+		`{"a": 1}`,
+		`{"a": {"Foo": "bar"}}`,
+		`{"a": "round=x"}`,
+		`{"a": {"RoundPlaces": "x"}}`,
+	} {
+		_, err := numfmt.LoadFormatters(strings.NewReader(doc))
+		assert.Errorf(t, err, "%d", i)
+	}
+}
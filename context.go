@@ -0,0 +1,53 @@
+package numfmt
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+)
+
+type localeContextKey struct{}
+
+// WithLocale returns a copy of ctx with the locale tag. Formatting with FormatContext and ctx uses the separators,
+// grouping, and digits of the locale. It is intended for middleware that negotiates a request-scoped locale.
+func WithLocale(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, tag)
+}
+
+// LocaleFromContext returns the locale tag set by WithLocale.
+func LocaleFromContext(ctx context.Context) (tag language.Tag, ok bool) {
+	tag, ok = ctx.Value(localeContextKey{}).(language.Tag)
+	return tag, ok
+}
+
+// contextFormatter is the Formatter used by the FormatContext function.
+var contextFormatter = &Formatter{}
+
+// FormatContext formats v with the locale set by WithLocale as NewLocaleFormatter would. If ctx has no locale the
+// zero Formatter is used.
+func FormatContext(ctx context.Context, v interface{}) string {
+	return contextFormatter.FormatContext(ctx, v)
+}
+
+// FormatContext formats v like Format but with the separators, grouping, and digits of the locale set by
+// WithLocale. All other options of f are kept. If ctx has no locale f is used unchanged.
+func (f *Formatter) FormatContext(ctx context.Context, v interface{}) string {
+	tag, ok := LocaleFromContext(ctx)
+	if !ok {
+		return f.Format(v)
+	}
+	return f.localize(matchLocale(tag)).Format(v)
+}
+
+// localize returns a copy of f with the locale l applied. Copies are cached in f.
+func (f *Formatter) localize(l *locale) *Formatter {
+	f = f.compiled()
+	if lf, ok := f.localized.Load(l); ok {
+		return lf.(*Formatter)
+	}
+
+	lf := f.clone()
+	l.apply(lf)
+	actual, _ := f.localized.LoadOrStore(l, lf)
+	return actual.(*Formatter)
+}
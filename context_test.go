@@ -0,0 +1,32 @@
+package numfmt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestFormatContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "1,234.5", numfmt.FormatContext(ctx, "1234.5"))
+
+	_, ok := numfmt.LocaleFromContext(ctx)
+	assert.False(t, ok)
+
+	ctx = numfmt.WithLocale(ctx, language.German)
+	assert.Equal(t, "1.234,5", numfmt.FormatContext(ctx, "1234.5"))
+
+	tag, ok := numfmt.LocaleFromContext(ctx)
+	if assert.True(t, ok) {
+		assert.Equal(t, language.German, tag)
+	}
+
+	f := &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}, Template: "n €"}
+	assert.Equal(t, "1.234,57 €", f.FormatContext(ctx, "1234.567"))
+	assert.Equal(t, "1.234,57 €", f.FormatContext(ctx, "1234.567"))
+	assert.Equal(t, "1,234.57 €", f.FormatContext(context.Background(), "1234.567"))
+	assert.Equal(t, "1\u202f234,57 €", f.FormatContext(numfmt.WithLocale(ctx, language.French), "1234.567"))
+}
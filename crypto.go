@@ -0,0 +1,17 @@
+package numfmt
+
+// NewCryptoFormatter returns a Formatter for amounts of a cryptocurrency with symbol written after the number and up
+// to decimals decimal places. Insignificant trailing zeros are not written. e.g. 0.00012300 => 0.000123 BTC with "BTC"
+// and 8. If minorUnits is set amounts are integers of the smallest unit such as satoshi or wei and are shifted by
+// decimals. e.g. 12300 => 0.000123 BTC with "BTC" and 8 or 21000000000 wei => 21 gwei with "gwei" and 9. Use a string,
+// *big.Int, or decimal.Decimal for amounts that do not fit in an int64 or float64 without losing precision.
+func NewCryptoFormatter(symbol string, decimals int32, minorUnits bool) *Formatter {
+	f := &Formatter{
+		Rounder:  &Rounder{Places: decimals},
+		Template: "-n " + EscapeTemplate(symbol),
+	}
+	if minorUnits {
+		f.Shift = -decimals
+	}
+	return f
+}
@@ -0,0 +1,40 @@
+package numfmt_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCryptoFormatter(t *testing.T) {
+	wei, _ := new(big.Int).SetString("1234567890123456789012", 10)
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{numfmt.NewCryptoFormatter("BTC", 8, false), "0.00012300", "0.000123 BTC"},
+		{numfmt.NewCryptoFormatter("BTC", 8, false), "1.123456789", "1.12345679 BTC"},
+		{numfmt.NewCryptoFormatter("BTC", 8, false), "-21000000", "-21,000,000 BTC"},
+		{numfmt.NewCryptoFormatter("BTC", 8, true), 12300, "0.000123 BTC"},
+		{numfmt.NewCryptoFormatter("ETH", 18, false), "0.000000000000000001", "0.000000000000000001 ETH"},
+		{numfmt.NewCryptoFormatter("ETH", 18, true), wei, "1,234.567890123456789012 ETH"},
+		{numfmt.NewCryptoFormatter("gwei", 9, true), 21000000000, "21 gwei"},
+		{numfmt.NewCryptoFormatter("sat", 0, false), "1500.4", "1,500 sat"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	actual, err := numfmt.TemplateFunc("Preset", "eth", "0.0100")
+	require.NoError(t, err)
+	assert.Equal(t, "0.01 ETH", actual)
+
+	d, err := numfmt.NewCryptoFormatter("BTC", 8, false).Parse("0.000123 BTC")
+	require.NoError(t, err)
+	assert.Equal(t, "0.000123", d.String())
+}
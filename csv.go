@@ -0,0 +1,60 @@
+package numfmt
+
+import (
+	"encoding/csv"
+	"fmt"
+)
+
+// CSVWriter writes records to a csv.Writer formatting each column with its own Formatter.
+type CSVWriter struct {
+	w          *csv.Writer
+	formatters []*Formatter
+	record     []string
+}
+
+// NewCSVWriter returns a CSVWriter that writes to w. formatters[i] formats column i. Columns without a Formatter or
+// with a nil Formatter are written with fmt.Sprint.
+func NewCSVWriter(w *csv.Writer, formatters ...*Formatter) *CSVWriter {
+	return &CSVWriter{w: w, formatters: formatters}
+}
+
+// Write formats record and writes it. nil values are written as empty fields. As with csv.Writer, writes are buffered
+// and Flush must be called to ensure the record is written to the underlying io.Writer.
+func (w *CSVWriter) Write(record []interface{}) error {
+	w.record = w.record[:0]
+	for i, v := range record {
+		w.record = append(w.record, w.formatField(i, v))
+	}
+	return w.w.Write(w.record)
+}
+
+// WriteAll formats and writes records and then calls Flush.
+func (w *CSVWriter) WriteAll(records [][]interface{}) error {
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *CSVWriter) Flush() {
+	w.w.Flush()
+}
+
+// Error reports any error that has occurred during a previous Write or Flush.
+func (w *CSVWriter) Error() error {
+	return w.w.Error()
+}
+
+func (w *CSVWriter) formatField(i int, v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if i < len(w.formatters) && w.formatters[i] != nil {
+		return w.formatters[i].Format(v)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,27 @@
+package numfmt_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := numfmt.NewCSVWriter(csv.NewWriter(buf),
+		nil,
+		&numfmt.Formatter{GroupSeparator: ".", DecimalSeparator: ",", Rounder: &numfmt.Rounder{Places: 2}},
+		numfmt.NewPercentFormatter(),
+	)
+
+	err := w.WriteAll([][]interface{}{
+		{"Widget", "1234.567", "0.25", "extra"},
+		{"Gadget, large", -5, nil},
+		{nil, "n/a", "0.5"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Widget,\"1.234,57\",25%,extra\n\"Gadget, large\",-5,\n,n/a,50%\n", buf.String())
+}
@@ -0,0 +1,173 @@
+package numfmt
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CurrencySignPosition is where a Formatter writes the sign of a negative number relative to Formatter.CurrencySymbol.
+type CurrencySignPosition int
+
+const (
+	CurrencySignBeforeSymbol CurrencySignPosition = iota // e.g. -$5.00 or -5,00 €
+	CurrencySignAfterSymbol                              // e.g. $-5.00 or 5,00 €-
+	CurrencySignParentheses                              // e.g. ($5.00) or (5,00 €)
+)
+
+// currency is the formatting data for a currency.
+type currency struct {
+	symbol string
+	places int32 // Number of minor unit decimal places.
+}
+
+// currencies maps ISO 4217 codes to formatting data. Symbols are the English symbols from CLDR. Currencies without a
+// distinct symbol use their code.
+var currencies = map[string]currency{
+	"AUD": {symbol: "A$", places: 2},
+	"BHD": {symbol: "BHD", places: 3},
+	"BRL": {symbol: "R$", places: 2},
+	"CAD": {symbol: "CA$", places: 2},
+	"CHF": {symbol: "CHF", places: 2},
+	"CLP": {symbol: "CLP", places: 0},
+	"CNY": {symbol: "CN¥", places: 2},
+	"CZK": {symbol: "CZK", places: 2},
+	"DKK": {symbol: "DKK", places: 2},
+	"EUR": {symbol: "€", places: 2},
+	"GBP": {symbol: "£", places: 2},
+	"HKD": {symbol: "HK$", places: 2},
+	"HUF": {symbol: "HUF", places: 2},
+	"IDR": {symbol: "IDR", places: 2},
+	"ILS": {symbol: "₪", places: 2},
+	"INR": {symbol: "₹", places: 2},
+	"ISK": {symbol: "ISK", places: 0},
+	"JOD": {symbol: "JOD", places: 3},
+	"JPY": {symbol: "¥", places: 0},
+	"KRW": {symbol: "₩", places: 0},
+	"KWD": {symbol: "KWD", places: 3},
+	"MXN": {symbol: "MX$", places: 2},
+	"NOK": {symbol: "NOK", places: 2},
+	"NZD": {symbol: "NZ$", places: 2},
+	"OMR": {symbol: "OMR", places: 3},
+	"PHP": {symbol: "₱", places: 2},
+	"PLN": {symbol: "PLN", places: 2},
+	"RUB": {symbol: "RUB", places: 2},
+	"SEK": {symbol: "SEK", places: 2},
+	"SGD": {symbol: "SGD", places: 2},
+	"THB": {symbol: "THB", places: 2},
+	"TND": {symbol: "TND", places: 3},
+	"TRY": {symbol: "TRY", places: 2},
+	"TWD": {symbol: "NT$", places: 2},
+	"USD": {symbol: "$", places: 2},
+	"VND": {symbol: "₫", places: 0},
+	"ZAR": {symbol: "ZAR", places: 2},
+}
+
+// NewCurrencyFormatter returns a Formatter for the currency with the ISO 4217 code such as "EUR". Numbers are rounded
+// to the minor unit of the currency and written with its symbol as CurrencySymbol. e.g. -€1,234.50. Unknown codes are
+// used as the symbol with two decimal places.
+func NewCurrencyFormatter(code string) *Formatter {
+	c := lookupCurrency(code)
+	return &Formatter{
+		Rounder:          &Rounder{Places: c.places},
+		MinDecimalPlaces: c.places,
+		CurrencySymbol:   c.symbol,
+	}
+}
+
+// NewINRFormatter returns a Formatter for Indian rupees with the ₹ symbol and lakh and crore grouping. e.g.
+// ₹1,23,456.00 and -₹12,34,56,789.00. The minus sign precedes the symbol as in Indian English.
+func NewINRFormatter() *Formatter {
+	f := NewCurrencyFormatter("INR")
+	f.Grouping = GroupingPattern{3, 2}
+	return f
+}
+
+// lookupCurrency returns the formatting data for the currency with the ISO 4217 code. Unknown codes use the code as
+// the symbol with two decimal places.
+func lookupCurrency(code string) currency {
+	code = strings.ToUpper(code)
+	if c, ok := currencies[code]; ok {
+		return c
+	}
+	return currency{symbol: code, places: 2}
+}
+
+// compileCurrency sets the defaults from Currency.
+func (f *Formatter) compileCurrency() {
+	if f.Currency == "" {
+		return
+	}
+
+	c := lookupCurrency(f.Currency)
+	f.currencySymbol = c.symbol
+	if f.Rounder == nil {
+		f.currencyRounder = &Rounder{Places: c.places}
+		f.currencyMinDecimalPlaces = c.places
+	}
+}
+
+// symbol returns CurrencySymbol or the symbol of Currency.
+func (f *Formatter) symbol() string {
+	if f.CurrencySymbol != "" {
+		return f.CurrencySymbol
+	}
+	return f.currencySymbol
+}
+
+// rounder returns Rounder or the Rounder for the minor units of Currency.
+func (f *Formatter) rounder() *Rounder {
+	if f.Rounder != nil {
+		return f.Rounder
+	}
+	return f.currencyRounder
+}
+
+// minDecimalPlaces returns MinDecimalPlaces or the minor units of Currency.
+func (f *Formatter) minDecimalPlaces() int32 {
+	if f.MinDecimalPlaces != 0 {
+		return f.MinDecimalPlaces
+	}
+	return f.currencyMinDecimalPlaces
+}
+
+// currencyTemplates returns the template and negative template for CurrencySymbol, CurrencySuffix, and CurrencySign.
+func (f *Formatter) currencyTemplates() (template, negativeTemplate string) {
+	symbol := EscapeTemplate(f.symbol())
+	separator := EscapeTemplate(f.currencySeparator())
+
+	if f.CurrencySuffix {
+		number := "n" + separator + symbol
+		switch f.CurrencySign {
+		case CurrencySignAfterSymbol:
+			return number + "-", ""
+		case CurrencySignParentheses:
+			return number, "(" + number + ")"
+		default:
+			return "-" + number, ""
+		}
+	}
+
+	symbol += separator
+	switch f.CurrencySign {
+	case CurrencySignAfterSymbol:
+		return symbol + "-n", ""
+	case CurrencySignParentheses:
+		return symbol + "n", "(" + symbol + "n)"
+	default:
+		return "-" + symbol + "n", ""
+	}
+}
+
+func (f *Formatter) currencySeparator() string {
+	if f.CurrencySeparator != "" {
+		return f.CurrencySeparator
+	}
+	if f.CurrencySuffix {
+		return NoBreakSpace
+	}
+	if r, _ := utf8.DecodeLastRuneInString(f.symbol()); unicode.IsLetter(r) {
+		return " "
+	}
+	return ""
+}
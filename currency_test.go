@@ -0,0 +1,158 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestNewCurrencyFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		code     string
+		arg      interface{}
+		expected string
+	}{
+		{"USD", "1234.5", "$1,234.50"},
+		{"usd", "-1234.567", "-$1,234.57"},
+		{"EUR", "1234.5", "€1,234.50"},
+		{"GBP", "0.1", "£0.10"},
+		{"JPY", "1234.5", "¥1,235"},
+		{"KRW", "-50000", "-₩50,000"},
+		{"KWD", "1.5", "KWD 1.500"},
+		{"CHF", "1234.5", "CHF 1,234.50"},
+		{"CAD", "5", "CA$5.00"},
+		{"XYZ", "5", "XYZ 5.00"},
+		{"TND", "5", "TND 5.000"},
+	} {
+		actual := numfmt.NewCurrencyFormatter(tt.code).Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := numfmt.NewCurrencyFormatter("EUR")
+	d, err := f.Parse("-€1,234.50")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-1234.5", d.String())
+	}
+}
+
+func TestFormatterCurrency(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Currency: "USD"}, "1234.5", "$1,234.50"},
+		{&numfmt.Formatter{Currency: "JPY"}, "1234.5", "¥1,235"},
+		{&numfmt.Formatter{Currency: "KWD"}, "-1.5", "-KWD 1.500"},
+		{&numfmt.Formatter{Currency: "kwd"}, 2, "KWD 2.000"},
+		{&numfmt.Formatter{Currency: "XYZ"}, "5", "XYZ 5.00"},
+		{&numfmt.Formatter{Currency: "USD", Rounder: &numfmt.Rounder{Places: 0}}, "1234.5", "$1,235"},
+		{&numfmt.Formatter{Currency: "USD", MinDecimalPlaces: 1}, "1234.5", "$1,234.5"},
+		{&numfmt.Formatter{Currency: "JPY", CurrencySymbol: "円", CurrencySuffix: true}, "1234", "1,234\u00a0円"},
+		{&numfmt.Formatter{Currency: "EUR", Template: "n EUR"}, "1234.5", "1,234.50 EUR"},
+		{&numfmt.Formatter{Currency: "EUR", GroupSeparator: ".", DecimalSeparator: ","}, "1234.5", "€1.234,50"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := &numfmt.Formatter{Currency: "JPY"}
+	d, err := f.Parse("-¥1,235")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-1235", d.String())
+	}
+	d, err = f.Quantize("1234.5")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1235", d.String())
+	}
+}
+
+func TestFormatterCurrencySign(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{CurrencySymbol: "$"}, "-5", "-$5"},
+		{&numfmt.Formatter{CurrencySymbol: "$", CurrencySign: numfmt.CurrencySignAfterSymbol}, "-5", "$-5"},
+		{&numfmt.Formatter{CurrencySymbol: "$", CurrencySign: numfmt.CurrencySignAfterSymbol}, "5", "$5"},
+		{&numfmt.Formatter{CurrencySymbol: "$", CurrencySign: numfmt.CurrencySignParentheses}, "-5", "($5)"},
+		{&numfmt.Formatter{CurrencySymbol: "$", CurrencySign: numfmt.CurrencySignParentheses}, "5", "$5"},
+		{&numfmt.Formatter{CurrencySymbol: "CHF", CurrencySign: numfmt.CurrencySignAfterSymbol}, "-5", "CHF -5"},
+		{&numfmt.Formatter{CurrencySymbol: "$", Template: "n $"}, "5", "5 $"},
+		{&numfmt.Formatter{CurrencySymbol: "$", NegativeTemplate: "$n-"}, "-5", "$5-"},
+		{&numfmt.Formatter{CurrencySymbol: "n*"}, "-5", "-n*5"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := &numfmt.Formatter{CurrencySymbol: "$", CurrencySign: numfmt.CurrencySignParentheses}
+	d, err := f.Parse("($1,234.50)")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-1234.5", d.String())
+	}
+}
+
+func TestFormatterCurrencySuffix(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{CurrencySymbol: "€", CurrencySuffix: true}, "-5", "-5\u00a0€"},
+		{&numfmt.Formatter{CurrencySymbol: "€", CurrencySuffix: true, CurrencySign: numfmt.CurrencySignAfterSymbol}, "-5", "5\u00a0€-"},
+		{&numfmt.Formatter{CurrencySymbol: "€", CurrencySuffix: true, CurrencySign: numfmt.CurrencySignParentheses}, "-5", "(5\u00a0€)"},
+		{&numfmt.Formatter{CurrencySymbol: "€", CurrencySuffix: true, CurrencySeparator: " "}, "5", "5 €"},
+		{&numfmt.Formatter{CurrencySymbol: "€", CurrencySeparator: " "}, "5", "€ 5"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestLocaleCurrency(t *testing.T) {
+	for i, tt := range []struct {
+		locale   string
+		expected string
+	}{
+		{"en-US", "-€1,234.56"},
+		{"fr-FR", "-1\u202f234,56\u00a0€"},
+		{"de-DE", "-1.234,56\u00a0€"},
+		{"de-AT", "-€\u00a01\u00a0234,56"},
+		{"nl-NL", "-€\u00a01.234,56"},
+		{"es-ES", "-1234,56\u00a0€"},
+	} {
+		f := numfmt.NewLocaleFormatter(language.MustParse(tt.locale))
+		f.CurrencySymbol = "€"
+		f.MinDecimalPlaces = 2
+		assert.Equalf(t, tt.expected, f.Format("-1234.56"), "%d", i)
+
+		d, err := f.Parse(tt.expected)
+		if assert.NoErrorf(t, err, "%d", i) {
+			assert.Equalf(t, "-1234.56", d.String(), "%d", i)
+		}
+	}
+}
+
+func TestNewINRFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		arg      interface{}
+		expected string
+	}{
+		{"123456", "₹1,23,456.00"},
+		{"-123456789", "-₹12,34,56,789.00"},
+		{"999.999", "₹1,000.00"},
+		{"0.5", "₹0.50"},
+	} {
+		actual := numfmt.NewINRFormatter().Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	d, err := numfmt.NewINRFormatter().Parse("-₹12,34,56,789.00")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-123456789", d.String())
+	}
+}
@@ -0,0 +1,153 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// String returns a description of the effective configuration of f including defaults such as the separators and
+// the templates and rounding of Currency. e.g. numfmt.Formatter{Template: "-$n", GroupSeparator: ",", ...}. Other
+// fields are included only if they are set. It is intended for log lines and test failures.
+func (f *Formatter) String() string {
+	if f == nil {
+		return "numfmt.Formatter(nil)"
+	}
+	c := NewFormatter(f) // Not f.compiled() so f can still be changed after String.
+
+	sb := &strings.Builder{}
+	sb.WriteString("numfmt.Formatter{")
+	t, nt := c.templates()
+	fmt.Fprintf(sb, "Template: %q", t)
+	if nt != "" {
+		fmt.Fprintf(sb, ", NegativeTemplate: %q", nt)
+	}
+	if f.Grouping != nil {
+		fmt.Fprintf(sb, ", GroupSeparator: %q, Grouping: %#v", c.groupSeparator(), f.Grouping)
+	} else {
+		fmt.Fprintf(sb, ", GroupSeparator: %q, GroupSize: %d", c.groupSeparator(), c.groupSize())
+	}
+	fmt.Fprintf(sb, ", DecimalSeparator: %q, MinusSign: %q", c.decimalSeparator(), c.minusSign())
+	if r := c.rounder(); r != nil {
+		fmt.Fprintf(sb, ", Rounder: numfmt.Rounder{Places: %d", r.Places)
+		writeGoFields(sb, reflect.ValueOf(r).Elem(), map[string]bool{"Places": true}, false, true)
+		sb.WriteByte('}')
+	}
+	if n := c.minDecimalPlaces(); n != 0 {
+		fmt.Fprintf(sb, ", MinDecimalPlaces: %d", n)
+	}
+
+	written := map[string]bool{
+		"Template":         true,
+		"NegativeTemplate": true,
+		"GroupSeparator":   true,
+		"GroupSize":        true,
+		"Grouping":         true,
+		"DecimalSeparator": true,
+		"MinusSign":        true,
+		"Rounder":          true,
+		"MinDecimalPlaces": true,
+	}
+	writeGoFields(sb, reflect.ValueOf(f).Elem(), written, false, true)
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// GoString returns the fields of f that are set in Go syntax for the %#v verb. e.g. &numfmt.Formatter{Rounder:
+// &numfmt.Rounder{Places: 2}, Template: "-$n"}. Unlike String it does not include defaults.
+func (f *Formatter) GoString() string {
+	if f == nil {
+		return "(*numfmt.Formatter)(nil)"
+	}
+	sb := &strings.Builder{}
+	writeGoValue(sb, reflect.ValueOf(f), true)
+	return sb.String()
+}
+
+// String returns the unit suffixes of h. e.g. numfmt.Humanizer{"", "K", "M", "B", "T"}.
+func (h *Humanizer) String() string {
+	if h == nil {
+		return "numfmt.Humanizer(nil)"
+	}
+	sb := &strings.Builder{}
+	sb.WriteString("numfmt.Humanizer{")
+	for i, u := range h.units {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(sb, "%q", u.suffix)
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// GoString returns the same as String for the %#v verb.
+func (h *Humanizer) GoString() string {
+	return h.String()
+}
+
+// writeGoValue writes v in the form used by Formatter.String and Formatter.GoString. Structs are written with only
+// their set exported fields. If goSyntax is set pointers to structs are written with &.
+func writeGoValue(sb *strings.Builder, v reflect.Value, goSyntax bool) {
+	switch {
+	case v.Type() == decimalType:
+		d := v.Interface().(decimal.Decimal)
+		if goSyntax {
+			fmt.Fprintf(sb, "decimal.RequireFromString(%q)", d.String())
+		} else {
+			sb.WriteString(d.String())
+		}
+		return
+	case v.Kind() == reflect.Func:
+		sb.WriteString("func(...)")
+		return
+	case v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct:
+		if _, ok := v.Interface().(fmt.GoStringer); ok && v.Type() != reflect.TypeOf(&Formatter{}) {
+			sb.WriteString(v.Interface().(fmt.GoStringer).GoString())
+			return
+		}
+		if goSyntax {
+			sb.WriteByte('&')
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		fmt.Fprintf(sb, "%#v", v.Interface())
+		return
+	}
+
+	sb.WriteString(v.Type().String())
+	sb.WriteByte('{')
+	writeGoFields(sb, v, nil, goSyntax, false)
+	sb.WriteByte('}')
+}
+
+// writeGoFields writes the set exported fields of the struct v except those in skip. If more is set the fields are
+// preceded by a comma as they follow others.
+func writeGoFields(sb *strings.Builder, v reflect.Value, skip map[string]bool, goSyntax, more bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if field.PkgPath != "" || skip[field.Name] || fv.IsZero() {
+			continue
+		}
+		if more {
+			sb.WriteString(", ")
+		}
+		more = true
+		sb.WriteString(field.Name)
+		sb.WriteString(": ")
+		if field.Type == reflect.TypeOf(&Formatter{}) && !goSyntax {
+			sb.WriteString(fv.Interface().(*Formatter).String())
+			continue
+		}
+		writeGoValue(sb, fv, goSyntax)
+	}
+}
@@ -0,0 +1,69 @@
+package numfmt_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterString(t *testing.T) {
+	for i, tt := range []struct {
+		f        *numfmt.Formatter
+		expected string
+	}{
+		{
+			&numfmt.Formatter{},
+			`numfmt.Formatter{Template: "-n", GroupSeparator: ",", GroupSize: 3, DecimalSeparator: ".", MinusSign: "-"}`,
+		},
+		{
+			numfmt.NewUSDFormatter(),
+			`numfmt.Formatter{Template: "-$n", GroupSeparator: ",", GroupSize: 3, DecimalSeparator: ".", MinusSign: "-", ` +
+				`MinDecimalPlaces: 2}`,
+		},
+		{
+			&numfmt.Formatter{Currency: "JPY", CurrencySign: numfmt.CurrencySignParentheses},
+			`numfmt.Formatter{Template: "¥n", NegativeTemplate: "(¥n)", GroupSeparator: ",", GroupSize: 3, ` +
+				`DecimalSeparator: ".", MinusSign: "-", Rounder: numfmt.Rounder{Places: 0}, Currency: "JPY", CurrencySign: 2}`,
+		},
+		{
+			&numfmt.Formatter{Grouping: numfmt.GroupingPattern{3, 2}, Scale: decimal.RequireFromString("2.54"),
+				Humanizer: numfmt.CompactHumanizer},
+			`numfmt.Formatter{Template: "-n", GroupSeparator: ",", Grouping: numfmt.GroupingPattern{3, 2}, ` +
+				`DecimalSeparator: ".", MinusSign: "-", Scale: 2.54, Humanizer: numfmt.Humanizer{"", "K", "M", "B", "T"}}`,
+		},
+		{
+			&numfmt.Formatter{Base: 16, Fallback: &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}},
+			`numfmt.Formatter{Template: "-n", GroupSeparator: "_", GroupSize: 4, DecimalSeparator: ".", MinusSign: "-", ` +
+				`Fallback: numfmt.Formatter{Template: "-n", GroupSeparator: ",", GroupSize: 3, DecimalSeparator: ".", ` +
+				`MinusSign: "-", Rounder: numfmt.Rounder{Places: 2}}, Base: 16}`,
+		},
+	} {
+		assert.Equalf(t, tt.expected, tt.f.String(), "%d", i)
+		assert.Equalf(t, tt.expected, fmt.Sprint(tt.f), "%d", i)
+	}
+}
+
+func TestFormatterStringDoesNotCompile(t *testing.T) {
+	f := &numfmt.Formatter{}
+	_ = f.String()
+	f.Template = "+n"
+	assert.Equal(t, "+5", f.Format(5))
+}
+
+func TestFormatterGoString(t *testing.T) {
+	f := &numfmt.Formatter{
+		Rounder:   &numfmt.Rounder{Places: 2},
+		Scale:     decimal.RequireFromString("2.54"),
+		Template:  "-$n",
+		PreFormat: func(d decimal.Decimal) decimal.Decimal { return d },
+	}
+	assert.Equal(t,
+		`&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}, Scale: decimal.RequireFromString("2.54"), `+
+			`PreFormat: func(...), Template: "-$n"}`,
+		fmt.Sprintf("%#v", f),
+	)
+	assert.Equal(t, "(*numfmt.Formatter)(nil)", fmt.Sprintf("%#v", (*numfmt.Formatter)(nil)))
+}
@@ -0,0 +1,78 @@
+package numfmt
+
+import (
+	"strings"
+)
+
+// defaultDeltaFormatter is used by DeltaFormatter when Formatter is nil.
+var defaultDeltaFormatter = NewFormatter(&Formatter{Template: "+n", MinusSign: MinusSign})
+
+// defaultDeltaPercentFormatter is used by DeltaFormatter when PercentFormatter is nil.
+var defaultDeltaPercentFormatter = NewFormatter(NewPercentChangeFormatter(1))
+
+// DeltaFormatter formats the change from an old value to a new value as an absolute and a percent change such as
+// +120 (+4.2%) for diff views and period-over-period reports. The zero value is usable.
+type DeltaFormatter struct {
+	// Formatter formats the absolute change. Its Template should include the '+' verb so increases are signed.
+	// Default: a Formatter with Template "+n" and MinusSign MinusSign as NewPercentChangeFormatter
+	Formatter *Formatter
+
+	// PercentFormatter formats the change relative to the old value. A change from zero is written as FormatRatio
+	// writes a ratio with a zero denominator. Default: NewPercentChangeFormatter(1)
+	PercentFormatter *Formatter
+
+	// Layout is written with {change} replaced by the absolute change and {percent} by the percent change. e.g.
+	// "{percent}" writes only the percent change. Default: "{change} ({percent})"
+	Layout string
+}
+
+// NewDeltaFormatter returns a DeltaFormatter that formats the absolute change with f and the percent change with
+// places decimal places. e.g. 2880 and 3000 => +$120.00 (+4.2%) with a Formatter with Template "+$n" and places 1.
+func NewDeltaFormatter(f *Formatter, places int32) *DeltaFormatter {
+	return &DeltaFormatter{Formatter: f, PercentFormatter: NewPercentChangeFormatter(places)}
+}
+
+// FormatDelta formats the change from oldValue to newValue with f for the absolute change and the defaults of DeltaFormatter
+// for the percent change and layout. e.g. 2880 and 3000 => +120 (+4.2%) with Template "+n".
+func (f *Formatter) FormatDelta(oldValue, newValue interface{}) string {
+	return (&DeltaFormatter{Formatter: f}).Format(oldValue, newValue)
+}
+
+// Format formats the change from oldValue to newValue. If oldValue or newValue is not a number it is written as Format
+// would write it.
+func (df *DeltaFormatter) Format(oldValue, newValue interface{}) string {
+	f := df.formatter()
+	o, s, ok := f.toDecimal(oldValue)
+	if !ok {
+		return s
+	}
+	n, s, ok := f.toDecimal(newValue)
+	if !ok {
+		return s
+	}
+
+	change := n.Sub(o)
+	layout := df.Layout
+	if layout == "" {
+		layout = "{change} ({percent})"
+	}
+	r := strings.NewReplacer(
+		"{change}", f.formatDecimal(change),
+		"{percent}", df.percentFormatter().FormatRatio(change, o.Abs()),
+	)
+	return r.Replace(layout)
+}
+
+func (df *DeltaFormatter) formatter() *Formatter {
+	if df.Formatter == nil {
+		return defaultDeltaFormatter
+	}
+	return df.Formatter
+}
+
+func (df *DeltaFormatter) percentFormatter() *Formatter {
+	if df.PercentFormatter == nil {
+		return defaultDeltaPercentFormatter
+	}
+	return df.PercentFormatter
+}
@@ -0,0 +1,42 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeltaFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.DeltaFormatter
+		old       interface{}
+		new       interface{}
+		expected  string
+	}{
+		{&numfmt.DeltaFormatter{}, 2880, 3000, "+120 (+4.2%)"},
+		{&numfmt.DeltaFormatter{}, 3000, 2880, "−120 (−4.0%)"},
+		{&numfmt.DeltaFormatter{}, 100, 100, "+0 (+0.0%)"},
+		{&numfmt.DeltaFormatter{}, -50, -25, "+25 (+50.0%)"},
+		{&numfmt.DeltaFormatter{}, 0, 10, "+10 (—)"},
+		{&numfmt.DeltaFormatter{}, 1234567, 2345678, "+1,111,111 (+90.0%)"},
+		{numfmt.NewDeltaFormatter(&numfmt.Formatter{Template: "+$n", MinDecimalPlaces: 2}, 2), 2880, 3000, "+$120.00 (+4.17%)"},
+		{&numfmt.DeltaFormatter{Layout: "{percent}"}, 200, 250, "+25.0%"},
+		{&numfmt.DeltaFormatter{Layout: "{change} / {percent}"}, 200, 150, "−50 / −25.0%"},
+		{
+			&numfmt.DeltaFormatter{PercentFormatter: &numfmt.Formatter{
+				Shift: 2, Rounder: &numfmt.Rounder{Places: 0}, Template: "{trend}n%", ZeroDenominator: numfmt.ZeroDenominatorBlank,
+			}},
+			0, 5, "+5 ()",
+		},
+		{&numfmt.DeltaFormatter{}, "abc", 5, "abc"},
+	} {
+		actual := tt.formatter.Format(tt.old, tt.new)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterFormatDelta(t *testing.T) {
+	f := &numfmt.Formatter{Template: "+n", Rounder: &numfmt.Rounder{Places: 1}}
+	assert.Equal(t, "+0.5 (+50.0%)", f.FormatDelta(1, 1.5))
+}
@@ -0,0 +1,199 @@
+package numfmt
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// durationUnit is a unit of DurationFormatter.
+type durationUnit struct {
+	d      time.Duration
+	symbol string
+}
+
+// ns returns the number of nanoseconds in u.
+func (u durationUnit) ns() decimal.Decimal {
+	return decimal.NewFromInt(int64(u.d))
+}
+
+// durationUnits are the units of DurationFormatter from largest to smallest.
+var durationUnits = []durationUnit{
+	{24 * time.Hour, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+	{time.Millisecond, "ms"},
+	{time.Microsecond, "µs"},
+	{time.Nanosecond, "ns"},
+}
+
+// DurationHumanizer formats a number of seconds with the largest time unit less than it. e.g. 0.34 => 340 ms and
+// 5400 => 1.5 h. Use DurationFormatter for time.Duration values and compound durations such as 1h 30m.
+var DurationHumanizer = NewRuleHumanizer([]HumanizeRule{
+	{Min: decimal.New(1, -9), Divisor: decimal.New(1, -9), Suffix: " ns"},
+	{Min: decimal.New(1, -6), Divisor: decimal.New(1, -6), Suffix: " µs"},
+	{Min: decimal.New(1, -3), Divisor: decimal.New(1, -3), Suffix: " ms"},
+	{Min: decimal.New(1, 0), Divisor: decimal.New(1, 0), Suffix: " s"},
+	{Min: decimal.New(60, 0), Divisor: decimal.New(60, 0), Suffix: " m"},
+	{Min: decimal.New(3600, 0), Divisor: decimal.New(3600, 0), Suffix: " h"},
+	{Min: decimal.New(86400, 0), Divisor: decimal.New(86400, 0), Suffix: " d"},
+})
+
+// defaultDurationFormatter is used by DurationFormatter when Formatter is nil.
+var defaultDurationFormatter = NewFormatter(&Formatter{Rounder: &Rounder{Places: 1}})
+
+// DurationFormatter formats a duration with human time units. e.g. 2.5 s, 340 ms, or 1h 23m. The zero value is usable
+// and writes a single unit rounded to 1 decimal place.
+type DurationFormatter struct {
+	// Formatter formats the number of each unit. Its Humanizer and Unit should not be set. Default: a Formatter that
+	// rounds to 1 decimal place
+	Formatter *Formatter
+
+	// MaxUnits is the maximum number of units written. If it is more than 1 the duration is written as whole numbers of
+	// successive units such as 1h 23m and rounded to the last unit. Units that are zero are omitted. Default: 1
+	MaxUnits int
+
+	// Largest is the largest unit written. e.g. 36 hours is 1.5 d by default and 36 h with time.Hour. Default: 24 hours
+	Largest time.Duration
+
+	// Smallest is the smallest unit written. e.g. 3721.5 seconds is 1h 2m 1s 500ms with MaxUnits 4 and 1h 2m 2s with
+	// time.Second. Default: time.Nanosecond
+	Smallest time.Duration
+}
+
+// NewDurationFormatter returns a DurationFormatter that writes up to maxUnits units. e.g. 5025 seconds => 1h 24m with
+// maxUnits 2.
+func NewDurationFormatter(maxUnits int) *DurationFormatter {
+	return &DurationFormatter{MaxUnits: maxUnits}
+}
+
+// Format formats v. v can be a time.Duration or a number of seconds such as 1.5 in any form Formatter.Format accepts.
+// If v is not a number it is returned as a string.
+func (df *DurationFormatter) Format(v interface{}) string {
+	f := df.formatter()
+
+	var ns decimal.Decimal
+	switch v := v.(type) {
+	case time.Duration:
+		ns = decimal.NewFromInt(int64(v))
+	case *time.Duration:
+		if v == nil {
+			return f.Nil
+		}
+		ns = decimal.NewFromInt(int64(*v))
+	default:
+		d, s, ok := f.toDecimal(v)
+		if !ok {
+			return s
+		}
+		ns = d.Shift(9)
+	}
+
+	units := df.units()
+	if df.MaxUnits > 1 {
+		return df.formatCompound(f, ns, units)
+	}
+
+	i := unitIndexFor(ns.Abs(), units)
+	n, _ := f.quantize(ns.Div(units[i].ns()))
+	if i > 0 && n.Abs().Mul(units[i].ns()).Cmp(units[i-1].ns()) >= 0 {
+		i--
+	}
+	return f.Format(ns.Div(units[i].ns())) + " " + units[i].symbol
+}
+
+// formatCompound formats ns with up to MaxUnits successive units.
+func (df *DurationFormatter) formatCompound(f *Formatter, ns decimal.Decimal, units []durationUnit) string {
+	abs := ns.Abs()
+	i := unitIndexFor(abs, units)
+	rounded := roundToUnit(abs, units[df.lastUnit(i, units)])
+	if i > 0 && rounded.Cmp(units[i-1].ns()) >= 0 {
+		i--
+		rounded = roundToUnit(abs, units[df.lastUnit(i, units)])
+	}
+
+	sb := &strings.Builder{}
+	last := df.lastUnit(i, units)
+	for j := i; j <= last; j++ {
+		unit := units[j].ns()
+		n := rounded.Div(unit).Truncate(0)
+		rounded = rounded.Sub(n.Mul(unit))
+		if n.IsZero() {
+			continue
+		}
+
+		if sb.Len() > 0 {
+			sb.WriteByte(' ')
+		} else if ns.Sign() < 0 {
+			n = n.Neg()
+		}
+		sb.WriteString(f.Format(n))
+		sb.WriteString(units[j].symbol)
+	}
+	if sb.Len() == 0 {
+		return f.Format(0) + units[i].symbol
+	}
+	return sb.String()
+}
+
+// lastUnit returns the index of the last unit written when the first unit is units[i].
+func (df *DurationFormatter) lastUnit(i int, units []durationUnit) int {
+	if last := i + df.MaxUnits - 1; last < len(units)-1 {
+		return last
+	}
+	return len(units) - 1
+}
+
+// roundToUnit rounds ns to a whole number of unit.
+func roundToUnit(ns decimal.Decimal, unit durationUnit) decimal.Decimal {
+	d := unit.ns()
+	return ns.Div(d).Round(0).Mul(d)
+}
+
+// unitIndexFor returns the index of the largest of units that is not greater than ns. Zero uses seconds if they are
+// in units. Durations smaller than every unit use the smallest unit.
+func unitIndexFor(ns decimal.Decimal, units []durationUnit) int {
+	if ns.IsZero() {
+		for i, u := range units {
+			if u.d <= time.Second {
+				return i
+			}
+		}
+	}
+	for i, u := range units {
+		if ns.Cmp(u.ns()) >= 0 {
+			return i
+		}
+	}
+	return len(units) - 1
+}
+
+func (df *DurationFormatter) formatter() *Formatter {
+	if df.Formatter == nil {
+		return defaultDurationFormatter
+	}
+	return df.Formatter
+}
+
+// units returns the units between Smallest and Largest.
+func (df *DurationFormatter) units() []durationUnit {
+	largest := df.Largest
+	if largest == 0 {
+		largest = 24 * time.Hour
+	}
+	smallest := df.Smallest
+	if smallest == 0 {
+		smallest = time.Nanosecond
+	}
+
+	start, end := 0, len(durationUnits)
+	for start < end-1 && durationUnits[start].d > largest {
+		start++
+	}
+	for end > start+1 && durationUnits[end-1].d < smallest {
+		end--
+	}
+	return durationUnits[start:end]
+}
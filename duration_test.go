@@ -0,0 +1,65 @@
+package numfmt_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.DurationFormatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.DurationFormatter{}, 2.5, "2.5 s"},
+		{&numfmt.DurationFormatter{}, "0.34", "340 ms"},
+		{&numfmt.DurationFormatter{}, 340 * time.Millisecond, "340 ms"},
+		{&numfmt.DurationFormatter{}, 90 * time.Second, "1.5 m"},
+		{&numfmt.DurationFormatter{}, 36 * time.Hour, "1.5 d"},
+		{&numfmt.DurationFormatter{}, 1500 * time.Nanosecond, "1.5 µs"},
+		{&numfmt.DurationFormatter{}, 59.97, "1 m"},
+		{&numfmt.DurationFormatter{}, -2.5, "-2.5 s"},
+		{&numfmt.DurationFormatter{}, 0, "0 s"},
+		{&numfmt.DurationFormatter{Largest: time.Hour}, 36 * time.Hour, "36 h"},
+		{&numfmt.DurationFormatter{Smallest: time.Second}, 0.002, "0 s"},
+		{&numfmt.DurationFormatter{Formatter: &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}}, 100, "1.67 m"},
+		{numfmt.NewDurationFormatter(2), 5025, "1h 24m"},
+		{numfmt.NewDurationFormatter(2), 83*time.Minute + 20*time.Second, "1h 23m"},
+		{numfmt.NewDurationFormatter(2), 3600, "1h"},
+		{numfmt.NewDurationFormatter(2), 3599.9, "1h"},
+		{numfmt.NewDurationFormatter(2), -5025, "-1h 24m"},
+		{numfmt.NewDurationFormatter(2), 0, "0s"},
+		{numfmt.NewDurationFormatter(3), 90061, "1d 1h 1m"},
+		{numfmt.NewDurationFormatter(4), 3721.5, "1h 2m 1s 500ms"},
+		{&numfmt.DurationFormatter{MaxUnits: 4, Smallest: time.Second}, 3721.5, "1h 2m 2s"},
+		{&numfmt.DurationFormatter{MaxUnits: 2, Largest: time.Hour}, 100 * time.Hour, "100h"},
+		{&numfmt.DurationFormatter{MaxUnits: 2, Largest: time.Minute}, 100000, "1,666m 40s"},
+		{&numfmt.DurationFormatter{}, "abc", "abc"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestDurationHumanizer(t *testing.T) {
+	f := &numfmt.Formatter{Humanizer: numfmt.DurationHumanizer, Rounder: &numfmt.Rounder{Places: 1}}
+	for i, tt := range []struct {
+		arg      interface{}
+		expected string
+	}{
+		{"0.34", "340 ms"},
+		{"2.5", "2.5 s"},
+		{"5400", "1.5 h"},
+		{"0.0000025", "2.5 µs"},
+	} {
+		assert.Equalf(t, tt.expected, f.Format(tt.arg), "%d", i)
+	}
+
+	s, err := numfmt.TemplateFunc("Humanizer", "duration", "RoundPlaces", 1, "90")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1.5 m", s)
+	}
+}
@@ -0,0 +1,47 @@
+package numfmt
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// EnvironmentLocale returns the numeric locale of the POSIX environment. LC_ALL, LC_NUMERIC, and LANG are checked in
+// that order and the first that is set is used. Values such as "de_DE.UTF-8@euro" are converted to BCP 47 tags. ok is
+// false if no variable is set, the locale is "C" or "POSIX", or the locale cannot be parsed.
+func EnvironmentLocale() (tag language.Tag, ok bool) {
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return parsePOSIXLocale(value)
+		}
+	}
+	return language.Und, false
+}
+
+// parsePOSIXLocale parses a POSIX locale name of the form language[_territory][.codeset][@modifier].
+func parsePOSIXLocale(s string) (language.Tag, bool) {
+	if i := strings.IndexAny(s, ".@"); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" || s == "C" || s == "POSIX" {
+		return language.Und, false
+	}
+
+	tag, err := language.Parse(strings.Replace(s, "_", "-", -1))
+	if err != nil {
+		return language.Und, false
+	}
+	return tag, true
+}
+
+// NewEnvironmentFormatter returns a Formatter for the numeric locale of the POSIX environment as returned by
+// EnvironmentLocale. It is intended for command line tools that should respect the numeric conventions of the user. If
+// the environment has no usable locale the zero Formatter is returned.
+func NewEnvironmentFormatter() *Formatter {
+	tag, ok := EnvironmentLocale()
+	if !ok {
+		return &Formatter{}
+	}
+	return NewLocaleFormatter(tag)
+}
@@ -0,0 +1,65 @@
+package numfmt_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// setenv sets the environment variables in env and returns a function that restores them.
+func setenv(env map[string]string) func() {
+	saved := map[string]*string{}
+	for k, v := range env {
+		if old, ok := os.LookupEnv(k); ok {
+			saved[k] = &old
+		} else {
+			saved[k] = nil
+		}
+		if v == "" {
+			os.Unsetenv(k)
+		} else {
+			os.Setenv(k, v)
+		}
+	}
+
+	return func() {
+		for k, v := range saved {
+			if v == nil {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, *v)
+			}
+		}
+	}
+}
+
+func TestNewEnvironmentFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		all      string
+		numeric  string
+		lang     string
+		expected string
+	}{
+		{"", "", "", "1,234.5"},
+		{"", "", "de_DE.UTF-8", "1.234,5"},
+		{"", "de_DE.UTF-8@euro", "en_US.UTF-8", "1.234,5"},
+		{"fr_FR", "de_DE", "en_US", "1\u202f234,5"},
+		{"C", "de_DE", "", "1,234.5"},
+		{"", "POSIX", "", "1,234.5"},
+		{"", "", "not a locale!", "1,234.5"},
+	} {
+		restore := setenv(map[string]string{"LC_ALL": tt.all, "LC_NUMERIC": tt.numeric, "LANG": tt.lang})
+		actual := numfmt.NewEnvironmentFormatter().Format("1234.5")
+		restore()
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	defer setenv(map[string]string{"LC_ALL": "pt_BR.UTF-8"})()
+	tag, ok := numfmt.EnvironmentLocale()
+	if assert.True(t, ok) {
+		assert.Equal(t, language.MustParse("pt-BR"), tag)
+	}
+}
@@ -0,0 +1,281 @@
+package numfmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrExcelUnsupported is wrapped by the error returned by ExcelFormatCode when f uses options that cannot be
+// represented in a spreadsheet number format.
+var ErrExcelUnsupported = errors.New("not supported by Excel format codes")
+
+// excelColors maps ANSI SGR foreground color codes to Excel format code colors.
+var excelColors = map[string]string{
+	"30": "[Black]",
+	"31": "[Red]",
+	"32": "[Green]",
+	"33": "[Yellow]",
+	"34": "[Blue]",
+	"35": "[Magenta]",
+	"36": "[Cyan]",
+	"37": "[White]",
+}
+
+// ExcelFormatCode returns a spreadsheet custom number format code equivalent to f for use with Excel, excelize, or
+// other xlsx writers. e.g. "$"#,##0.00 for NewCurrencyFormatter("USD") and #,##0.#% for a percent Formatter rounding to
+// 1 place. The separators in a format code are always "," and "." and are displayed with those of the locale of the
+// spreadsheet, so GroupSeparator, DecimalSeparator, and Digits are not represented. Without a Rounder up to 10 decimal
+// places are displayed.
+//
+// The conversion is best-effort. The returned code is usable even if an error is returned. The error wraps
+// ErrExcelUnsupported and lists the options of f that are not represented such as Humanizer or Scale.
+func (f *Formatter) ExcelFormatCode() (string, error) {
+	c := NewFormatter(f)
+	e := &excelEncoder{f: c}
+
+	positive := c.compiledTemplate
+	negative := c.compiledNegativeTemplate
+	hasZero := false
+	explicitNegative := negative != nil || c.minusSign() != "-" || c.Colors != nil
+	for _, part := range positive {
+		switch part.(type) {
+		case compiledTemplatePartForceSign, compiledTemplatePartTrend:
+			hasZero = true
+			explicitNegative = true
+		}
+	}
+	if negative == nil {
+		negative = positive
+	}
+
+	sections := []string{e.section(positive, 1)}
+	if explicitNegative {
+		sections = append(sections, e.section(negative, -1))
+	}
+	if hasZero {
+		sections = append(sections, e.section(positive, 0))
+	}
+	code := strings.Join(sections, ";")
+
+	if len(e.unsupported) > 0 {
+		return code, fmt.Errorf("%w: %s", ErrExcelUnsupported, strings.Join(e.unsupported, ", "))
+	}
+	return code, nil
+}
+
+// excelEncoder writes the sections of an Excel format code for a compiled Formatter.
+type excelEncoder struct {
+	f           *Formatter
+	unsupported []string
+}
+
+// unsupport records that option is not represented. Each option is recorded once.
+func (e *excelEncoder) unsupport(option string) {
+	for _, s := range e.unsupported {
+		if s == option {
+			return
+		}
+	}
+	e.unsupported = append(e.unsupported, option)
+}
+
+// section returns the format code section for ct for numbers with sign.
+func (e *excelEncoder) section(ct compiledTemplate, sign int) string {
+	f := e.f
+	sb := &strings.Builder{}
+
+	if f.Colors != nil {
+		var code string
+		switch sign {
+		case 1:
+			code = f.Colors.Positive
+		case -1:
+			code = f.Colors.Negative
+		default:
+			code = f.Colors.Zero
+		}
+		if color, ok := excelColors[code]; ok {
+			sb.WriteString(color)
+		} else if code != "" {
+			e.unsupport("Colors")
+		}
+	}
+
+	// Adjacent literal text is written as one quoted string.
+	literal := &strings.Builder{}
+	flush := func() {
+		e.writeLiteral(sb, literal.String())
+		literal.Reset()
+	}
+
+	for _, part := range ct {
+		switch part := part.(type) {
+		case compiledTemplatePartLiteral:
+			literal.WriteString(string(part))
+		case compiledTemplatePartOptionalSign:
+			if sign < 0 {
+				literal.WriteString(f.minusSign())
+			}
+		case compiledTemplatePartForceSign:
+			switch sign {
+			case 1:
+				literal.WriteString(f.plusSign())
+			case -1:
+				literal.WriteString(f.minusSign())
+			default:
+				literal.WriteString(f.zeroSign())
+			}
+		case compiledTemplatePartTrend:
+			switch sign {
+			case 1:
+				literal.WriteString(f.trendUp())
+			case -1:
+				literal.WriteString(f.trendDown())
+			default:
+				literal.WriteString(f.trendFlat())
+			}
+		case compiledTemplatePartFill:
+			flush()
+			r, _ := utf8.DecodeRuneInString(f.fill())
+			sb.WriteByte('*')
+			sb.WriteRune(r)
+		case compiledTemplatePartNumber:
+			flush()
+			e.writeNumber(sb)
+		default:
+			flush()
+			e.unsupport("integer and fraction template verbs")
+			e.writeNumber(sb)
+		}
+	}
+	flush()
+
+	return sb.String()
+}
+
+// writeLiteral writes the literal text s. A % is written unquoted when Shift is 2 so the spreadsheet scales the number
+// by 100 the same as f.
+func (e *excelEncoder) writeLiteral(sb *strings.Builder, s string) {
+	if e.f.Shift != 2 {
+		writeExcelQuoted(sb, s)
+		return
+	}
+	parts := strings.Split(s, "%")
+	for i, p := range parts {
+		if i > 0 {
+			sb.WriteByte('%')
+		}
+		writeExcelQuoted(sb, p)
+	}
+}
+
+// writeNumber writes the digit placeholders for the number and any unit.
+func (e *excelEncoder) writeNumber(sb *strings.Builder) {
+	f := e.f
+
+	switch {
+	case f.NoGrouping:
+		sb.WriteString("0")
+	case f.Grouping != nil || f.groupSize() != 3 || f.MinGroupingDigits > 0:
+		e.unsupport("Grouping")
+		sb.WriteString("#,##0")
+	default:
+		sb.WriteString("#,##0")
+	}
+
+	places := int32(10)
+	if r := f.rounder(); r != nil {
+		if r.SignificantDigits != 0 || len(r.Rules) > 0 {
+			e.unsupport("Rounder")
+		} else {
+			places = r.Places
+		}
+	}
+	minPlaces := f.minDecimalPlaces()
+	if minPlaces > places {
+		places = minPlaces
+	}
+	if minPlaces < 0 {
+		minPlaces = 0
+	}
+	if places > 0 || f.AlwaysShowDecimalSeparator {
+		sb.WriteByte('.')
+		sb.WriteString(strings.Repeat("0", int(minPlaces)))
+		sb.WriteString(strings.Repeat("#", int(places-minPlaces)))
+	}
+
+	switch {
+	case f.Shift == 0, f.Shift == 2 && e.hasPercent():
+	case f.Shift < 0 && f.Shift%3 == 0:
+		sb.WriteString(strings.Repeat(",", int(-f.Shift/3)))
+	default:
+		e.unsupport("Shift")
+	}
+
+	if f.Unit != nil {
+		if f.Unit.Prefixes || f.Unit.Plural != "" || len(f.Unit.Forms) > 0 {
+			e.unsupport("Unit")
+		}
+		writeExcelQuoted(sb, f.unitSeparator()+f.Unit.Symbol)
+	}
+
+	for _, o := range []struct {
+		name string
+		set  bool
+	}{
+		{"Scale", !f.Scale.IsZero()},
+		{"Humanizer", f.Humanizer != nil},
+		{"Scientific", f.Scientific != nil},
+		{"Fraction", f.Fraction != nil},
+		{"Sexagesimal", f.Sexagesimal != nil},
+		{"Clock", f.Clock != nil},
+		{"Base", f.base() != 10},
+		{"Ordinal", f.Ordinal},
+		{"MagnitudeRules", len(f.MagnitudeRules) > 0},
+		{"PreFormat", f.PreFormat != nil},
+		{"PostFormat", f.PostFormat != nil},
+		{"DisplayMax", !f.DisplayMax.IsZero()},
+		{"ClampMin", f.ClampMin != nil},
+		{"ClampMax", f.ClampMax != nil},
+		{"MaxWidth", f.MaxWidth != 0},
+		{"MaxIntegerDigits", f.MaxIntegerDigits != 0},
+		{"MarkApproximate", f.MarkApproximate},
+		{"PipSeparator", f.PipSeparator != ""},
+		{"MyriadUnits", len(f.MyriadUnits) > 0},
+		{"Mask", f.Mask != ""},
+		{"LeadingZeros", f.LeadingZeros != LeadingZerosDrop},
+	} {
+		if o.set {
+			e.unsupport(o.name)
+		}
+	}
+}
+
+// hasPercent reports whether a literal of the template contains %.
+func (e *excelEncoder) hasPercent() bool {
+	for _, ct := range []compiledTemplate{e.f.compiledTemplate, e.f.compiledNegativeTemplate} {
+		for _, part := range ct {
+			if lit, ok := part.(compiledTemplatePartLiteral); ok && strings.Contains(string(lit), "%") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeExcelQuoted writes s as a quoted literal. Quotes within s are escaped with a backslash outside the quoted
+// text.
+func writeExcelQuoted(sb *strings.Builder, s string) {
+	for i, part := range strings.Split(s, `"`) {
+		if i > 0 {
+			sb.WriteString(`\"`)
+		}
+		if part != "" {
+			sb.WriteByte('"')
+			sb.WriteString(part)
+			sb.WriteByte('"')
+		}
+	}
+}
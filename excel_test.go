@@ -0,0 +1,69 @@
+package numfmt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterExcelFormatCode(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		expected  string
+	}{
+		{&numfmt.Formatter{}, `#,##0.##########`},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}}, `#,##0`},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 3}, MinDecimalPlaces: 1}, `#,##0.0##`},
+		{numfmt.NewCurrencyFormatter("USD"), `"$"#,##0.00`},
+		{
+			&numfmt.Formatter{Currency: "USD", NegativeStyle: numfmt.NegativeParentheses},
+			`"$"#,##0.00;"($"#,##0.00")"`,
+		},
+		{&numfmt.Formatter{Currency: "EUR", CurrencySuffix: true}, "#,##0.00\" €\""},
+		{&numfmt.Formatter{Shift: 2, Rounder: &numfmt.Rounder{Places: 1}, Template: "-n%"}, `#,##0.#%`},
+		{numfmt.NewPercentChangeFormatter(1), "\"+\"#,##0.0%;\"−\"#,##0.0%;\"+\"#,##0.0%"},
+		{&numfmt.Formatter{Shift: -3, Rounder: &numfmt.Rounder{Places: 0}, Template: "-nK"}, `#,##0,"K"`},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}, Template: `-n "cm"`}, `#,##0" "\""cm"\"`},
+		{
+			&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}, Colors: numfmt.DefaultANSIColors},
+			`[Green]#,##0;[Red]"-"#,##0`,
+		},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}, Template: "${fill}n", Fill: "."}, `"$"*.#,##0`},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 1}, Unit: &numfmt.Unit{Symbol: "kg"}}, `#,##0.#" kg"`},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}, NoGrouping: true}, `0`},
+	} {
+		actual, err := tt.formatter.ExcelFormatCode()
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterExcelFormatCodeUnsupported(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		expected  string
+		err       string
+	}{
+		{numfmt.NewCompactFormatter(), `#,##0.#`, "not supported by Excel format codes: Humanizer"},
+		{
+			&numfmt.Formatter{Scale: decimal.RequireFromString("2.54"), Grouping: numfmt.GroupingPattern{3, 2}},
+			`#,##0.##########`,
+			"not supported by Excel format codes: Grouping, Scale",
+		},
+		{
+			&numfmt.Formatter{Template: "{int}"},
+			`#,##0.##########`,
+			"not supported by Excel format codes: integer and fraction template verbs",
+		},
+	} {
+		actual, err := tt.formatter.ExcelFormatCode()
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+		require.Errorf(t, err, "%d", i)
+		assert.Truef(t, errors.Is(err, numfmt.ErrExcelUnsupported), "%d", i)
+		assert.Equalf(t, tt.err, err.Error(), "%d", i)
+	}
+}
@@ -0,0 +1,113 @@
+package numfmt
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Fit returns a copy of f adjusted to format all of values consistently, such as for a table column or chart axis.
+// Non-numeric values are ignored.
+//
+// If f has a Humanizer the copy always uses the unit of the value with the largest magnitude. The copy rounds to and
+// always displays the same number of decimal places for every value. That is the fewest places that show the smallest
+// non-zero value with two significant digits and keep distinct values distinct, but no more than needed to show every
+// value exactly.
+func (f *Formatter) Fit(values []interface{}) *Formatter {
+	fitted := f.clone()
+
+	ds := make([]decimal.Decimal, 0, len(values))
+	for _, v := range values {
+		if d, _, ok := f.toDecimal(v); ok {
+			ds = append(ds, f.scale(d))
+		}
+	}
+	if len(ds) == 0 {
+		return fitted
+	}
+
+	if f.Humanizer != nil {
+		maxAbs := ds[0].Abs()
+		for _, d := range ds[1:] {
+			if d.Abs().Cmp(maxAbs) > 0 {
+				maxAbs = d.Abs()
+			}
+		}
+		unit := f.Humanizer.unitFor(maxAbs)
+		unit.rounder = nil // Fit chooses the places.
+		fitted.Humanizer = &Humanizer{units: []humanizeUnit{unit}}
+		for i := range ds {
+			ds[i] = ds[i].Div(unit.divisor)
+		}
+	}
+
+	places := fitPlaces(ds)
+	fitted.Rounder = &Rounder{Places: places}
+	fitted.MinDecimalPlaces = places
+
+	return fitted
+}
+
+// fitPlaces returns the number of decimal places to consistently display ds as described by Formatter.Fit.
+func fitPlaces(ds []decimal.Decimal) int32 {
+	var maxPlaces int32
+	var minAbs decimal.Decimal
+	for _, d := range ds {
+		if p := exactPlaces(d); p > maxPlaces {
+			maxPlaces = p
+		}
+		if !d.IsZero() && (minAbs.IsZero() || d.Abs().Cmp(minAbs) < 0) {
+			minAbs = d.Abs()
+		}
+	}
+
+	var places int32
+	if !minAbs.IsZero() {
+		places = 1 - magnitude(minAbs)
+	}
+	if places < 0 {
+		places = 0
+	}
+
+	sorted := make([]decimal.Decimal, len(ds))
+	copy(sorted, ds)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+
+	for ; places < maxPlaces; places++ {
+		distinct := true
+		for i := 1; i < len(sorted); i++ {
+			if !sorted[i].Equal(sorted[i-1]) && sorted[i].Round(places).Equal(sorted[i-1].Round(places)) {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			break
+		}
+	}
+
+	if places > maxPlaces {
+		places = maxPlaces
+	}
+
+	return places
+}
+
+// magnitude returns the exponent of the most significant digit of d. e.g. 1234 => 3 and 0.05 => -2. d must not be zero.
+func magnitude(d decimal.Decimal) int32 {
+	digits := len(d.Coefficient().String())
+	if d.Sign() < 0 {
+		digits--
+	}
+	return int32(digits) - 1 + d.Exponent()
+}
+
+// exactPlaces returns the number of decimal places needed to represent d exactly.
+func exactPlaces(d decimal.Decimal) int32 {
+	s := d.String()
+	if idx := strings.IndexByte(s, '.'); idx != -1 {
+		return int32(len(s) - idx - 1)
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFit(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		args      []interface{}
+		expected  []string
+	}{
+		{&numfmt.Formatter{}, []interface{}{"1000", "2000", "3000"}, []string{"1,000", "2,000", "3,000"}},
+		{&numfmt.Formatter{}, []interface{}{"1.5", "100"}, []string{"1.5", "100.0"}},
+		{&numfmt.Formatter{}, []interface{}{"0.5"}, []string{"0.5"}},
+		{&numfmt.Formatter{}, []interface{}{"12.3456", "45.6789", "12.3499"}, []string{"12.346", "45.679", "12.350"}},
+		{&numfmt.Formatter{}, []interface{}{"0.001234", "0.002"}, []string{"0.0012", "0.0020"}},
+		{&numfmt.Formatter{}, []interface{}{"0", "10", "20"}, []string{"0", "10", "20"}},
+		{&numfmt.Formatter{}, []interface{}{"-3.25", "n/a", "1"}, []string{"-3.3", "n/a", "1.0"}},
+		{numfmt.NewPercentFormatter(), []interface{}{"0.125", "0.5"}, []string{"13%", "50%"}},
+		{numfmt.NewPercentFormatter(), []interface{}{"0.0125", "0.5"}, []string{"1.3%", "50.0%"}},
+		{numfmt.NewCompactFormatter(), []interface{}{"1200000", "950000", "1250000"}, []string{"1.20M", "0.95M", "1.25M"}},
+		{numfmt.NewCompactFormatter(), []interface{}{"1500", "2500000"}, []string{"0.0015M", "2.5000M"}},
+		{&numfmt.Formatter{}, []interface{}{}, []string{}},
+	} {
+		fitted := tt.formatter.Fit(tt.args)
+		actual := make([]string, len(tt.args))
+		for j, arg := range tt.args {
+			actual[j] = fitted.Format(arg)
+		}
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,63 @@
+package numfmt
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+// FloatConversion is how a Formatter converts float32 and float64 inputs to decimal numbers.
+type FloatConversion int
+
+const (
+	FloatShortest          FloatConversion = iota // The shortest decimal that converts back to the float. e.g. 0.1
+	FloatExact                                    // The exact value of the binary float. e.g. 0.1000000000000000055511…
+	FloatSignificantDigits                        // The float rounded to FloatDigits significant digits.
+)
+
+// floatToDecimal converts v to a decimal.Decimal according to FloatConversion. ok is false if v is not a finite float
+// or FloatConversion is FloatShortest.
+func (f *Formatter) floatToDecimal(v interface{}) (d decimal.Decimal, ok bool) {
+	var x float64
+	bitSize := 64
+	switch v := v.(type) {
+	case float64:
+		x = v
+	case float32:
+		x = float64(v)
+		bitSize = 32
+	default:
+		return decimal.Decimal{}, false
+	}
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return decimal.Decimal{}, false
+	}
+
+	switch f.FloatConversion {
+	case FloatExact:
+		return exactFloatDecimal(x), true
+	case FloatSignificantDigits:
+		digits := f.FloatDigits
+		if digits <= 0 {
+			digits = 15
+			if bitSize == 32 {
+				digits = 6
+			}
+		}
+		d, err := decimal.NewFromString(strconv.FormatFloat(x, 'g', digits, bitSize))
+		return d, err == nil
+	default:
+		return decimal.Decimal{}, false
+	}
+}
+
+// exactFloatDecimal returns the exact value of the finite float x. A float is an integer divided by a power of two
+// 2^k so it is the integer multiplied by 5^k divided by 10^k.
+func exactFloatDecimal(x float64) decimal.Decimal {
+	r := new(big.Rat).SetFloat64(x)
+	k := r.Denom().BitLen() - 1
+	pow := new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(k)), nil)
+	return decimal.NewFromBigInt(new(big.Int).Mul(r.Num(), pow), -int32(k))
+}
@@ -0,0 +1,38 @@
+package numfmt_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFloatConversion(t *testing.T) {
+	a, b := 0.1, 0.2
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{}, 0.1, "0.1"},
+		{&numfmt.Formatter{}, float32(0.1), "0.1"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatExact}, 0.1, "0.1000000000000000055511151231257827021181583404541015625"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatExact}, float32(0.1), "0.100000001490116119384765625"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatExact}, -1234.5, "-1,234.5"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatExact}, 1e20, "100,000,000,000,000,000,000"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatExact}, 0.0, "0"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatExact, Rounder: &numfmt.Rounder{Places: 2}}, 1.005, "1"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}, 1.005, "1.01"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatSignificantDigits}, a + b, "0.3"},
+		{&numfmt.Formatter{}, a + b, "0.30000000000000004"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatSignificantDigits, FloatDigits: 3}, 1234.5678, "1,230"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatSignificantDigits}, float32(16777217), "16,777,200"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatSignificantDigits}, 1e21, "1,000,000,000,000,000,000,000"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatExact}, math.NaN(), "NaN"},
+		{&numfmt.Formatter{FloatConversion: numfmt.FloatExact}, "0.1", "0.1"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,156 @@
+package numfmt
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Fraction configures a Formatter to write the fractional part of a number as a common fraction such as ½ or 3/16.
+// Numbers that are not within Tolerance of a fraction with one of the Denominators are formatted normally unless Round
+// is set.
+type Fraction struct {
+	// Denominators are the denominators to try in order. The first that approximates the number within Tolerance is
+	// used. The fraction is reduced to lowest terms. Default: 2 through 10
+	Denominators []int64
+
+	// Tolerance is the largest difference allowed between the number and the fraction. Default: 0.001
+	Tolerance decimal.Decimal
+
+	// Round rounds numbers that are not within Tolerance of any fraction to the nearest fraction with the last of
+	// Denominators instead of formatting them normally.
+	Round bool
+
+	// Glyphs writes fractions with a Unicode vulgar fraction character such as ½ when there is one. Otherwise
+	// fractions are written as numerator/denominator.
+	Glyphs bool
+}
+
+// NewMixedFractionFormatter returns a Formatter that rounds numbers to the nearest fraction with denominator and
+// formats them as mixed fractions. e.g. 1.19 => 1 3/16 with denominator 16. Fractions are reduced to lowest terms.
+func NewMixedFractionFormatter(denominator int64) *Formatter {
+	return &Formatter{
+		Fraction: &Fraction{
+			Denominators: []int64{denominator},
+			Round:        true,
+		},
+	}
+}
+
+var defaultFractionDenominators = []int64{2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+var defaultFractionTolerance = decimal.New(1, -3)
+
+// vulgarFractions are the Unicode vulgar fraction characters by numerator/denominator.
+var vulgarFractions = map[[2]int64]string{
+	{1, 2}: "½",
+	{1, 3}: "⅓", {2, 3}: "⅔",
+	{1, 4}: "¼", {3, 4}: "¾",
+	{1, 5}: "⅕", {2, 5}: "⅖", {3, 5}: "⅗", {4, 5}: "⅘",
+	{1, 6}: "⅙", {5, 6}: "⅚",
+	{1, 7}: "⅐",
+	{1, 8}: "⅛", {3, 8}: "⅜", {5, 8}: "⅝", {7, 8}: "⅞",
+	{1, 9}:  "⅑",
+	{1, 10}: "⅒",
+}
+
+// approximate returns the whole part of the absolute value of d and the numerator and denominator of the fraction that
+// approximates the rest. num is 0 if d is within Tolerance of a whole number. ok is false if no fraction is within
+// Tolerance.
+func (fr *Fraction) approximate(d decimal.Decimal) (whole decimal.Decimal, num, den int64, ok bool) {
+	denominators := fr.Denominators
+	if len(denominators) == 0 {
+		denominators = defaultFractionDenominators
+	}
+	tolerance := fr.Tolerance
+	if tolerance.IsZero() {
+		tolerance = defaultFractionTolerance
+	}
+
+	abs := d.Abs()
+	whole = abs.Truncate(0)
+	frac := abs.Sub(whole)
+
+	for _, den = range denominators {
+		if den <= 0 {
+			continue
+		}
+		dden := decimal.New(den, 0)
+		scaled := frac.Mul(dden)
+		rounded := scaled.Round(0)
+		if scaled.Sub(rounded).Abs().Cmp(tolerance.Mul(dden)) <= 0 {
+			return reduceFraction(whole, rounded.IntPart(), den)
+		}
+	}
+
+	if fr.Round && den > 0 {
+		return reduceFraction(whole, frac.Mul(decimal.New(den, 0)).Round(0).IntPart(), den)
+	}
+
+	return decimal.Decimal{}, 0, 0, false
+}
+
+// reduceFraction reduces num/den to lowest terms and carries a whole fraction into whole.
+func reduceFraction(whole decimal.Decimal, num, den int64) (decimal.Decimal, int64, int64, bool) {
+	if num == den {
+		return whole.Add(decimal.New(1, 0)), 0, 1, true
+	}
+	if num == 0 {
+		return whole, 0, 1, true
+	}
+	g := gcd(num, den)
+	return whole, num / g, den / g, true
+}
+
+// write writes the fraction num/den.
+func (fr *Fraction) write(sb *strings.Builder, num, den int64, digits []string) {
+	if fr.Glyphs {
+		if s, ok := vulgarFractions[[2]int64{num, den}]; ok {
+			sb.WriteString(s)
+			return
+		}
+	}
+	writeDigits(sb, strconv.FormatInt(num, 10), digits)
+	sb.WriteByte('/')
+	writeDigits(sb, strconv.FormatInt(den, 10), digits)
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// fractionState returns the formatState for d written with a common fraction. ok is false if no fraction approximates
+// d.
+func (f *Formatter) fractionState(d decimal.Decimal) (*formatState, bool) {
+	whole, num, den, ok := f.Fraction.approximate(d)
+	if !ok {
+		return nil, false
+	}
+
+	fs := &formatState{
+		f:       f,
+		neg:     d.Sign() < 0 && !(whole.IsZero() && num == 0),
+		zero:    whole.IsZero() && num == 0,
+		intPart: whole.String(),
+
+		fillIndex: -1,
+	}
+
+	if num != 0 {
+		sb := &strings.Builder{}
+		f.Fraction.write(sb, num, den, f.digits)
+		fs.fraction = sb.String()
+		switch {
+		case whole.IsZero():
+			fs.intPart = ""
+		case !f.Fraction.Glyphs || vulgarFractions[[2]int64{num, den}] == "":
+			fs.fraction = " " + fs.fraction
+		}
+	}
+
+	return fs, true
+}
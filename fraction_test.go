@@ -0,0 +1,65 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFraction(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true}}, "0.5", "½"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true}}, "0.333", "⅓"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true}}, "0.6667", "⅔"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true}}, "2.75", "2¾"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true}}, "-1.125", "-1⅛"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true}}, "0.7", "7/10"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true}}, "0.41", "0.41"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true, Denominators: []int64{7}}}, "0.4286", "3/7"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true, Denominators: []int64{7}}}, "1.4286", "1 3/7"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Glyphs: true}}, "1234.5", "1,234½"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{}}, "0.5", "1/2"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{}}, "2.75", "2 3/4"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{}}, "-0.25", "-1/4"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{}}, "3", "3"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{}}, "0", "0"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{}}, "-0.0001", "0"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{}}, "1.9999", "2"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Tolerance: decimal.New(5, -2)}}, "0.3", "1/3"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Denominators: []int64{8}}}, "0.5", "1/2"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Denominators: []int64{2, 4, 8, 16}, Round: true}}, "0.3", "5/16"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{Denominators: []int64{2, 4, 8, 16}, Round: true}}, "0.2505", "1/4"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{}, Template: "n cups"}, "1.5", "1 1/2 cups"},
+		{&numfmt.Formatter{Fraction: &numfmt.Fraction{}, Digits: numfmt.ArabicIndicDigits}, "0.5", "١/٢"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestNewMixedFractionFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		denominator int64
+		arg         interface{}
+		expected    string
+	}{
+		{16, "1.19", "1 3/16"},
+		{16, "1.1875", "1 3/16"},
+		{16, "0.5", "1/2"},
+		{16, "0.01", "0"},
+		{16, "0.99", "1"},
+		{16, "-2.37", "-2 3/8"},
+		{8, "12.4", "12 3/8"},
+		{32, "0.03125", "1/32"},
+		{3, "0.5", "2/3"},
+	} {
+		actual := numfmt.NewMixedFractionFormatter(tt.denominator).Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,80 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// maxCachedArgFormatters is the maximum number of Formatters kept by cachedFormatterFromArgs. Like maxCachedTemplates
+// it bounds the cache for arguments that come from data.
+const maxCachedArgFormatters = 1024
+
+// argFormatters maps the arguments of TemplateFunc to their compiled Formatter. It is cleared by Register and
+// RegisterLocale as they change the meaning of the Preset and Locale keys.
+var argFormatters struct {
+	mux        sync.RWMutex
+	formatters map[string]*Formatter
+	generation int // Incremented by resetArgFormatters so Formatters built before a reset are not cached.
+}
+
+// cachedFormatterFromArgs returns the Formatter for the key-value pairs in args like newFormatterFromArgs. The
+// Formatter is compiled and cached by the key-value pairs so a template calling TemplateFunc with the same arguments
+// for every cell of a table compiles one Formatter. The returned Formatter must not be changed. Arguments with values
+// that are not strings, numbers, or booleans are not cached.
+func cachedFormatterFromArgs(args []interface{}) (*Formatter, error) {
+	key, ok := argsCacheKey(args[:len(args)/2*2])
+	if !ok {
+		return newFormatterFromArgs(args)
+	}
+
+	argFormatters.mux.RLock()
+	f, ok := argFormatters.formatters[key]
+	generation := argFormatters.generation
+	argFormatters.mux.RUnlock()
+	if ok {
+		return f, nil
+	}
+
+	f, err := newFormatterFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	f = NewFormatter(f)
+
+	argFormatters.mux.Lock()
+	defer argFormatters.mux.Unlock()
+	if argFormatters.formatters == nil {
+		argFormatters.formatters = make(map[string]*Formatter)
+	}
+	if generation == argFormatters.generation && len(argFormatters.formatters) < maxCachedArgFormatters {
+		argFormatters.formatters[key] = f
+	}
+	return f, nil
+}
+
+// argsCacheKey returns a string that identifies args by their types and values. ok is false if an argument is not of
+// a basic type.
+func argsCacheKey(args []interface{}) (key string, ok bool) {
+	sb := &strings.Builder{}
+	for _, arg := range args {
+		switch arg.(type) {
+		case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			fmt.Fprintf(sb, "%T %#v;", arg, arg)
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
+
+// resetArgFormatters clears the Formatters cached by cachedFormatterFromArgs.
+func resetArgFormatters() {
+	argFormatters.mux.Lock()
+	defer argFormatters.mux.Unlock()
+	argFormatters.formatters = nil
+	argFormatters.generation++
+}
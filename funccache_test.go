@@ -0,0 +1,60 @@
+package numfmt_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFuncCacheRegister(t *testing.T) {
+	numfmt.Register("test-funccache", &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 1}})
+	actual, err := numfmt.TemplateFunc("Preset", "test-funccache", "1234.56")
+	require.NoError(t, err)
+	assert.Equal(t, "1,234.6", actual)
+
+	// Registering a preset again replaces the Formatter cached for the same arguments.
+	numfmt.Register("test-funccache", &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}})
+	actual, err = numfmt.TemplateFunc("Preset", "test-funccache", "1234.56")
+	require.NoError(t, err)
+	assert.Equal(t, "1,235", actual)
+}
+
+func TestTemplateFuncCacheArgumentTypes(t *testing.T) {
+	for i, tt := range []struct {
+		args     []interface{}
+		expected string
+	}{
+		{[]interface{}{"RoundPlaces", 1, "1.25"}, "1.3"},
+		{[]interface{}{"RoundPlaces", "1", "1.25"}, "1.3"},
+		{[]interface{}{"RoundPlaces", int32(0), "1.25"}, "1"},
+		{[]interface{}{"Template", "n%", "12"}, "12%"},
+		{[]interface{}{"Template", "n %", "12"}, "12 %"},
+		{[]interface{}{"RoundPlaces", decimal.New(2, 0), "1.255"}, "1.26"},
+	} {
+		for j := 0; j < 2; j++ {
+			actual, err := numfmt.TemplateFunc(tt.args...)
+			require.NoErrorf(t, err, "%d", i)
+			assert.Equalf(t, tt.expected, actual, "%d", i)
+		}
+	}
+}
+
+func TestTemplateFuncCacheConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				actual, err := numfmt.TemplateFunc("GroupSeparator", ".", "DecimalSeparator", ",", "1234.5")
+				assert.NoError(t, err)
+				assert.Equal(t, "1.234,5", actual)
+			}
+		}()
+	}
+	wg.Wait()
+}
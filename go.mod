@@ -3,6 +3,8 @@ module github.com/jackc/numfmt
 go 1.15
 
 require (
+	github.com/Rhymond/go-money v1.0.15
 	github.com/shopspring/decimal v1.2.0
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/text v0.13.0
 )
@@ -0,0 +1,65 @@
+package numfmt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Grouping determines the widths of the groups of digits in the integer part of a number.
+type Grouping interface {
+	// Groups returns the widths of the groups of digits of an integer with n digits from the decimal separator
+	// outward. The last width is used for any remaining digits. A width of zero or less ends grouping so the remaining
+	// digits are written as one group.
+	Groups(n int) []int
+}
+
+// GroupingPattern is a Grouping with fixed widths from the decimal separator outward. The last width repeats. e.g.
+// GroupingPattern{3, 2} for Indian grouping such as 12,34,56,789.
+type GroupingPattern []int
+
+// Groups implements Grouping.
+func (p GroupingPattern) Groups(n int) []int {
+	return p
+}
+
+// ParseGroupingPattern parses a comma separated list of group widths such as "3,2".
+func ParseGroupingPattern(s string) (GroupingPattern, error) {
+	parts := strings.Split(s, ",")
+	p := make(GroupingPattern, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid grouping pattern: %s", s)
+		}
+		p[i] = n
+	}
+	return p, nil
+}
+
+// GroupingFunc is an adapter to allow the use of ordinary functions as a Grouping.
+type GroupingFunc func(n int) []int
+
+// Groups implements Grouping by calling fn(n).
+func (fn GroupingFunc) Groups(n int) []int {
+	return fn(n)
+}
+
+// groupWidths returns the widths of the groups of an integer with n digits from the decimal separator outward.
+func (f *Formatter) groupWidths(n int) []int {
+	if f.NoGrouping || n < f.MinGroupingDigits {
+		return []int{0}
+	}
+	if f.Grouping != nil {
+		return f.Grouping.Groups(n)
+	}
+	return []int{f.groupSize()}
+}
+
+// groupWidth returns the width of group i of widths from the decimal separator outward.
+func groupWidth(widths []int, i int) int {
+	if i < len(widths) {
+		return widths[i]
+	}
+	return widths[len(widths)-1]
+}
@@ -0,0 +1,81 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterGrouping(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Grouping: numfmt.GroupingPattern{3}}, "1234567", "1,234,567"},
+		{&numfmt.Formatter{Grouping: numfmt.GroupingPattern{3, 2}}, "123456789", "12,34,56,789"},
+		{&numfmt.Formatter{Grouping: numfmt.GroupingPattern{3, 2}}, "-1234.5", "-1,234.5"},
+		{&numfmt.Formatter{Grouping: numfmt.GroupingPattern{3, 2}}, "123", "123"},
+		{&numfmt.Formatter{Grouping: numfmt.GroupingPattern{3, 0}}, "123456789", "123456,789"},
+		{&numfmt.Formatter{Grouping: numfmt.GroupingPattern{4}, GroupSize: 2}, "123456789", "1,2345,6789"},
+		{&numfmt.Formatter{Grouping: numfmt.GroupingPattern{}}, "123456789", "123456789"},
+		{&numfmt.Formatter{Grouping: numfmt.GroupingFunc(func(n int) []int {
+			if n <= 4 {
+				return []int{0}
+			}
+			return []int{3}
+		})}, "1234", "1234"},
+		{&numfmt.Formatter{Grouping: numfmt.GroupingFunc(func(n int) []int {
+			if n <= 4 {
+				return []int{0}
+			}
+			return []int{3}
+		})}, "12345", "12,345"},
+		{&numfmt.Formatter{MinGroupingDigits: 5}, "9999", "9999"},
+		{&numfmt.Formatter{MinGroupingDigits: 5}, "-9999.5", "-9999.5"},
+		{&numfmt.Formatter{MinGroupingDigits: 5}, "10000", "10,000"},
+		{&numfmt.Formatter{MinGroupingDigits: 5}, "1234567", "1,234,567"},
+		{&numfmt.Formatter{MinGroupingDigits: 5, Grouping: numfmt.GroupingPattern{3, 2}}, "1234", "1234"},
+		{&numfmt.Formatter{MinGroupingDigits: 5, Grouping: numfmt.GroupingPattern{3, 2}}, "123456", "1,23,456"},
+		{&numfmt.Formatter{MinGroupingDigits: 5, GroupSeparator: numfmt.NoBreakSpace}, "2024", "2024"},
+		{&numfmt.Formatter{NoGrouping: true}, "1234567", "1234567"},
+		{&numfmt.Formatter{NoGrouping: true}, "-1234567.89", "-1234567.89"},
+		{&numfmt.Formatter{NoGrouping: true, Grouping: numfmt.GroupingPattern{3, 2}}, "1234567", "1234567"},
+		{&numfmt.Formatter{NoGrouping: true, DecimalSeparator: ","}, "1234.5", "1234,5"},
+		{numfmt.NewFormatter(&numfmt.Formatter{NoGrouping: true, Currency: "USD"}), "1234.5", "$1234.50"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := &numfmt.Formatter{Grouping: numfmt.GroupingPattern{3, 2}}
+	d, err := f.ParseStrict("12,34,56,789")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "123456789", d.String())
+	}
+	_, err = f.ParseStrict("123,456,789")
+	assert.ErrorIs(t, err, numfmt.ErrMisplacedGroupSeparator)
+
+	f = &numfmt.Formatter{MinGroupingDigits: 5}
+	d, err = f.ParseStrict("9999")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "9999", d.String())
+	}
+	d, err = f.ParseStrict("10,000")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "10000", d.String())
+	}
+}
+
+func TestParseGroupingPattern(t *testing.T) {
+	p, err := numfmt.ParseGroupingPattern("3, 2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, numfmt.GroupingPattern{3, 2}, p)
+	}
+
+	for i, s := range []string{"", "a", "3,-1", "3,,2"} {
+		_, err := numfmt.ParseGroupingPattern(s)
+		assert.Errorf(t, err, "%d", i)
+	}
+}
@@ -0,0 +1,60 @@
+package numfmt
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/shopspring/decimal"
+)
+
+// Holder holds a Formatter that can be replaced while it is in use. It allows long-lived services to change
+// formatting at runtime such as from feature flags or admin settings. Format calls in progress finish with the
+// Formatter they started with. The zero value holds the zero value Formatter. A Holder must not be copied after it has
+// been used.
+type Holder struct {
+	v   atomic.Value // *Formatter
+	mux sync.Mutex   // Serializes Store and Update.
+}
+
+// NewHolder returns a Holder holding a copy of f.
+func NewHolder(f *Formatter) *Holder {
+	h := &Holder{}
+	h.Store(f)
+	return h
+}
+
+// Load returns the current Formatter. It must not be changed.
+func (h *Holder) Load() *Formatter {
+	if f, ok := h.v.Load().(*Formatter); ok {
+		return f
+	}
+	return zeroFormatter
+}
+
+// Store replaces the current Formatter with a copy of f compiled by NewFormatter so that Format calls after Store do
+// not pay to compile it. f is not used and may still be changed. Pointer fields such as Rounder and Humanizer are
+// shared with f and must not be changed.
+func (h *Holder) Store(f *Formatter) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	h.v.Store(NewFormatter(f))
+}
+
+// Update replaces the current Formatter with a copy changed by fn. e.g. to change only the rounding.
+func (h *Holder) Update(fn func(f *Formatter)) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	f := h.Load().clone()
+	fn(f)
+	h.v.Store(NewFormatter(f))
+}
+
+// Format formats v with the current Formatter. See Formatter.Format.
+func (h *Holder) Format(v interface{}) string {
+	return h.Load().Format(v)
+}
+
+// Parse parses s with the current Formatter. See Formatter.Parse.
+func (h *Holder) Parse(s string) (decimal.Decimal, error) {
+	return h.Load().Parse(s)
+}
@@ -0,0 +1,61 @@
+package numfmt_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHolder(t *testing.T) {
+	h := &numfmt.Holder{}
+	assert.Equal(t, "1,234.567", h.Format("1234.567"))
+
+	f := &numfmt.Formatter{GroupSeparator: " "}
+	h.Store(f)
+	f.GroupSeparator = "."
+	assert.Equal(t, "1 234.567", h.Format("1234.567"))
+
+	h.Update(func(f *numfmt.Formatter) {
+		f.Rounder = &numfmt.Rounder{Places: 1}
+	})
+	assert.Equal(t, "1 234.6", h.Format("1234.567"))
+
+	d, err := h.Parse("1 234.6")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1234.6", d.String())
+	}
+
+	h = numfmt.NewHolder(numfmt.NewEuropeanFormatter())
+	assert.Equal(t, "1.234,5", h.Format("1234.5"))
+}
+
+func TestHolderConcurrent(t *testing.T) {
+	h := numfmt.NewHolder(&numfmt.Formatter{})
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s := h.Format("1234.5")
+				if s != "1,234.5" && s != "1.234,5" {
+					t.Errorf("unexpected format: %s", s)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		h.Update(func(f *numfmt.Formatter) {
+			if f.GroupSeparator == "." {
+				f.GroupSeparator, f.DecimalSeparator = "", ""
+			} else {
+				f.GroupSeparator, f.DecimalSeparator = ".", ","
+			}
+		})
+	}
+	wg.Wait()
+}
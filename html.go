@@ -0,0 +1,40 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"html/template"
+)
+
+// FormatHTML formats v like Format and returns it as HTML for use with html/template. Template, NegativeTemplate, and
+// the separators are trusted as HTML so they may contain markup and character references such as "<sup>" or
+// "&nbsp;". If v is not a number it is escaped.
+func (f *Formatter) FormatHTML(v interface{}) template.HTML {
+	fs, s, ok := f.state(v)
+	if !ok {
+		return template.HTML(template.HTMLEscapeString(s))
+	}
+	return template.HTML(f.finish(fs, v))
+}
+
+// HTMLTemplateFunc is the html/template variant of TemplateFunc. It accepts the same keys and returns FormatHTML or its
+// result instead of Format.
+func HTMLTemplateFunc(args ...interface{}) (interface{}, error) {
+	args = expandMapArgs(args)
+	f, err := cachedFormatterFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args)%2 == 1 {
+		return f.FormatHTML(args[len(args)-1]), nil
+	}
+
+	return f.FormatHTML, nil
+}
+
+// FormatHTML formats v as HTML. See Formatter.FormatHTML.
+func (cf CompiledFormatter) FormatHTML(v interface{}) template.HTML {
+	return cf.formatter().FormatHTML(v)
+}
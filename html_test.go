@@ -0,0 +1,62 @@
+package numfmt_test
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterFormatHTML(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  template.HTML
+	}{
+		{&numfmt.Formatter{}, "1234.5", "1,234.5"},
+		{&numfmt.Formatter{GroupSeparator: "&nbsp;"}, "1234.5", "1&nbsp;234.5"},
+		{&numfmt.Formatter{Template: "-€n", MinDecimalPlaces: 2}, "-1234", "-€1,234.00"},
+		{&numfmt.Formatter{Template: "<\\s\\u\\p>$</\\s\\u\\p>n"}, "12", "<sup>$</sup>12"},
+		{&numfmt.Formatter{}, "<script>", "&lt;script&gt;"},
+	} {
+		actual := tt.formatter.FormatHTML(tt.arg)
+		if tt.expected != actual {
+			t.Errorf("%d. expected formatting %v to return %v, but got %v", i, tt.arg, tt.expected, actual)
+		}
+	}
+}
+
+func TestHTMLTemplateFunc(t *testing.T) {
+	actual, err := numfmt.HTMLTemplateFunc("GroupSeparator", "&thinsp;", "1234")
+	require.NoError(t, err)
+	assert.Equal(t, template.HTML("1&thinsp;234"), actual)
+
+	fn, err := numfmt.HTMLTemplateFunc("GroupSeparator", "&thinsp;")
+	require.NoError(t, err)
+	assert.Equal(t, template.HTML("1&thinsp;234"), fn.(func(interface{}) template.HTML)("1234"))
+
+	actual, err = numfmt.HTMLTemplateFunc(map[string]interface{}{"GroupSeparator": "&thinsp;"}, "1234")
+	require.NoError(t, err)
+	assert.Equal(t, template.HTML("1&thinsp;234"), actual)
+
+	_, err = numfmt.HTMLTemplateFunc("Unknown", "x")
+	assert.Error(t, err)
+}
+
+func ExampleHTMLTemplateFunc() {
+	t := template.Must(template.New("root").Funcs(template.FuncMap{
+		"numfmt": numfmt.HTMLTemplateFunc,
+	}).Parse(`<td>{{numfmt "GroupSeparator" "&nbsp;" .}}</td>`))
+
+	err := t.Execute(os.Stdout, "1234567")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// <td>1&nbsp;234&nbsp;567</td>
+}
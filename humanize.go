@@ -0,0 +1,195 @@
+package numfmt
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Humanizer scales numbers down by the largest unit they reach and appends that unit's suffix. For example, 1500 is
+// formatted as 1.5K by CompactHumanizer.
+type Humanizer struct {
+	units []humanizeUnit // In ascending order of divisor.
+}
+
+type humanizeUnit struct {
+	min     decimal.Decimal // Minimum absolute value the unit is used for.
+	divisor decimal.Decimal
+	suffix  string
+	rounder *Rounder // Overrides the Formatter Rounder if not nil.
+}
+
+// HumanizeRule is a unit of a Humanizer created by NewRuleHumanizer.
+type HumanizeRule struct {
+	Min     decimal.Decimal // Minimum absolute value the rule applies to.
+	Divisor decimal.Decimal // Numbers are divided by Divisor. Zero is the same as 1.
+	Suffix  string          // Written after the divided number.
+	Rounder *Rounder        // Rounds the divided number instead of Formatter.Rounder if not nil.
+}
+
+// NewRuleHumanizer returns a Humanizer with units defined by rules. It allows conventions that are not successive powers
+// of a base such as lakh and crore or dozens. rules must be in ascending order of Min. The first rule is used for
+// numbers smaller than its Min. e.g.
+//
+//   numfmt.NewRuleHumanizer([]numfmt.HumanizeRule{
+//     {Suffix: ""},
+//     {Min: decimal.New(12, 0), Divisor: decimal.New(12, 0), Suffix: " dozen"},
+//     {Min: decimal.New(144, 0), Divisor: decimal.New(144, 0), Suffix: " gross"},
+//   })
+func NewRuleHumanizer(rules []HumanizeRule) *Humanizer {
+	h := &Humanizer{units: make([]humanizeUnit, len(rules))}
+	for i, rule := range rules {
+		divisor := rule.Divisor
+		if divisor.IsZero() {
+			divisor = decimal.New(1, 0)
+		}
+		h.units[i] = humanizeUnit{min: rule.Min, divisor: divisor, suffix: rule.Suffix, rounder: rule.Rounder}
+	}
+	return h
+}
+
+// NewHumanizer returns a Humanizer whose units are successive powers of base with the given suffixes. The first suffix
+// is used for numbers smaller than base. e.g. NewHumanizer(1000, "", "K", "MM", "B") for finance abbreviations.
+func NewHumanizer(base int64, suffixes ...string) *Humanizer {
+	h := &Humanizer{units: make([]humanizeUnit, len(suffixes))}
+	divisor := decimal.New(1, 0)
+	for i, suffix := range suffixes {
+		h.units[i] = humanizeUnit{min: divisor, divisor: divisor, suffix: suffix}
+		divisor = divisor.Mul(decimal.New(base, 0))
+	}
+	return h
+}
+
+var (
+	// CompactHumanizer uses K, M, B, and T for thousands, millions, billions, and trillions.
+	CompactHumanizer = NewHumanizer(1000, "", "K", "M", "B", "T")
+
+	// SIHumanizer uses the SI prefixes k, M, G, T, P, and E.
+	SIHumanizer = NewHumanizer(1000, "", "k", "M", "G", "T", "P", "E")
+
+	// BytesHumanizer formats a number of bytes with decimal units such as kB and MB.
+	BytesHumanizer = NewHumanizer(1000, " B", " kB", " MB", " GB", " TB", " PB", " EB")
+
+	// IECBytesHumanizer formats a number of bytes with binary units such as KiB and MiB.
+	IECBytesHumanizer = NewHumanizer(1024, " B", " KiB", " MiB", " GiB", " TiB", " PiB", " EiB")
+
+	// BitRateHumanizer formats a number of bits per second with decimal units such as Kbps and Mbps. Use it for
+	// network speeds. Use BytesHumanizer with NewRateFormatter for byte rates.
+	BitRateHumanizer = NewHumanizer(1000, " bps", " Kbps", " Mbps", " Gbps", " Tbps", " Pbps")
+
+	// IndianHumanizer uses the Indian lakh and crore units. e.g. 250000 => 2.5 lakh and 12000000 => 1.2 crore.
+	IndianHumanizer = NewRuleHumanizer([]HumanizeRule{
+		{Suffix: ""},
+		{Min: decimal.New(1, 5), Divisor: decimal.New(1, 5), Suffix: " lakh"},
+		{Min: decimal.New(1, 7), Divisor: decimal.New(1, 7), Suffix: " crore"},
+	})
+
+	// FinanceHumanizer uses the finance abbreviations K, MM, B, and T for thousands, millions, billions, and trillions.
+	FinanceHumanizer = NewHumanizer(1000, "", "K", "MM", "B", "T")
+
+	// ThousandsHumanizer always formats numbers in thousands. e.g. 1234567 => 1,234.567 K
+	ThousandsHumanizer = NewFixedHumanizer(decimal.New(1, 3), " K")
+
+	// MillionsHumanizer always formats numbers in millions. e.g. 4500000 => 4.5 M
+	MillionsHumanizer = NewFixedHumanizer(decimal.New(1, 6), " M")
+
+	// BillionsHumanizer always formats numbers in billions. e.g. 4500000000 => 4.5 B
+	BillionsHumanizer = NewFixedHumanizer(decimal.New(1, 9), " B")
+)
+
+// NewFixedHumanizer returns a Humanizer that always divides numbers by divisor and appends suffix regardless of their
+// magnitude. This is the "amounts in thousands" convention of financial statements. Use an empty suffix when the
+// divisor is stated elsewhere such as a column heading.
+func NewFixedHumanizer(divisor decimal.Decimal, suffix string) *Humanizer {
+	return &Humanizer{units: []humanizeUnit{{min: divisor, divisor: divisor, suffix: suffix}}}
+}
+
+// unitFor returns the largest unit that abs reaches.
+func (h *Humanizer) unitFor(abs decimal.Decimal) humanizeUnit {
+	return h.units[h.unitIndex(abs)]
+}
+
+func (h *Humanizer) unitIndex(abs decimal.Decimal) int {
+	i := 0
+	for i+1 < len(h.units) && abs.Cmp(h.units[i+1].min) >= 0 {
+		i++
+	}
+	return i
+}
+
+// humanize scales d to the largest unit its absolute value reaches and rounds it with r if r is not nil. If rounding
+// carries the scaled value up to the next unit then the next unit is used instead. e.g. 999,950 is 1M not 1,000K.
+// unit is the unit used. rounded reports whether rounding changed the value.
+func (h *Humanizer) humanize(d decimal.Decimal, r *Rounder) (_ decimal.Decimal, unit humanizeUnit, rounded bool) {
+	i := h.unitIndex(d.Abs())
+	for {
+		unit = h.units[i]
+		scaled := d.Div(unit.divisor)
+		if r := unit.roundWith(r); r != nil {
+			roundedScaled := r.Round(scaled)
+			rounded = !roundedScaled.Equal(scaled)
+			scaled = roundedScaled
+		}
+		if i+1 < len(h.units) && scaled.Abs().Mul(unit.divisor).Cmp(h.units[i+1].min) >= 0 {
+			i++
+			continue
+		}
+		return scaled, unit, rounded
+	}
+}
+
+// roundWith returns the Rounder of u or r if u has none.
+func (u humanizeUnit) roundWith(r *Rounder) *Rounder {
+	if u.rounder != nil {
+		return u.rounder
+	}
+	return r
+}
+
+// NewCompactFormatter returns a Formatter that formats a number such as 1234567 to 1.2M.
+func NewCompactFormatter() *Formatter {
+	return &Formatter{
+		Humanizer: CompactHumanizer,
+		Rounder:   &Rounder{Places: 1},
+	}
+}
+
+// NewFinanceFormatter returns a Formatter that formats a number such as 1234567 to 1.2MM.
+func NewFinanceFormatter() *Formatter {
+	return &Formatter{
+		Humanizer: FinanceHumanizer,
+		Rounder:   &Rounder{Places: 1},
+	}
+}
+
+// NewBytesFormatter returns a Formatter that formats a number of bytes such as 1500000 to 1.5 MB.
+func NewBytesFormatter() *Formatter {
+	return &Formatter{
+		Humanizer: BytesHumanizer,
+		Rounder:   &Rounder{Places: 1},
+	}
+}
+
+// NewIECBytesFormatter returns a Formatter that formats a number of bytes such as 1572864 to 1.5 MiB.
+func NewIECBytesFormatter() *Formatter {
+	return &Formatter{
+		Humanizer: IECBytesHumanizer,
+		Rounder:   &Rounder{Places: 1},
+	}
+}
+
+// NewBitRateFormatter returns a Formatter that formats a number of bits per second such as 1500000 to 1.5 Mbps.
+func NewBitRateFormatter() *Formatter {
+	return &Formatter{
+		Humanizer: BitRateHumanizer,
+		Rounder:   &Rounder{Places: 1},
+	}
+}
+
+// NewRateFormatter returns a Formatter that formats a rate such as bytes per second by scaling it with h and appending
+// per. e.g. 1536000 => 1.5 MB/s with BytesHumanizer and "/s".
+func NewRateFormatter(h *Humanizer, per string) *Formatter {
+	return &Formatter{
+		Humanizer: h,
+		Rounder:   &Rounder{Places: 1},
+		Template:  "-n" + EscapeTemplate(per),
+	}
+}
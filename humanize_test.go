@@ -0,0 +1,85 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+)
+
+func TestHumanizer(t *testing.T) {
+	dozens := numfmt.NewRuleHumanizer([]numfmt.HumanizeRule{
+		{},
+		{Min: decimal.New(12, 0), Divisor: decimal.New(12, 0), Suffix: " dozen"},
+		{Min: decimal.New(144, 0), Divisor: decimal.New(144, 0), Suffix: " gross"},
+	})
+	gameEconomy := numfmt.NewRuleHumanizer([]numfmt.HumanizeRule{
+		{},
+		{Min: decimal.New(1, 3), Divisor: decimal.New(1, 3), Suffix: "K", Rounder: &numfmt.Rounder{SignificantDigits: 3}},
+		{Min: decimal.New(1, 6), Divisor: decimal.New(1, 6), Suffix: "M"},
+		{Min: decimal.New(1, 9), Divisor: decimal.New(1, 9), Suffix: "B"},
+		{Min: decimal.New(1, 12), Divisor: decimal.New(1, 12), Suffix: "T"},
+		{Min: decimal.New(1, 15), Divisor: decimal.New(1, 15), Suffix: "aa"},
+	})
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{numfmt.NewCompactFormatter(), "0", "0"},
+		{numfmt.NewCompactFormatter(), "999", "999"},
+		{numfmt.NewCompactFormatter(), "1000", "1K"},
+		{numfmt.NewCompactFormatter(), "1234", "1.2K"},
+		{numfmt.NewCompactFormatter(), "-1234567", "-1.2M"},
+		{numfmt.NewCompactFormatter(), "999950", "1M"},
+		{numfmt.NewCompactFormatter(), "999949", "999.9K"},
+		{numfmt.NewCompactFormatter(), "3400000000", "3.4B"},
+		{numfmt.NewCompactFormatter(), "5000000000000000", "5,000T"},
+		{numfmt.NewCompactFormatter(), "0.5", "0.5"},
+		{numfmt.NewFinanceFormatter(), "850000", "850K"},
+		{numfmt.NewFinanceFormatter(), "1234567", "1.2MM"},
+		{numfmt.NewFinanceFormatter(), "3400000000", "3.4B"},
+		{numfmt.NewFinanceFormatter(), "-999950", "-1MM"},
+		{&numfmt.Formatter{Humanizer: numfmt.NewHumanizer(1000, "", "M", "MM", "B")}, "2500", "2.5M"},
+		{&numfmt.Formatter{Humanizer: numfmt.NewHumanizer(10000, "", "万", "亿")}, "123456789", "1.23456789亿"},
+		{numfmt.NewBytesFormatter(), "512", "512 B"},
+		{numfmt.NewBytesFormatter(), "1500000", "1.5 MB"},
+		{numfmt.NewIECBytesFormatter(), "1536", "1.5 KiB"},
+		{numfmt.NewIECBytesFormatter(), "1572864", "1.5 MiB"},
+		{numfmt.NewBitRateFormatter(), "800", "800 bps"},
+		{numfmt.NewBitRateFormatter(), "1500000", "1.5 Mbps"},
+		{numfmt.NewBitRateFormatter(), "10000000000", "10 Gbps"},
+		{numfmt.NewBitRateFormatter(), "999950", "1 Mbps"},
+		{&numfmt.Formatter{Humanizer: numfmt.SIHumanizer, Template: "-n\\m"}, "1500", "1.5km"},
+		{&numfmt.Formatter{Humanizer: numfmt.CompactHumanizer}, "1234", "1.234K"},
+		{&numfmt.Formatter{Humanizer: numfmt.CompactHumanizer, Template: "-$n"}, "-2500000", "-$2.5M"},
+		{&numfmt.Formatter{Humanizer: numfmt.ThousandsHumanizer, Rounder: &numfmt.Rounder{Places: 0}}, "1234567", "1,235 K"},
+		{&numfmt.Formatter{Humanizer: numfmt.ThousandsHumanizer, Rounder: &numfmt.Rounder{Places: 0}}, "12", "0 K"},
+		{&numfmt.Formatter{Humanizer: numfmt.ThousandsHumanizer, Rounder: &numfmt.Rounder{Places: 0}}, "5000000000", "5,000,000 K"},
+		{&numfmt.Formatter{Humanizer: numfmt.MillionsHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, "4500000", "4.5 M"},
+		{&numfmt.Formatter{Humanizer: numfmt.MillionsHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, "-999999", "-1 M"},
+		{&numfmt.Formatter{Humanizer: numfmt.BillionsHumanizer, Rounder: &numfmt.Rounder{Places: 2}}, "1234567890", "1.23 B"},
+		{&numfmt.Formatter{Humanizer: numfmt.NewFixedHumanizer(decimal.New(1, 3), ""), NegativeTemplate: "(n)"}, "-2500", "(2.5)"},
+		{numfmt.NewRateFormatter(numfmt.BytesHumanizer, "/s"), "1536000", "1.5 MB/s"},
+		{numfmt.NewRateFormatter(numfmt.IECBytesHumanizer, "/s"), "1572864", "1.5 MiB/s"},
+		{numfmt.NewRateFormatter(numfmt.CompactHumanizer, " req/min"), "12345", "12.3K req/min"},
+		{numfmt.NewRateFormatter(numfmt.CompactHumanizer, "/s"), "-950", "-950/s"},
+		{&numfmt.Formatter{Humanizer: numfmt.IndianHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, "99999", "99,999"},
+		{&numfmt.Formatter{Humanizer: numfmt.IndianHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, "250000", "2.5 lakh"},
+		{&numfmt.Formatter{Humanizer: numfmt.IndianHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, "12000000", "1.2 crore"},
+		{&numfmt.Formatter{Humanizer: numfmt.IndianHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, "9999999", "1 crore"},
+		{&numfmt.Formatter{Humanizer: dozens}, "6", "6"},
+		{&numfmt.Formatter{Humanizer: dozens}, "30", "2.5 dozen"},
+		{&numfmt.Formatter{Humanizer: dozens}, "288", "2 gross"},
+		{&numfmt.Formatter{Humanizer: gameEconomy, Rounder: &numfmt.Rounder{Places: 1}}, "1234567", "1.2M"},
+		{&numfmt.Formatter{Humanizer: gameEconomy, Rounder: &numfmt.Rounder{Places: 1}}, "1234567890123456", "1.2aa"},
+		{&numfmt.Formatter{Humanizer: gameEconomy, Rounder: &numfmt.Rounder{Places: 1}}, "999", "999"},
+		{&numfmt.Formatter{Humanizer: gameEconomy, Rounder: &numfmt.Rounder{Places: 1}}, "1500", "1.50K"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		if tt.expected != actual {
+			t.Errorf("%d. expected formatting %v to return %v, but got %v", i, tt.arg, tt.expected, actual)
+		}
+	}
+}
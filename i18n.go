@@ -0,0 +1,33 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"text/template"
+
+	"golang.org/x/text/language"
+)
+
+// LocaleFuncMap returns a template.FuncMap like FuncMap whose functions format numbers with the separators and digits
+// of the locale tag. It is intended for message templates of translation libraries such as the LocalizeConfig.Funcs
+// of github.com/nicksnyder/go-i18n so a translated message embeds numbers localized for the language of the message
+// catalog. e.g. with the de tag {{numfmt "RoundPlaces" 2 .Total}} => 1.234,50. A Locale key given to numfmt overrides
+// tag.
+//
+// Messages printed with a golang.org/x/text/message.Printer such as those extracted by gotext do not need a FuncMap.
+// Pass numbers wrapped with Formatter.Localized as message arguments instead.
+func LocaleFuncMap(tag language.Tag) template.FuncMap {
+	l := matchLocale(tag)
+	locale := tag.String()
+	return template.FuncMap{
+		"numfmt": func(args ...interface{}) (interface{}, error) {
+			args = expandMapArgs(args)
+			return TemplateFunc(append([]interface{}{"Locale", locale}, args...)...)
+		},
+		"numfmtUSD":     NewUSDFormatter().localize(l).Format,
+		"numfmtPercent": NewPercentFormatter().localize(l).Format,
+		"numfmtBytes":   NewBytesFormatter().localize(l).Format,
+		"numfmtCompact": NewCompactFormatter().localize(l).Format,
+	}
+}
@@ -0,0 +1,44 @@
+package numfmt_test
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestLocaleFuncMap(t *testing.T) {
+	for i, tt := range []struct {
+		tag      language.Tag
+		message  string
+		expected string
+	}{
+		{language.German, `{{numfmt "RoundPlaces" 2 "MinDecimalPlaces" 2 .}} gesamt`, "1.234,57 gesamt"},
+		{language.French, `{{numfmt .}}`, "1\u202f234,567"},
+		{language.English, `{{numfmt .}}`, "1,234.567"},
+		{language.German, `{{numfmt (dict "RoundPlaces" 1) .}}`, "1.234,6"},
+		{language.German, `{{numfmt "Locale" "en" .}}`, "1,234.567"},
+		{language.German, `{{numfmtUSD .}}`, "$1.234,567"},
+		{language.German, `{{numfmtCompact .}}`, "1,2K"},
+		{language.MustParse("ar-EG"), `{{numfmt "RoundPlaces" 0 .}}`, "١٬٢٣٥"},
+	} {
+		funcs := numfmt.LocaleFuncMap(tt.tag)
+		funcs["dict"] = func(kv ...interface{}) map[string]interface{} {
+			m := map[string]interface{}{}
+			for j := 0; j < len(kv)-1; j += 2 {
+				m[kv[j].(string)] = kv[j+1]
+			}
+			return m
+		}
+		tmpl, err := template.New("message").Funcs(funcs).Parse(tt.message)
+		require.NoErrorf(t, err, "%d", i)
+		sb := &strings.Builder{}
+		err = tmpl.Execute(sb, 1234.567)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, sb.String(), "%d", i)
+	}
+}
@@ -0,0 +1,91 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	money "github.com/Rhymond/go-money"
+	"github.com/shopspring/decimal"
+)
+
+// derefInput returns v with pointers followed. Pointers that are numbers themselves such as *big.Rat or that
+// implement encoding.TextMarshaler or fmt.Stringer are not followed. isNil is true if v or a followed pointer is nil.
+func derefInput(v interface{}) (_ interface{}, isNil bool) {
+	if v == nil {
+		return nil, true
+	}
+
+	switch v.(type) {
+	case string, decimal.Decimal, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v, false
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, true
+		}
+		switch rv.Interface().(type) {
+		case *big.Rat, *big.Int, *big.Float, *money.Money, BigDecimal, encoding.TextMarshaler, fmt.Stringer:
+			return rv.Interface(), false
+		}
+		rv = rv.Elem()
+	}
+
+	return builtinNumber(rv), false
+}
+
+// builtinNumberTypes maps numeric kinds to their predeclared types.
+var builtinNumberTypes = [...]reflect.Type{
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
+// builtinNumber returns the value of rv. If rv has a named type with a numeric underlying type such as
+// type Cents int64 it is converted to the predeclared type so it is formatted the same way without fmt.Sprint. Types
+// that implement encoding.TextMarshaler, fmt.Stringer, or BigDecimal are not converted so those methods are used.
+func builtinNumber(rv reflect.Value) interface{} {
+	v := rv.Interface()
+	k := rv.Kind()
+	if int(k) >= len(builtinNumberTypes) {
+		return v
+	}
+	t := builtinNumberTypes[k]
+	if t == nil || rv.Type() == t {
+		return v
+	}
+	switch v.(type) {
+	case encoding.TextMarshaler, fmt.Stringer, BigDecimal:
+		return v
+	}
+	return rv.Convert(t).Interface()
+}
+
+// inputString returns v as a string to convert to a number. It uses encoding.TextMarshaler and fmt.Stringer before
+// fmt.Sprint so custom numeric types that write plain numbers work.
+func inputString(v interface{}) string {
+	if tm, ok := v.(encoding.TextMarshaler); ok {
+		if b, err := tm.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,30 @@
+//go:build numfmt_noreflect
+// +build numfmt_noreflect
+
+package numfmt
+
+import (
+	"encoding"
+)
+
+// derefInput returns v. Without reflection pointers are not followed so isNil is only true if v is nil.
+func derefInput(v interface{}) (_ interface{}, isNil bool) {
+	return v, v == nil
+}
+
+// inputString returns v as a string to convert to a number. Without fmt.Sprint only types that implement
+// encoding.TextMarshaler or have a String method can be converted. Other types are written as "".
+func inputString(v interface{}) string {
+	if tm, ok := v.(encoding.TextMarshaler); ok {
+		if b, err := tm.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+	if s, ok := v.(interface{ String() string }); ok {
+		return s.String()
+	}
+	return ""
+}
+
+// resetArgFormatters does nothing as TemplateFunc is not available with the numfmt_noreflect tag.
+func resetArgFormatters() {}
@@ -0,0 +1,267 @@
+package numfmt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/language"
+)
+
+// IntlOptions are the options of the ECMAScript Intl.NumberFormat constructor. They can be decoded from the same JSON
+// options object a browser uses so a front-end and back-end share one formatting spec. Options without an equivalent
+// in Formatter are rejected by NewIntlFormatter rather than ignored.
+type IntlOptions struct {
+	Style           string `json:"style,omitempty"`           // decimal, percent, currency, or unit. Default: decimal
+	Currency        string `json:"currency,omitempty"`        // ISO 4217 code. Required with style currency.
+	CurrencyDisplay string `json:"currencyDisplay,omitempty"` // symbol, narrowSymbol, or code. Default: symbol
+	CurrencySign    string `json:"currencySign,omitempty"`    // standard or accounting. Default: standard
+	Unit            string `json:"unit,omitempty"`            // Simple unit such as kilogram. Required with style unit.
+	UnitDisplay     string `json:"unitDisplay,omitempty"`     // short, narrow, or long. Default: short
+	Notation        string `json:"notation,omitempty"`        // standard, scientific, or compact. Default: standard
+	CompactDisplay  string `json:"compactDisplay,omitempty"`  // short or long. Default: short
+	SignDisplay     string `json:"signDisplay,omitempty"`     // auto, always, exceptZero, negative, or never
+	RoundingMode    string `json:"roundingMode,omitempty"`    // Only halfExpand is supported. Default: halfExpand
+
+	// UseGrouping is true, false, "always", "auto", or "min2". "min2" only groups numbers of 5 or more digits.
+	// Default: auto
+	UseGrouping interface{} `json:"useGrouping,omitempty"`
+
+	// MinimumIntegerDigits must be 1 if it is set.
+	MinimumIntegerDigits     *int `json:"minimumIntegerDigits,omitempty"`
+	MinimumFractionDigits    *int `json:"minimumFractionDigits,omitempty"`
+	MaximumFractionDigits    *int `json:"maximumFractionDigits,omitempty"`
+	MinimumSignificantDigits *int `json:"minimumSignificantDigits,omitempty"`
+	MaximumSignificantDigits *int `json:"maximumSignificantDigits,omitempty"`
+}
+
+// intlUnit is the English data for a unit of IntlOptions.
+type intlUnit struct {
+	short, shortPlural string
+	narrow             string
+	long, longPlural   string
+	noSpace            bool // The short form is written without a space. e.g. 12°C
+}
+
+// intlUnits are the units of IntlOptions by their Intl.NumberFormat identifiers.
+var intlUnits = map[string]intlUnit{
+	"percent":            {short: "%", narrow: "%", long: "percent", longPlural: "percent", noSpace: true},
+	"gram":               {short: "g", narrow: "g", long: "gram", longPlural: "grams"},
+	"kilogram":           {short: "kg", narrow: "kg", long: "kilogram", longPlural: "kilograms"},
+	"millimeter":         {short: "mm", narrow: "mm", long: "millimeter", longPlural: "millimeters"},
+	"centimeter":         {short: "cm", narrow: "cm", long: "centimeter", longPlural: "centimeters"},
+	"meter":              {short: "m", narrow: "m", long: "meter", longPlural: "meters"},
+	"kilometer":          {short: "km", narrow: "km", long: "kilometer", longPlural: "kilometers"},
+	"inch":               {short: "in", narrow: "″", long: "inch", longPlural: "inches"},
+	"foot":               {short: "ft", narrow: "′", long: "foot", longPlural: "feet"},
+	"mile":               {short: "mi", narrow: "mi", long: "mile", longPlural: "miles"},
+	"milliliter":         {short: "mL", narrow: "mL", long: "milliliter", longPlural: "milliliters"},
+	"liter":              {short: "L", narrow: "L", long: "liter", longPlural: "liters"},
+	"millisecond":        {short: "ms", narrow: "ms", long: "millisecond", longPlural: "milliseconds"},
+	"second":             {short: "sec", narrow: "s", long: "second", longPlural: "seconds"},
+	"minute":             {short: "min", narrow: "m", long: "minute", longPlural: "minutes"},
+	"hour":               {short: "hr", narrow: "h", long: "hour", longPlural: "hours"},
+	"day":                {short: "day", shortPlural: "days", narrow: "d", long: "day", longPlural: "days"},
+	"byte":               {short: "byte", narrow: "B", long: "byte", longPlural: "bytes"},
+	"kilobyte":           {short: "kB", narrow: "kB", long: "kilobyte", longPlural: "kilobytes"},
+	"megabyte":           {short: "MB", narrow: "MB", long: "megabyte", longPlural: "megabytes"},
+	"gigabyte":           {short: "GB", narrow: "GB", long: "gigabyte", longPlural: "gigabytes"},
+	"celsius":            {short: "°C", narrow: "°C", long: "degree Celsius", longPlural: "degrees Celsius", noSpace: true},
+	"fahrenheit":         {short: "°F", narrow: "°", long: "degree Fahrenheit", longPlural: "degrees Fahrenheit", noSpace: true},
+	"kilometer-per-hour": {short: "km/h", narrow: "km/h", long: "kilometer per hour", longPlural: "kilometers per hour"},
+	"mile-per-hour":      {short: "mph", narrow: "mph", long: "mile per hour", longPlural: "miles per hour"},
+}
+
+// compactLongHumanizer writes compact numbers with words as Intl.NumberFormat does with compactDisplay long.
+var compactLongHumanizer = NewHumanizer(1000, "", " thousand", " million", " billion", " trillion")
+
+// compactRounder rounds numbers as Intl.NumberFormat does with compact notation and no digit options. Numbers of 2 or
+// more integer digits are rounded to integers and smaller numbers to 2 significant digits.
+var compactRounder = &Rounder{Rules: []PrecisionRule{
+	{Min: decimal.New(10, 0), Places: 0},
+	{Min: decimal.New(1, 0), Places: 1},
+	{Min: decimal.New(1, -1), Places: 2},
+	{Min: decimal.New(1, -2), Places: 3},
+	{Min: decimal.Decimal{}, Places: 4},
+}}
+
+// NewIntlFormatter returns a Formatter that formats numbers like new Intl.NumberFormat(locale, opts) in ECMAScript.
+// locale is a BCP 47 tag such as "de-DE" or empty for English. Units and currency symbols are English. An error is
+// returned for invalid options and for options that are not supported.
+func NewIntlFormatter(locale string, opts IntlOptions) (*Formatter, error) {
+	f := &Formatter{}
+	if locale != "" {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return nil, err
+		}
+		matchLocale(tag).apply(f)
+	}
+
+	minFraction, maxFraction := 0, 3
+	switch opts.Style {
+	case "", "decimal":
+	case "percent":
+		f.Shift = 2
+		f.Template = "-n%"
+		maxFraction = 0
+	case "currency":
+		if opts.Currency == "" {
+			return nil, fmt.Errorf("currency is required with style currency")
+		}
+		c := lookupCurrency(opts.Currency)
+		minFraction, maxFraction = int(c.places), int(c.places)
+		switch opts.CurrencyDisplay {
+		case "", "symbol", "narrowSymbol":
+			f.CurrencySymbol = c.symbol
+		case "code":
+			f.CurrencySymbol = strings.ToUpper(opts.Currency)
+			f.CurrencySeparator = NoBreakSpace
+		default:
+			return nil, fmt.Errorf("unsupported currencyDisplay: %s", opts.CurrencyDisplay)
+		}
+		switch opts.CurrencySign {
+		case "", "standard":
+		case "accounting":
+			f.CurrencySign = CurrencySignParentheses
+		default:
+			return nil, fmt.Errorf("invalid currencySign: %s", opts.CurrencySign)
+		}
+	case "unit":
+		u, ok := intlUnits[opts.Unit]
+		if !ok {
+			return nil, fmt.Errorf("unsupported unit: %s", opts.Unit)
+		}
+		switch opts.UnitDisplay {
+		case "", "short":
+			f.Unit = &Unit{Symbol: u.short, Plural: u.shortPlural, NoSpace: u.noSpace}
+		case "narrow":
+			f.Unit = &Unit{Symbol: u.narrow, NoSpace: true}
+		case "long":
+			f.Unit = &Unit{Symbol: u.long, Plural: u.longPlural}
+		default:
+			return nil, fmt.Errorf("invalid unitDisplay: %s", opts.UnitDisplay)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported style: %s", opts.Style)
+	}
+
+	compact := false
+	switch opts.Notation {
+	case "", "standard":
+	case "scientific":
+		f.Scientific = &Scientific{Above: decimal.New(1, 0), Below: decimal.New(1, 0), UppercaseE: true}
+	case "compact":
+		compact = true
+		switch opts.CompactDisplay {
+		case "", "short":
+			f.Humanizer = CompactHumanizer
+		case "long":
+			f.Humanizer = compactLongHumanizer
+		default:
+			return nil, fmt.Errorf("invalid compactDisplay: %s", opts.CompactDisplay)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported notation: %s", opts.Notation)
+	}
+
+	if opts.RoundingMode != "" && opts.RoundingMode != "halfExpand" {
+		return nil, fmt.Errorf("unsupported roundingMode: %s", opts.RoundingMode)
+	}
+	if opts.MinimumIntegerDigits != nil && *opts.MinimumIntegerDigits != 1 {
+		return nil, fmt.Errorf("unsupported minimumIntegerDigits: %d", *opts.MinimumIntegerDigits)
+	}
+
+	switch {
+	case opts.MinimumSignificantDigits != nil || opts.MaximumSignificantDigits != nil:
+		minSignificant, maxSignificant := 1, 21
+		if opts.MinimumSignificantDigits != nil {
+			minSignificant = *opts.MinimumSignificantDigits
+		}
+		if opts.MaximumSignificantDigits != nil {
+			maxSignificant = *opts.MaximumSignificantDigits
+		}
+		if minSignificant < 1 || maxSignificant > 21 || minSignificant > maxSignificant {
+			return nil, fmt.Errorf("significant digits out of range: %d to %d", minSignificant, maxSignificant)
+		}
+		f.Rounder = &Rounder{SignificantDigits: int32(maxSignificant), MinSignificantDigits: int32(minSignificant)}
+	case compact && opts.MinimumFractionDigits == nil && opts.MaximumFractionDigits == nil:
+		f.Rounder = compactRounder
+	default:
+		if opts.MinimumFractionDigits != nil {
+			minFraction = *opts.MinimumFractionDigits
+			if maxFraction < minFraction {
+				maxFraction = minFraction
+			}
+		}
+		if opts.MaximumFractionDigits != nil {
+			maxFraction = *opts.MaximumFractionDigits
+			if opts.MinimumFractionDigits == nil && minFraction > maxFraction {
+				minFraction = maxFraction
+			}
+		}
+		if minFraction < 0 || maxFraction > 100 || minFraction > maxFraction {
+			return nil, fmt.Errorf("fraction digits out of range: %d to %d", minFraction, maxFraction)
+		}
+		f.Rounder = &Rounder{Places: int32(maxFraction)}
+		f.MinDecimalPlaces = int32(minFraction)
+	}
+
+	switch opts.UseGrouping {
+	case nil, "auto":
+	case true, "always", "true":
+		f.MinGroupingDigits = 0
+	case false, "false":
+		f.NoGrouping = true
+	case "min2":
+		f.MinGroupingDigits = 5
+	default:
+		return nil, fmt.Errorf("invalid useGrouping: %v", opts.UseGrouping)
+	}
+
+	switch opts.SignDisplay {
+	case "", "auto", "negative":
+	case "always", "exceptZero", "never":
+		template, negativeTemplate := f.Template, f.NegativeTemplate
+		if template == "" {
+			template = "-n"
+			if f.symbol() != "" {
+				template, negativeTemplate = f.currencyTemplates()
+			}
+		}
+		if opts.SignDisplay == "never" {
+			f.Template = replaceTemplateVerb(template, '-', "")
+			f.NegativeTemplate = f.Template
+		} else {
+			f.Template = replaceTemplateVerb(template, '-', "+")
+			f.NegativeTemplate = negativeTemplate
+			if opts.SignDisplay == "exceptZero" {
+				f.ZeroSign = ZeroSignNone
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid signDisplay: %s", opts.SignDisplay)
+	}
+
+	return f, nil
+}
+
+// replaceTemplateVerb returns template with the verb replaced by replacement. Escaped characters are not replaced.
+func replaceTemplateVerb(template string, verb byte, replacement string) string {
+	sb := &strings.Builder{}
+	for i := 0; i < len(template); i++ {
+		switch template[i] {
+		case '\\':
+			sb.WriteByte('\\')
+			if i+1 < len(template) {
+				i++
+				sb.WriteByte(template[i])
+			}
+		case verb:
+			sb.WriteString(replacement)
+		default:
+			sb.WriteByte(template[i])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,95 @@
+package numfmt_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIntlFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		locale   string
+		options  string
+		arg      interface{}
+		expected string
+	}{
+		{"", `{}`, "1234.5678", "1,234.568"},
+		{"en-US", `{}`, -0.5, "-0.5"},
+		{"de-DE", `{}`, "1234.5678", "1.234,568"},
+		{"", `{"style": "percent"}`, "0.256", "26%"},
+		{"", `{"style": "percent", "minimumFractionDigits": 1}`, "0.256", "25.6%"},
+		{"", `{"style": "currency", "currency": "USD"}`, "-1234.5", "-$1,234.50"},
+		{"", `{"style": "currency", "currency": "JPY"}`, "1234.5", "¥1,235"},
+		{"de-DE", `{"style": "currency", "currency": "EUR"}`, "1234.5", "1.234,50\u00a0€"},
+		{"", `{"style": "currency", "currency": "usd", "currencyDisplay": "code"}`, "1234.5", "USD\u00a01,234.50"},
+		{"", `{"style": "currency", "currency": "USD", "currencySign": "accounting"}`, "-5", "($5.00)"},
+		{"", `{"style": "currency", "currency": "USD", "maximumFractionDigits": 0}`, "5.5", "$6"},
+		{"", `{"notation": "compact"}`, 1234, "1.2K"},
+		{"", `{"notation": "compact"}`, 12345, "12K"},
+		{"", `{"notation": "compact"}`, 1500000, "1.5M"},
+		{"", `{"notation": "compact"}`, "0.123", "0.12"},
+		{"", `{"notation": "compact", "compactDisplay": "long"}`, 1234, "1.2 thousand"},
+		{"", `{"notation": "compact", "maximumFractionDigits": 2}`, 1234, "1.23K"},
+		{"", `{"notation": "scientific"}`, 123456, "1.235E5"},
+		{"", `{"notation": "scientific"}`, "0.00012", "1.2E-4"},
+		{"", `{"maximumSignificantDigits": 3}`, "1234.5", "1,230"},
+		{"", `{"maximumSignificantDigits": 3}`, "1.5", "1.5"},
+		{"", `{"minimumSignificantDigits": 3}`, "1.5", "1.50"},
+		{"", `{"minimumFractionDigits": 2}`, "1.5", "1.50"},
+		{"", `{"minimumFractionDigits": 2}`, "1.23456", "1.235"},
+		{"", `{"maximumFractionDigits": 0}`, "2.5", "3"},
+		{"", `{"useGrouping": false}`, 1234567, "1234567"},
+		{"", `{"useGrouping": "min2"}`, 1234, "1234"},
+		{"", `{"useGrouping": "min2"}`, 12345, "12,345"},
+		{"es-ES", `{}`, 1234, "1234"},
+		{"es-ES", `{"useGrouping": "always"}`, 1234, "1.234"},
+		{"en-IN", `{}`, 1234567, "12,34,567"},
+		{"", `{"signDisplay": "always"}`, 5, "+5"},
+		{"", `{"signDisplay": "always"}`, 0, "+0"},
+		{"", `{"signDisplay": "always"}`, -5, "-5"},
+		{"", `{"signDisplay": "exceptZero"}`, 0, "0"},
+		{"", `{"signDisplay": "never"}`, -5, "5"},
+		{"", `{"style": "currency", "currency": "USD", "signDisplay": "always"}`, 5, "+$5.00"},
+		{"", `{"style": "percent", "signDisplay": "exceptZero"}`, "0.05", "+5%"},
+		{"", `{"style": "unit", "unit": "kilogram"}`, 12, "12 kg"},
+		{"", `{"style": "unit", "unit": "kilometer", "unitDisplay": "narrow"}`, 12, "12km"},
+		{"", `{"style": "unit", "unit": "kilogram", "unitDisplay": "long"}`, 1, "1 kilogram"},
+		{"", `{"style": "unit", "unit": "kilogram", "unitDisplay": "long"}`, 2, "2 kilograms"},
+		{"", `{"style": "unit", "unit": "celsius"}`, 21.5, "21.5°C"},
+		{"", `{"style": "unit", "unit": "day"}`, 3, "3 days"},
+	} {
+		var opts numfmt.IntlOptions
+		require.NoErrorf(t, json.Unmarshal([]byte(tt.options), &opts), "%d", i)
+		f, err := numfmt.NewIntlFormatter(tt.locale, opts)
+		if assert.NoErrorf(t, err, "%d", i) {
+			assert.Equalf(t, tt.expected, f.Format(tt.arg), "%d", i)
+		}
+	}
+}
+
+func TestNewIntlFormatterError(t *testing.T) {
+	for i, options := range []string{
+		`{"style": "currency"}`,
+		`{"style": "currency", "currency": "USD", "currencyDisplay": "name"}`,
+		`{"style": "unit", "unit": "furlong"}`,
+		`{"style": "scientific"}`,
+		`{"notation": "engineering"}`,
+		`{"roundingMode": "floor"}`,
+		`{"minimumIntegerDigits": 2}`,
+		`{"minimumFractionDigits": 2, "maximumFractionDigits": 1}`,
+		`{"maximumSignificantDigits": 0}`,
+		`{"useGrouping": "sometimes"}`,
+		`{"signDisplay": "maybe"}`,
+	} {
+		var opts numfmt.IntlOptions
+		require.NoErrorf(t, json.Unmarshal([]byte(options), &opts), "%d", i)
+		_, err := numfmt.NewIntlFormatter("", opts)
+		assert.Errorf(t, err, "%d", i)
+	}
+
+	_, err := numfmt.NewIntlFormatter("not a locale!", numfmt.IntlOptions{})
+	assert.Error(t, err)
+}
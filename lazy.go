@@ -0,0 +1,25 @@
+package numfmt
+
+// Lazy is a value that is formatted by a Formatter only when it is written. It is intended for structured logging so
+// that numbers are human readable without formatting them at every call site. Lazy implements fmt.Stringer and
+// encoding.TextMarshaler so it can be used with zap.Stringer or any logger that accepts those interfaces. It also
+// implements slog.LogValuer when built with Go 1.21 or later.
+type Lazy struct {
+	f *Formatter
+	v interface{}
+}
+
+// Lazy returns v wrapped to be formatted by f when it is written. e.g. slog.Any("size", f.Lazy(n)).
+func (f *Formatter) Lazy(v interface{}) Lazy {
+	return Lazy{f: f, v: v}
+}
+
+// String returns the value formatted with Format.
+func (l Lazy) String() string {
+	return l.f.Format(l.v)
+}
+
+// MarshalText returns the value formatted with Format.
+func (l Lazy) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
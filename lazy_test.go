@@ -0,0 +1,22 @@
+package numfmt_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterLazy(t *testing.T) {
+	f := numfmt.NewBytesFormatter()
+	l := f.Lazy(1500000)
+	assert.Equal(t, "1.5 MB", l.String())
+	assert.Equal(t, "size: 1.5 MB", fmt.Sprintf("size: %v", l))
+
+	buf, err := json.Marshal(map[string]interface{}{"size": l})
+	require.NoError(t, err)
+	assert.Equal(t, `{"size":"1.5 MB"}`, string(buf))
+}
@@ -0,0 +1,36 @@
+package numfmt
+
+import (
+	"strings"
+)
+
+// LeadingZeros is how a Formatter writes the leading zeros of a string input such as "00731".
+type LeadingZeros int
+
+const (
+	LeadingZerosDrop          LeadingZeros = iota // Write the number without leading zeros. e.g. 731
+	LeadingZerosKeep                              // Write the leading zeros and group them with the number. e.g. 00,731
+	LeadingZerosKeepUngrouped                     // Write the leading zeros and no group separators. e.g. 00731
+)
+
+// keepLeadingZeros pads the integer digits of fs with zeros to the number of integer digits of the input s according
+// to LeadingZeros.
+func (f *Formatter) keepLeadingZeros(fs *formatState, s string) {
+	s = strings.TrimLeft(strings.TrimSpace(s), "+-")
+	if !strings.HasPrefix(s, "0") {
+		return
+	}
+	width := 0
+	for width < len(s) && s[width] >= '0' && s[width] <= '9' {
+		width++
+	}
+
+	rf := fs.f
+	if fs.exponent != "" || fs.scaleSuffix != "" || fs.prefix != "" || fs.fraction != "" || rf.Shift != 0 ||
+		!rf.Scale.IsZero() || len(fs.intPart) >= width {
+		return
+	}
+
+	fs.intPart = strings.Repeat("0", width-len(fs.intPart)) + fs.intPart
+	fs.ungrouped = f.LeadingZeros == LeadingZerosKeepUngrouped
+}
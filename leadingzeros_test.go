@@ -0,0 +1,32 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterLeadingZeros(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{}, "00731", "731"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeepUngrouped}, "00731", "00731"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeepUngrouped}, "0001234", "0001234"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeep}, "0001234", "0,001,234"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeepUngrouped}, " -0042 ", "-0042"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeepUngrouped}, "000", "000"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeepUngrouped}, "0.5", "0.5"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeepUngrouped}, "0012.50", "0012.5"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeepUngrouped}, "1234", "1,234"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeepUngrouped}, 731, "731"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeepUngrouped, Shift: 2}, "0050", "5,000"},
+		{&numfmt.Formatter{LeadingZeros: numfmt.LeadingZerosKeep, Mask: "###-####"}, "0012345", "001-2345"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,105 @@
+package numfmt
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInputLimit is the reason of a ParseError for input that exceeds InputLimits.
+var ErrInputLimit = errors.New("input exceeds limits")
+
+// InputLimits bounds the inputs a Formatter accepts so that formatting or parsing untrusted strings cannot allocate
+// huge strings. e.g. "1e999999999" would otherwise be written with a billion zeros. Inputs that exceed a limit are
+// written as Replacement by Format and the other Format methods such as FormatScaled and FormatRatio, and rejected
+// with ErrInputLimit by Parse and ParseStrict. They are not passed to Fallback. A zero limit is not checked.
+type InputLimits struct {
+	// MaxLength is the maximum length in bytes of string inputs. It is checked before parsing.
+	MaxLength int
+
+	// MaxIntegerDigits is the maximum number of integer digits of the number. e.g. 3 accepts 999.99 but not 1000.
+	MaxIntegerDigits int
+
+	// MaxExponent is the maximum absolute exponent of the number in scientific notation. e.g. 100 accepts 1.5e100 and
+	// 1e-100 but not 1e101 or 1e-101. Zeros such as 0e-500 are checked by their exponent too.
+	MaxExponent int32
+
+	// Replacement is written instead of an input that exceeds a limit. Default: Invalid, or Undefined if Invalid is
+	// empty
+	Replacement string
+}
+
+// DefaultInputLimits are limits suited to numbers read from user-supplied text.
+var DefaultInputLimits = InputLimits{MaxLength: 1000, MaxIntegerDigits: 100, MaxExponent: 1000}
+
+// allowsLength reports whether a string input of n bytes is within the limits. l may be nil.
+func (l *InputLimits) allowsLength(n int) bool {
+	return l == nil || l.MaxLength <= 0 || n <= l.MaxLength
+}
+
+// allows reports whether d is within the limits. l may be nil.
+func (l *InputLimits) allows(d decimal.Decimal) bool {
+	if l == nil || (l.MaxIntegerDigits <= 0 && l.MaxExponent <= 0) {
+		return true
+	}
+	return l.allowsExponent(int64(numDigits(d)) + int64(d.Exponent()) - 1)
+}
+
+// allowsExponent reports whether a number with exponent in scientific notation is within the limits. l may be nil.
+func (l *InputLimits) allowsExponent(exponent int64) bool {
+	if l == nil {
+		return true
+	}
+	if l.MaxIntegerDigits > 0 && exponent+1 > int64(l.MaxIntegerDigits) {
+		return false
+	}
+	if l.MaxExponent > 0 && (exponent > int64(l.MaxExponent) || -exponent > int64(l.MaxExponent)) {
+		return false
+	}
+	return true
+}
+
+// exceededText returns what Format writes for an input that exceeds InputLimits.
+func (f *Formatter) exceededText() string {
+	switch {
+	case f.InputLimits != nil && f.InputLimits.Replacement != "":
+		return f.InputLimits.Replacement
+	case f.Invalid != "":
+		return f.Invalid
+	}
+	return f.undefined()
+}
+
+// limitedToDecimal is toDecimal with InputLimits applied. exceeded reports whether v was rejected by a limit.
+func (f *Formatter) limitedToDecimal(v interface{}) (d decimal.Decimal, s string, ok, exceeded bool) {
+	if f.InputLimits == nil {
+		d, s, ok = f.unlimitedToDecimal(v)
+		return d, s, ok, false
+	}
+
+	v, _ = derefInput(v)
+	if str, isString := v.(string); isString && !f.InputLimits.allowsLength(len(str)) {
+		return decimal.Decimal{}, f.exceededText(), false, true
+	}
+	// A BigDecimal is checked before it is converted as converting it may allocate as many digits as its exponent.
+	if b, isBig := v.(BigDecimal); isBig {
+		if exponent, known := bigDecimalExponent(b); known && !f.InputLimits.allowsExponent(exponent) {
+			return decimal.Decimal{}, f.exceededText(), false, true
+		}
+	}
+
+	d, s, ok = f.unlimitedToDecimal(v)
+	if ok && !f.InputLimits.allows(d) {
+		return decimal.Decimal{}, f.exceededText(), false, true
+	}
+	return d, s, ok, false
+}
+
+// checkInputLength returns a *ParseError if s is longer than InputLimits allows. Input is truncated to the maximum
+// length so that the error does not hold on to the whole string.
+func (f *Formatter) checkInputLength(s string) error {
+	if f.InputLimits.allowsLength(len(s)) {
+		return nil
+	}
+	return &ParseError{Input: s[:f.InputLimits.MaxLength], Offset: f.InputLimits.MaxLength, Err: ErrInputLimit}
+}
@@ -0,0 +1,79 @@
+package numfmt_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterInputLimits(t *testing.T) {
+	limits := &numfmt.InputLimits{MaxLength: 10, MaxIntegerDigits: 6, MaxExponent: 20}
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{InputLimits: limits}, "123456", "123,456"},
+		{&numfmt.Formatter{InputLimits: limits}, "1234567", "—"},
+		{&numfmt.Formatter{InputLimits: limits}, 1234567, "—"},
+		{&numfmt.Formatter{InputLimits: limits}, "12345678901", "—"},
+		{&numfmt.Formatter{InputLimits: limits}, "1e999999999", "—"},
+		{&numfmt.Formatter{InputLimits: limits}, "1.5e-20", "0.000000000000000000015"},
+		{&numfmt.Formatter{InputLimits: limits}, "1e-21", "—"},
+		{&numfmt.Formatter{InputLimits: limits}, decimal.New(1, -30), "—"},
+		{&numfmt.Formatter{InputLimits: limits}, "0e9999999", "—"},
+		{&numfmt.Formatter{InputLimits: limits}, "0.00", "0"},
+		{&numfmt.Formatter{InputLimits: limits}, "abc", "abc"},
+		{&numfmt.Formatter{InputLimits: limits, Invalid: "n/a"}, "1234567", "n/a"},
+		{&numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxLength: 3, Replacement: "#"}}, "1234", "#"},
+		{
+			&numfmt.Formatter{InputLimits: limits, Fallback: &numfmt.Formatter{Invalid: "fallback"}},
+			strings.Repeat("9", 100), "—",
+		},
+		{&numfmt.Formatter{InputLimits: &numfmt.DefaultInputLimits}, "1e1001", "—"},
+		{&numfmt.Formatter{InputLimits: &numfmt.DefaultInputLimits}, "1e99", "1" + strings.Repeat(",000", 33)},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterInputLimitsDerivedNumbers(t *testing.T) {
+	f := &numfmt.Formatter{InputLimits: &numfmt.DefaultInputLimits}
+	assert.Equal(t, "—", f.FormatRatio("1e900", "1e-900"))
+	assert.Equal(t, "—", f.FormatDelta("-1e999", "1e999"))
+	assert.Equal(t, "0.5", f.FormatRatio(1, 2))
+}
+
+func TestFormatterParseInputLimits(t *testing.T) {
+	f := &numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxLength: 10, MaxExponent: 20}}
+
+	d, err := f.Parse("1,234.5")
+	require.NoError(t, err)
+	assert.Equal(t, "1234.5", d.String())
+
+	for _, s := range []string{strings.Repeat("1", 11), "1e21"} {
+		_, err = f.Parse(s)
+		assert.Truef(t, errors.Is(err, numfmt.ErrInputLimit), "%s: %v", s, err)
+
+		_, err = f.ParseStrict(s)
+		assert.Truef(t, errors.Is(err, numfmt.ErrInputLimit), "%s: %v", s, err)
+	}
+
+	_, err = f.ParseStrict(strings.Repeat("1", 1000))
+	var parseErr *numfmt.ParseError
+	require.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, 10, len(parseErr.Input))
+}
+
+func TestTemplateFuncInputLimits(t *testing.T) {
+	actual, err := numfmt.TemplateFunc("InputMaxIntegerDigits", "3", "InputReplacement", "too large", 1234)
+	require.NoError(t, err)
+	assert.Equal(t, "too large", actual)
+}
@@ -0,0 +1,146 @@
+package numfmt
+
+import (
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// locale is the number formatting data for a locale.
+type locale struct {
+	tag              language.Tag
+	groupSeparator   string
+	decimalSeparator string
+	digits           string
+	unitSeparator    string
+
+	grouping          GroupingPattern // nil for groups of three.
+	minGroupingDigits int             // e.g. 5 for CLDR minimumGroupingDigits 2.
+	negativeTemplate  string          // Template for negative numbers without a currency. e.g. "−n"
+
+	currencySuffix    bool   // Currency symbol is written after the number.
+	currencySeparator string // Written between the number and the currency symbol.
+}
+
+// fallbackLocale is used when no locales are registered such as when numfmt is built with the numfmt_nolocales tag.
+// Its empty fields use the Formatter defaults.
+var fallbackLocale = locale{tag: language.English}
+
+// LocaleData is the number formatting data for a locale registered with RegisterLocale. The cmd/numfmt-locales
+// generator writes it from CLDR JSON so an application can add locales it needs. Building with the numfmt_nolocales
+// tag leaves out the built-in locales so that a binary embeds only the locales it registers. Empty fields use the
+// Formatter defaults.
+type LocaleData struct {
+	Tag               language.Tag
+	GroupSeparator    string
+	DecimalSeparator  string
+	Digits            string // e.g. ArabicIndicDigits
+	UnitSeparator     string
+	CurrencySuffix    bool   // Currency symbol is written after the number.
+	CurrencySeparator string // Written between the number and the currency symbol.
+	Grouping          GroupingPattern
+	MinGroupingDigits int
+	NegativeTemplate  string // Used for numbers without a currency if f has no Template or NegativeTemplate.
+}
+
+var localeRegistry struct {
+	mux     sync.RWMutex
+	locales []*locale
+	matcher language.Matcher
+}
+
+func init() {
+	for i := range locales {
+		registerLocale(&locales[i])
+	}
+}
+
+// RegisterLocale makes the number formatting data for data.Tag available to NewLocaleFormatter, the Locale key of
+// TemplateFunc, and FormatContext. Registering a tag that is already registered replaces the previous data.
+// RegisterLocale is concurrency safe.
+func RegisterLocale(data LocaleData) {
+	registerLocale(&locale{
+		tag:               data.Tag,
+		groupSeparator:    data.GroupSeparator,
+		decimalSeparator:  data.DecimalSeparator,
+		digits:            data.Digits,
+		unitSeparator:     data.UnitSeparator,
+		currencySuffix:    data.CurrencySuffix,
+		currencySeparator: data.CurrencySeparator,
+		grouping:          data.Grouping,
+		minGroupingDigits: data.MinGroupingDigits,
+		negativeTemplate:  data.NegativeTemplate,
+	})
+}
+
+func registerLocale(l *locale) {
+	defer resetArgFormatters()
+	localeRegistry.mux.Lock()
+	defer localeRegistry.mux.Unlock()
+
+	replaced := false
+	for i, registered := range localeRegistry.locales {
+		if registered.tag == l.tag {
+			localeRegistry.locales[i] = l
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		localeRegistry.locales = append(localeRegistry.locales, l)
+	}
+
+	tags := make([]language.Tag, len(localeRegistry.locales))
+	for i, registered := range localeRegistry.locales {
+		tags[i] = registered.tag
+	}
+	localeRegistry.matcher = language.NewMatcher(tags)
+}
+
+// Locales returns the locales with number formatting data including those added by RegisterLocale. It can be used to
+// build a language.Matcher that negotiates with other supported locales of an application.
+func Locales() []language.Tag {
+	localeRegistry.mux.RLock()
+	defer localeRegistry.mux.RUnlock()
+
+	tags := make([]language.Tag, len(localeRegistry.locales))
+	for i, l := range localeRegistry.locales {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// NewLocaleFormatter returns a Formatter for the locale that best matches tag. If no locale matches then English is
+// used. With the numfmt_nolocales tag the first registered locale is used instead and the Formatter defaults are used
+// if no locales are registered.
+func NewLocaleFormatter(tag language.Tag) *Formatter {
+	f := &Formatter{}
+	matchLocale(tag).apply(f)
+	return f
+}
+
+func matchLocale(tag language.Tag) *locale {
+	localeRegistry.mux.RLock()
+	defer localeRegistry.mux.RUnlock()
+
+	if len(localeRegistry.locales) == 0 {
+		return &fallbackLocale
+	}
+	_, i, _ := localeRegistry.matcher.Match(tag)
+	return localeRegistry.locales[i]
+}
+
+func (l *locale) apply(f *Formatter) {
+	f.GroupSeparator = l.groupSeparator
+	f.DecimalSeparator = l.decimalSeparator
+	f.Digits = l.digits
+	f.UnitSeparator = l.unitSeparator
+	f.CurrencySuffix = l.currencySuffix
+	f.CurrencySeparator = l.currencySeparator
+	f.Grouping = nil
+	if l.grouping != nil {
+		f.Grouping = l.grouping
+	}
+	f.MinGroupingDigits = l.minGroupingDigits
+	f.localeNegativeTemplate = l.negativeTemplate
+}
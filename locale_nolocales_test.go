@@ -0,0 +1,26 @@
+//go:build numfmt_nolocales
+// +build numfmt_nolocales
+
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestNoBuiltinLocales(t *testing.T) {
+	assert.Empty(t, numfmt.Locales())
+	assert.Equal(t, "1,234.5", numfmt.NewLocaleFormatter(language.German).Format("1234.5"))
+
+	numfmt.RegisterLocale(numfmt.LocaleData{Tag: language.German, GroupSeparator: ".", DecimalSeparator: ","})
+	assert.Equal(t, []language.Tag{language.German}, numfmt.Locales())
+	assert.Equal(t, "1.234,5", numfmt.NewLocaleFormatter(language.MustParse("de-AT")).Format("1234.5"))
+
+	actual, err := numfmt.TemplateFunc("Locale", "fr", "1234.5")
+	require.NoError(t, err)
+	assert.Equal(t, "1.234,5", actual)
+}
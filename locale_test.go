@@ -0,0 +1,102 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestNewLocaleFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		tag      language.Tag
+		arg      interface{}
+		expected string
+	}{
+		{language.English, "-1234567.89", "-1,234,567.89"},
+		{language.AmericanEnglish, "1234567.89", "1,234,567.89"},
+		{language.German, "1234567.89", "1.234.567,89"},
+		{language.MustParse("de-AT"), "1234567.89", "1 234 567,89"},
+		{language.MustParse("de-CH"), "1234567.89", "1’234’567.89"},
+		{language.French, "1234567.89", "1 234 567,89"},
+		{language.CanadianFrench, "1234567.89", "1 234 567,89"},
+		{language.Arabic, "1234567.89", "١٬٢٣٤٬٥٦٧٫٨٩"},
+		{language.MustParse("es-419"), "1234567.89", "1,234,567.89"},
+		{language.Swahili, "1234567.89", "1,234,567.89"},
+	} {
+		actual := numfmt.NewLocaleFormatter(tt.tag).Format(tt.arg)
+		if tt.expected != actual {
+			t.Errorf("%d. expected formatting %v with %v to return %v, but got %v", i, tt.arg, tt.tag, tt.expected, actual)
+		}
+	}
+}
+
+func TestLocalesWithMatcher(t *testing.T) {
+	supported := append([]language.Tag{language.English}, numfmt.Locales()...)
+	matcher := language.NewMatcher(supported)
+	tag, _, _ := matcher.Match(language.MustParse("de-DE"))
+	actual := numfmt.NewLocaleFormatter(tag).Format("1234.5")
+	if actual != "1.234,5" {
+		t.Errorf("expected 1.234,5, but got %v", actual)
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	hindi := language.MustParse("hi-IN")
+	numfmt.RegisterLocale(numfmt.LocaleData{
+		Tag:              hindi,
+		GroupSeparator:   ",",
+		DecimalSeparator: ".",
+		Digits:           numfmt.DevanagariDigits,
+	})
+
+	actual := numfmt.NewLocaleFormatter(hindi).Format("1234.5")
+	if actual != "१,२३४.५" {
+		t.Errorf("expected १,२३४.५, but got %v", actual)
+	}
+
+	found := false
+	for _, tag := range numfmt.Locales() {
+		if tag == hindi {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected Locales to include %v", hindi)
+	}
+}
+
+func TestTemplateFuncLocaleGroupingAndNegative(t *testing.T) {
+	for i, tt := range []struct {
+		args     []interface{}
+		expected string
+	}{
+		{[]interface{}{"Locale", "en-IN", "1234567.8"}, "12,34,567.8"},
+		{[]interface{}{"Locale", "en-IN", "-123456789"}, "-12,34,56,789"},
+		{[]interface{}{"Locale", "es-ES", "1234"}, "1234"},
+		{[]interface{}{"Locale", "es-ES", "12345"}, "12.345"},
+		{[]interface{}{"Locale", "pl", "9999,5"}, "9999,5"},
+		{[]interface{}{"Locale", "es-MX", "1234"}, "1,234"},
+		{[]interface{}{"Locale", "sv", "-1234.5"}, "\u22121\u00a0234,5"},
+		{[]interface{}{"Locale", "fa", "-12"}, "\u200e\u2212\u06f1\u06f2"},
+		{[]interface{}{"Locale", "ar", "-12"}, "\u061c-\u0661\u0662"},
+		{[]interface{}{"Locale", "sv", "Template", "-n%", "-12"}, "-12%"},
+		{[]interface{}{"Locale", "sv", "MinusSign", "-", "-12"}, "-12"},
+		{[]interface{}{"Preset", "usd", "Locale", "sv", "-12"}, "-$12,00"},
+		{[]interface{}{"Locale", "sv", "Grouping", "3,2", "-123456"}, "\u22121\u00a023\u00a0456"},
+		{[]interface{}{"Locale", "es-ES", "MinGroupingDigits", "0", "1234"}, "1.234"},
+	} {
+		actual, err := numfmt.TemplateFunc(tt.args...)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestLocaleNegativeTemplateParse(t *testing.T) {
+	f := numfmt.NewLocaleFormatter(language.Swedish)
+	d, err := f.Parse("\u22121\u00a0234,5")
+	require.NoError(t, err)
+	assert.Equal(t, "-1234.5", d.String())
+}
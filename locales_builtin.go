@@ -0,0 +1,59 @@
+//go:build !numfmt_nolocales
+// +build !numfmt_nolocales
+
+package numfmt
+
+import "golang.org/x/text/language"
+
+// locales is the number formatting data for the built-in locales. It is derived from CLDR. The first entry is the
+// fallback for tags that do not match any locale. Build with the numfmt_nolocales tag to leave it out.
+var locales = []locale{
+	{tag: language.English, groupSeparator: ",", decimalSeparator: "."},
+	{tag: language.AmericanEnglish, groupSeparator: ",", decimalSeparator: "."},
+	{tag: language.BritishEnglish, groupSeparator: ",", decimalSeparator: "."},
+	{
+		tag: language.Arabic, groupSeparator: "٬", decimalSeparator: "٫", digits: ArabicIndicDigits,
+		negativeTemplate: "\u061c\\-n",
+	},
+	{tag: language.Chinese, groupSeparator: ",", decimalSeparator: "."},
+	{tag: language.Dutch, groupSeparator: ".", decimalSeparator: ",", currencySeparator: NoBreakSpace},
+	{
+		tag: language.French, groupSeparator: NarrowNoBreakSpace, decimalSeparator: ",", unitSeparator: NoBreakSpace,
+		currencySuffix: true,
+	},
+	{tag: language.German, groupSeparator: ".", decimalSeparator: ",", currencySuffix: true},
+	{
+		tag: language.MustParse("de-AT"), groupSeparator: NoBreakSpace, decimalSeparator: ",",
+		currencySeparator: NoBreakSpace,
+	},
+	{tag: language.MustParse("de-CH"), groupSeparator: "’", decimalSeparator: "."},
+	{tag: language.Italian, groupSeparator: ".", decimalSeparator: ",", currencySuffix: true},
+	{tag: language.Hindi, groupSeparator: ",", decimalSeparator: ".", grouping: GroupingPattern{3, 2}},
+	{tag: language.MustParse("en-IN"), groupSeparator: ",", decimalSeparator: ".", grouping: GroupingPattern{3, 2}},
+	{tag: language.Japanese, groupSeparator: ",", decimalSeparator: "."},
+	{tag: language.Korean, groupSeparator: ",", decimalSeparator: "."},
+	{
+		tag: language.Persian, groupSeparator: "٬", decimalSeparator: "٫", digits: ExtendedArabicIndicDigits,
+		negativeTemplate: "\u200e\u2212n",
+	},
+	{
+		tag: language.Polish, groupSeparator: NoBreakSpace, decimalSeparator: ",", currencySuffix: true,
+		minGroupingDigits: 5,
+	},
+	{tag: language.Portuguese, groupSeparator: ".", decimalSeparator: ",", currencySeparator: NoBreakSpace},
+	{
+		tag: language.EuropeanPortuguese, groupSeparator: NoBreakSpace, decimalSeparator: ",", currencySuffix: true,
+		minGroupingDigits: 5,
+	},
+	{tag: language.Russian, groupSeparator: NoBreakSpace, decimalSeparator: ",", currencySuffix: true},
+	{
+		tag: language.Spanish, groupSeparator: ".", decimalSeparator: ",", currencySuffix: true,
+		minGroupingDigits: 5,
+	},
+	{tag: language.MustParse("es-MX"), groupSeparator: ",", decimalSeparator: "."},
+	{
+		tag: language.Swedish, groupSeparator: NoBreakSpace, decimalSeparator: ",", currencySuffix: true,
+		negativeTemplate: "\u2212n",
+	},
+	{tag: language.Thai, groupSeparator: ",", decimalSeparator: "."},
+}
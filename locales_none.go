@@ -0,0 +1,7 @@
+//go:build numfmt_nolocales
+// +build numfmt_nolocales
+
+package numfmt
+
+// locales is empty with the numfmt_nolocales tag. Only locales registered with RegisterLocale are used.
+var locales []locale
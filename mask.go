@@ -0,0 +1,98 @@
+package numfmt
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// compiledMask is a compiled Formatter.Mask.
+type compiledMask []maskPart
+
+// maskPart is literal text or, if placeholder is not 0, a '#' or '0' placeholder for a digit.
+type maskPart struct {
+	placeholder byte
+	literal     string
+}
+
+func (f *Formatter) compileMask() {
+	if f.Mask != "" {
+		f.compiledMask = compileMask(f.Mask)
+	}
+}
+
+// compileMask compiles s. Backslash escapes are decoded as in compileTemplate.
+func compileMask(s string) compiledMask {
+	var cm compiledMask
+	literal := &strings.Builder{}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+
+		switch {
+		case r == '\\':
+			if i == len(s) {
+				break
+			}
+			if ur, n, ok := unicodeEscape(s[i:]); ok {
+				literal.WriteRune(ur)
+				i += n
+				continue
+			}
+			_, size = utf8.DecodeRuneInString(s[i:])
+			literal.WriteString(s[i : i+size])
+			i += size
+		case r == '#' || r == '0':
+			if literal.Len() > 0 {
+				cm = append(cm, maskPart{literal: literal.String()})
+				literal.Reset()
+			}
+			cm = append(cm, maskPart{placeholder: byte(r)})
+		default:
+			literal.WriteString(s[i-size : i])
+		}
+	}
+	if literal.Len() > 0 {
+		cm = append(cm, maskPart{literal: literal.String()})
+	}
+	return cm
+}
+
+// write writes the ASCII digits in num into the placeholders of cm replacing them with digits if it is not nil. If cm
+// has no placeholders num is written before it.
+func (cm compiledMask) write(sb *strings.Builder, num string, digits []string) {
+	placeholders := 0
+	for _, part := range cm {
+		if part.placeholder != 0 {
+			placeholders++
+		}
+	}
+	if placeholders == 0 {
+		writeDigits(sb, num, digits)
+	}
+
+	pos := len(num) - placeholders // Index in num of the digit for the next placeholder.
+	seenPlaceholder := false
+	wrote := false
+	for _, part := range cm {
+		if part.placeholder == 0 {
+			if wrote || !seenPlaceholder {
+				sb.WriteString(part.literal)
+			}
+			continue
+		}
+
+		switch {
+		case !seenPlaceholder && pos > 0:
+			writeDigits(sb, num[:pos+1], digits)
+			wrote = true
+		case pos >= 0:
+			writeDigits(sb, num[pos:pos+1], digits)
+			wrote = true
+		case part.placeholder == '0':
+			writeDigits(sb, "0", digits)
+			wrote = true
+		}
+		seenPlaceholder = true
+		pos++
+	}
+}
@@ -0,0 +1,34 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterMask(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Mask: "###-##-####"}, 123456789, "123-45-6789"},
+		{&numfmt.Formatter{Mask: "###-##-####"}, 12345, "1-2345"},
+		{&numfmt.Formatter{Mask: "000-00-0000"}, 1234567, "001-23-4567"},
+		{&numfmt.Formatter{Mask: "#### #### #### ####"}, "4111111111111111", "4111 1111 1111 1111"},
+		{&numfmt.Formatter{Mask: "(###) ###-####"}, 5551234567, "(555) 123-4567"},
+		{&numfmt.Formatter{Mask: "##-##"}, 1234567, "12345-67"},
+		{&numfmt.Formatter{Mask: "####"}, 0, "0"},
+		{&numfmt.Formatter{Mask: "0000"}, 0, "0000"},
+		{&numfmt.Formatter{Mask: `\#####`}, 42, "#42"},
+		{&numfmt.Formatter{Mask: `No. 0\0##`}, 57, "No. 0057"},
+		{&numfmt.Formatter{Mask: "ID"}, 42, "42ID"},
+		{&numfmt.Formatter{Mask: "###-###", Template: "-n"}, -123456, "-123-456"},
+		{&numfmt.Formatter{Mask: "###-###", Rounder: &numfmt.Rounder{Places: 0}}, "123456.7", "123-457"},
+		{&numfmt.Formatter{Mask: "###-###", Digits: numfmt.ArabicIndicDigits}, 123456, "١٢٣-٤٥٦"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
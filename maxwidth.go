@@ -0,0 +1,62 @@
+package numfmt
+
+import (
+	"unicode/utf8"
+)
+
+// Ellipsis is written at the end of a number truncated to Formatter.MaxWidth.
+const Ellipsis = "…"
+
+// shorten returns v formatted to fit in MaxWidth as described by Formatter.MaxWidth. places is the number of decimal
+// places v was formatted with. PostFormat is not called.
+func (f *Formatter) shorten(v interface{}, places int) string {
+	format := func(places int32, h *Humanizer) string {
+		c := f.clone()
+		c.MaxWidth = 0
+		c.PostFormat = nil
+		c.MagnitudeRules = nil
+		c.Repeating = RepeatingNone
+		c.PreserveScale = false
+		c.Rounder = &Rounder{Places: places}
+		if c.MinDecimalPlaces > places {
+			c.MinDecimalPlaces = places
+		}
+		if h != nil {
+			c.Humanizer = h
+		}
+
+		fs, s, ok := c.state(v)
+		if !ok {
+			return s
+		}
+		return fs.f.render(fs)
+	}
+
+	fits := func(s string) bool {
+		return utf8.RuneCountInString(stripANSI(s)) <= f.MaxWidth
+	}
+
+	for p := places - 1; p >= 0; p-- {
+		if s := format(int32(p), nil); fits(s) {
+			return s
+		}
+	}
+
+	var h *Humanizer
+	if f.Humanizer == nil && f.Scientific == nil {
+		h = CompactHumanizer
+		for p := int32(1); p > 0; p-- {
+			if s := format(p, h); fits(s) {
+				return s
+			}
+		}
+	}
+
+	s := format(0, h)
+	if fits(s) {
+		return s
+	}
+
+	runes := []rune(s)
+	return string(runes[:f.MaxWidth-1]) + Ellipsis
+}
@@ -0,0 +1,31 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterMaxWidth(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{MaxWidth: 6}, "1234.5678", "1,235"},
+		{&numfmt.Formatter{MaxWidth: 7}, "1234.5678", "1,234.6"},
+		{&numfmt.Formatter{MaxWidth: 6}, "12.5", "12.5"},
+		{&numfmt.Formatter{MaxWidth: 6}, "123456789", "123.5M"},
+		{&numfmt.Formatter{MaxWidth: 4}, "123456789", "123M"},
+		{&numfmt.Formatter{MaxWidth: 6}, "-123456789", "-123M"},
+		{&numfmt.Formatter{MaxWidth: 8, MinDecimalPlaces: 2, Template: "$n"}, "1234567.891", "$1.2M"},
+		{&numfmt.Formatter{MaxWidth: 4, Humanizer: numfmt.BytesHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, "1500000", "2 MB"},
+		{&numfmt.Formatter{MaxWidth: 3}, "123456789", "12…"},
+		{&numfmt.Formatter{MaxWidth: 6, PostFormat: func(s string) string { return "<" + s + ">" }}, "1234.5678", "<1,235>"},
+		{&numfmt.Formatter{MaxWidth: 6}, "abcdefghijk", "abcdefghijk"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
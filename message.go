@@ -0,0 +1,114 @@
+package numfmt
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Localized is a value that is formatted by a Formatter with the locale of the golang.org/x/text/message.Printer
+// printing it. It lets numfmt formatting be used in messages translated with x/text. Outside a message.Printer, such
+// as with the fmt package, it is formatted with Format.
+type Localized struct {
+	f *Formatter
+	v interface{}
+}
+
+// Localized returns v wrapped to be formatted by f with the separators and digits of the locale of the
+// message.Printer it is printed with. All other options of f are kept as with FormatContext. e.g.
+// p.Printf("Total: %v", f.Localized(1234.5)) => Total: 1.234,50 € with a German printer and a EUR Formatter.
+func (f *Formatter) Localized(v interface{}) Localized {
+	return Localized{f: f, v: v}
+}
+
+// Format implements fmt.Formatter. The width and '-' flag of the verb pad the formatted number.
+func (l Localized) Format(s fmt.State, verb rune) {
+	var str string
+	if ls, ok := s.(interface{ Language() language.Tag }); ok {
+		str = l.f.localize(matchLocale(ls.Language())).Format(l.v)
+	} else {
+		str = l.f.Format(l.v)
+	}
+
+	if width, ok := s.Width(); ok {
+		if pad := width - utf8.RuneCountInString(str); pad > 0 {
+			if s.Flag('-') {
+				str += strings.Repeat(" ", pad)
+			} else {
+				str = strings.Repeat(" ", pad) + str
+			}
+		}
+	}
+	fmt.Fprint(s, str)
+}
+
+// String returns the value formatted with Format.
+func (l Localized) String() string {
+	return l.f.Format(l.v)
+}
+
+// NewPrinterFormatter returns a Formatter that writes numbers with the same digits, separators, grouping, and minus
+// sign as p. It eases migrating from formatting numbers with a message.Printer so both write numbers the same way.
+func NewPrinterFormatter(p *message.Printer) *Formatter {
+	f := &Formatter{}
+
+	digits := make([]string, 10)
+	for i := range digits {
+		digits[i] = p.Sprint(i)
+	}
+	if d := strings.Join(digits, ""); d != asciiDigits && utf8.RuneCountInString(d) == 10 {
+		f.Digits = d
+	}
+	isDigit := func(r rune) bool {
+		for _, d := range digits {
+			if d == string(r) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Split a number with a fraction and at least three groups into its runs of digits and the separators between
+	// them. e.g. 12,34,567.5 => [12 34 567 5] and [, , .].
+	var runs []int
+	var separators []string
+	sb := &strings.Builder{}
+	for _, r := range p.Sprintf("%.1f", 1234567.5) {
+		switch {
+		case !isDigit(r):
+			sb.WriteRune(r)
+		case len(runs) == 0:
+			sb.Reset() // Ignore marks before the number.
+			runs = append(runs, 1)
+		case sb.Len() > 0:
+			separators = append(separators, sb.String())
+			sb.Reset()
+			runs = append(runs, 1)
+		default:
+			runs[len(runs)-1]++
+		}
+	}
+
+	if n := len(separators); n > 0 {
+		f.DecimalSeparator = separators[n-1]
+		runs = runs[:n]
+		if n > 1 {
+			f.GroupSeparator = separators[0]
+			primary, secondary := runs[len(runs)-1], runs[len(runs)-2]
+			if len(runs) > 2 && secondary != primary {
+				f.Grouping = GroupingPattern{primary, secondary}
+			} else {
+				f.GroupSize = primary
+			}
+		}
+	}
+
+	if minus := p.Sprint(-1); strings.HasSuffix(minus, digits[1]) {
+		f.MinusSign = strings.TrimSuffix(minus, digits[1])
+	}
+
+	return f
+}
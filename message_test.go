@@ -0,0 +1,54 @@
+package numfmt_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestFormatterLocalized(t *testing.T) {
+	f := &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}, MinDecimalPlaces: 2}
+
+	for i, tt := range []struct {
+		tag      language.Tag
+		format   string
+		expected string
+	}{
+		{language.English, "Total: %v", "Total: 1,234.50"},
+		{language.German, "Total: %v", "Total: 1.234,50"},
+		{language.French, "Total: %v", "Total: 1\u202f234,50"},
+		{language.German, "[%10v]", "[  1.234,50]"},
+		{language.German, "[%-10v]", "[1.234,50  ]"},
+	} {
+		p := message.NewPrinter(tt.tag)
+		assert.Equalf(t, tt.expected, p.Sprintf(tt.format, f.Localized(1234.5)), "%d", i)
+	}
+
+	assert.Equal(t, "1,234.50", fmt.Sprint(f.Localized(1234.5)))
+	assert.Equal(t, "1,234.50", f.Localized(1234.5).String())
+}
+
+func TestNewPrinterFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		tag   language.Tag
+		value float64
+	}{
+		{language.English, -1234567.5},
+		{language.German, -1234567.5},
+		{language.French, 1234567.5},
+		{language.MustParse("de-CH"), 1234567.5},
+		{language.Arabic, -1234567.5},
+		{language.Persian, 1234567.5},
+		{language.Hindi, -1234567.5},
+		{language.Bengali, 1234567.5},
+		{language.Spanish, 1234.5},
+	} {
+		p := message.NewPrinter(tt.tag)
+		f := numfmt.NewPrinterFormatter(p)
+		assert.Equalf(t, p.Sprintf("%.1f", tt.value), f.Format(tt.value), "%d %v", i, tt.tag)
+	}
+}
@@ -0,0 +1,59 @@
+package numfmt
+
+import (
+	money "github.com/Rhymond/go-money"
+	"github.com/shopspring/decimal"
+)
+
+// moneyAmount returns the amount of m in major units. e.g. 1234 USD cents => 12.34.
+func moneyAmount(m *money.Money) decimal.Decimal {
+	return decimal.New(m.Amount(), -moneyPlaces(m.Currency()))
+}
+
+// moneyPlaces returns the number of minor unit decimal places of c. A nil currency has 2 places as go-money uses for
+// unknown currencies.
+func moneyPlaces(c *money.Currency) int32 {
+	if c == nil {
+		return 2
+	}
+	return int32(c.Fraction)
+}
+
+// moneyState returns the state for m formatted with the Formatter for its currency.
+func (f *Formatter) moneyState(m *money.Money) *formatState {
+	return f.forCurrency(m.Currency()).decimalState(moneyAmount(m))
+}
+
+// forCurrency returns a copy of f for c. If f has no CurrencySymbol or Template the symbol of c is written as
+// NewCurrencyFormatter would. If f has no Rounder it rounds to the minor units of c and always displays them. Copies are
+// cached in f.
+func (f *Formatter) forCurrency(c *money.Currency) *Formatter {
+	f = f.compiled()
+	code := ""
+	if c != nil {
+		code = c.Code
+	}
+	if cf, ok := f.currencyFormatters.Load(code); ok {
+		return cf.(*Formatter)
+	}
+
+	cf := f.clone()
+	cf.Currency = ""
+	places := moneyPlaces(c)
+	if cf.CurrencySymbol == "" && cf.Template == "" {
+		if known, ok := currencies[code]; ok {
+			cf.CurrencySymbol = known.symbol
+		} else if c != nil && c.Grapheme != "" {
+			cf.CurrencySymbol = c.Grapheme
+		} else {
+			cf.CurrencySymbol = code
+		}
+	}
+	if cf.Rounder == nil {
+		cf.Rounder = &Rounder{Places: places}
+		cf.MinDecimalPlaces = places
+	}
+
+	actual, _ := f.currencyFormatters.LoadOrStore(code, cf)
+	return actual.(*Formatter)
+}
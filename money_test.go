@@ -0,0 +1,42 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	money "github.com/Rhymond/go-money"
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFormatMoney(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{}, money.New(123450, money.EUR), "€1,234.50"},
+		{&numfmt.Formatter{}, money.New(-123450, money.USD), "-$1,234.50"},
+		{&numfmt.Formatter{}, money.New(1234, money.JPY), "¥1,234"},
+		{&numfmt.Formatter{}, money.New(1500, money.KWD), "KWD 1.500"},
+		{&numfmt.Formatter{}, money.New(500, money.UAH), "₴5.00"},
+		{&numfmt.Formatter{}, money.New(500, "XYZ"), "XYZ 5.00"},
+		{&numfmt.Formatter{}, &money.Money{}, "0.00"},
+		{&numfmt.Formatter{}, (*money.Money)(nil), ""},
+		{&numfmt.Formatter{Template: "n"}, money.New(123450, money.EUR), "1,234.50"},
+		{&numfmt.Formatter{CurrencySymbol: "€", CurrencySuffix: true}, money.New(123450, money.EUR), "1,234.50 €"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}}, money.New(123450, money.EUR), "€1,235"},
+		{numfmt.NewEuropeanFormatter(), money.New(123450, money.EUR), "€1.234,50"},
+		{&numfmt.Formatter{Currency: "USD"}, money.New(1234, money.JPY), "¥1,234"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := &numfmt.Formatter{}
+	assert.Equal(t, "€1,234.50", f.Format(money.New(123450, money.EUR)))
+	assert.Equal(t, "$5.00", f.Format(money.New(500, money.USD)))
+	assert.Equal(t, "1,234.5", f.Format("1234.5"))
+
+	html := f.FormatHTML(money.New(123450, money.EUR))
+	assert.Equal(t, "€1,234.50", string(html))
+}
@@ -0,0 +1,47 @@
+package numfmt
+
+import (
+	"strings"
+)
+
+var (
+	// JapaneseMyriadUnits are the Japanese units for successive powers of 10,000 starting with 10⁴.
+	JapaneseMyriadUnits = []string{"万", "億", "兆", "京"}
+
+	// ChineseMyriadUnits are the simplified Chinese units for successive powers of 10,000 starting with 10⁴.
+	ChineseMyriadUnits = []string{"万", "亿", "万亿"}
+
+	// JapaneseMyriadHumanizer scales numbers by powers of 10,000. e.g. 123456789 => 1.23億 with Rounder places 2.
+	JapaneseMyriadHumanizer = NewHumanizer(10000, append([]string{""}, JapaneseMyriadUnits...)...)
+
+	// ChineseMyriadHumanizer scales numbers by powers of 10,000. e.g. 123456789 => 1.23亿 with Rounder places 2.
+	ChineseMyriadHumanizer = NewHumanizer(10000, append([]string{""}, ChineseMyriadUnits...)...)
+)
+
+// writeMyriadGroups writes num in groups of four digits each followed by its unit from units. Groups that are zero are
+// omitted and leading zeros of groups are not written. e.g. 1億2345万6789 or 1億6789. If num has more groups than there
+// are units the remaining digits are written before the last unit.
+func writeMyriadGroups(sb *strings.Builder, num string, units []string, digits []string) {
+	var groups []string // Least significant first.
+	for len(num) > 4 && len(groups) < len(units) {
+		groups = append(groups, num[len(num)-4:])
+		num = num[:len(num)-4]
+	}
+	groups = append(groups, num)
+
+	wrote := false
+	for i := len(groups) - 1; i >= 0; i-- {
+		group := strings.TrimLeft(groups[i], "0")
+		if group == "" {
+			continue
+		}
+		writeDigits(sb, group, digits)
+		if i > 0 {
+			sb.WriteString(units[i-1])
+		}
+		wrote = true
+	}
+	if !wrote {
+		writeDigits(sb, "0", digits)
+	}
+}
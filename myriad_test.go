@@ -0,0 +1,33 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterMyriad(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{MyriadUnits: numfmt.JapaneseMyriadUnits}, "123456789", "1億2345万6789"},
+		{&numfmt.Formatter{MyriadUnits: numfmt.JapaneseMyriadUnits}, "100006789", "1億6789"},
+		{&numfmt.Formatter{MyriadUnits: numfmt.JapaneseMyriadUnits}, "100230000", "1億23万"},
+		{&numfmt.Formatter{MyriadUnits: numfmt.JapaneseMyriadUnits}, "9999", "9999"},
+		{&numfmt.Formatter{MyriadUnits: numfmt.JapaneseMyriadUnits}, "0", "0"},
+		{&numfmt.Formatter{MyriadUnits: numfmt.JapaneseMyriadUnits}, "0.5", "0.5"},
+		{&numfmt.Formatter{MyriadUnits: numfmt.JapaneseMyriadUnits}, "-12345.67", "-1万2345.67"},
+		{&numfmt.Formatter{MyriadUnits: numfmt.JapaneseMyriadUnits, Template: "n円"}, "50000", "5万円"},
+		{&numfmt.Formatter{MyriadUnits: numfmt.ChineseMyriadUnits}, "123456789", "1亿2345万6789"},
+		{&numfmt.Formatter{MyriadUnits: []string{"万"}}, "123456789", "12345万6789"},
+		{&numfmt.Formatter{Humanizer: numfmt.JapaneseMyriadHumanizer, Rounder: &numfmt.Rounder{Places: 2}}, "123456789", "1.23億"},
+		{&numfmt.Formatter{Humanizer: numfmt.JapaneseMyriadHumanizer, Rounder: &numfmt.Rounder{Places: 2}}, "56789", "5.68万"},
+		{&numfmt.Formatter{Humanizer: numfmt.ChineseMyriadHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, "-2500000000", "-25亿"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
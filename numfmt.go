@@ -9,52 +9,499 @@ Number formatting is provided by the Formatter type. The zero value of Formatter
 package numfmt
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"unicode/utf8"
 
+	money "github.com/Rhymond/go-money"
 	"github.com/shopspring/decimal"
 )
 
+// Unicode space characters commonly used as group separators. They are multi-byte strings and can be used anywhere a
+// separator is accepted.
+const (
+	NoBreakSpace       = "\u00A0" // Non-breaking space. Used for grouping in many European locales.
+	NarrowNoBreakSpace = "\u202F" // Narrow non-breaking space. Used for grouping in French and SI style.
+	ThinSpace          = "\u2009" // Thin space. Breakable variant of NarrowNoBreakSpace.
+)
+
+// MinusSign is the Unicode minus sign. It is typographically preferred to the ASCII hyphen-minus and is used by some
+// locales.
+const MinusSign = "\u2212"
+
+// PlusMinusSign is written for zero with ZeroSignPlusMinus.
+const PlusMinusSign = "±"
+
+// Digit sets for use with Formatter.Digits.
+const (
+	asciiDigits               = "0123456789"
+	ArabicIndicDigits         = "٠١٢٣٤٥٦٧٨٩" // Used in Arabic.
+	ExtendedArabicIndicDigits = "۰۱۲۳۴۵۶۷۸۹" // Used in Persian and Urdu.
+	DevanagariDigits          = "०१२३४५६७८९" // Used in Hindi, Marathi, and Nepali.
+	BengaliDigits             = "০১২৩৪৫৬৭৮৯"
+	ThaiDigits                = "๐๑๒๓๔๕๖๗๘๙"
+)
+
+// ErrInvalidDigits is the reason of the error returned by ValidateDigits.
+var ErrInvalidDigits = errors.New("digits must be exactly ten characters")
+
+// ValidateDigits returns an error if s is not a valid Formatter.Digits. Formatter does not require valid digits.
+// Invalid digits are ignored and ASCII digits are written. TemplateFunc and FormatterOptions reject invalid digits.
+func ValidateDigits(s string) error {
+	if s != "" && utf8.RuneCountInString(s) != 10 {
+		return fmt.Errorf("invalid digits %q: %w", s, ErrInvalidDigits)
+	}
+	return nil
+}
+
+// BidiIsolation is a way of isolating a formatted number from surrounding bidirectional text.
+type BidiIsolation int
+
+const (
+	BidiNone               BidiIsolation = iota // No isolation.
+	BidiFirstStrongIsolate                      // Surround with FSI and PDI. Direction is determined by the content.
+	BidiLeftToRightIsolate                      // Surround with LRI and PDI.
+	BidiLeftToRightMark                         // Surround with LRM. For renderers without isolate support.
+)
+
+// ZeroSign is what the '+' verb writes for zero.
+type ZeroSign int
+
+const (
+	ZeroSignPlus      ZeroSign = iota // Write PlusSign. e.g. +0
+	ZeroSignMinus                     // Write MinusSign. e.g. -0
+	ZeroSignPlusMinus                 // Write PlusMinusSign. e.g. ±0
+	ZeroSignBlank                     // Write a space in place of the sign so zero aligns with signed numbers. e.g. " 0"
+	ZeroSignNone                      // Write no sign. e.g. 0
+)
+
+// NegativeStyle is how a Formatter derives the template for negative numbers when NegativeTemplate is empty.
+type NegativeStyle int
+
+const (
+	NegativeSign        NegativeStyle = iota // Use Template. e.g. -9.45
+	NegativeParentheses                      // Wrap Template in parentheses without its sign verbs. e.g. (9.45)
+	NegativeDebitCredit                      // Replace sign verbs with a trailing DebitLabel or CreditLabel. e.g. 9.45 DR
+)
+
+// IntegerOverflow is what a Formatter writes for numbers with more integer digits than Formatter.MaxIntegerDigits.
+type IntegerOverflow int
+
+const (
+	OverflowClamp      IntegerOverflow = iota // Write the largest number that fits and DisplayMaxSuffix. e.g. 9,999+
+	OverflowScientific                        // Write the number in scientific notation. e.g. 1.2345e5
+	OverflowCompact                           // Write the number with CompactHumanizer. e.g. 123.45K
+	OverflowError                             // Write a # for each allowed digit or use Fallback. e.g. ####
+)
+
+// Alignment is the alignment of a formatted number padded to Formatter.MinWidth.
+type Alignment int
+
+const (
+	AlignRight   Alignment = iota // Pad on the left.
+	AlignLeft                     // Pad on the right.
+	AlignDecimal                  // Pad on both sides so decimal separators line up for a fixed number of decimal places.
+)
+
+// Rounder rounds numbers to a number of decimal places.
 type Rounder struct {
 	Places int32 // Number of decimal places to round to.
+
+	// Rules picks the number of decimal places from the magnitude of the number. The first rule whose Min is less than or
+	// equal to the absolute value of the number is used. If no rule matches Places is used. See
+	// DefaultPrecisionRules.
+	Rules []PrecisionRule
+
+	// SignificantDigits rounds to this many significant digits instead of Places and Rules. Formatter pads the result
+	// with trailing zeros to show the precision. e.g. 1.2 => 1.20 and 1234 => 1,230 with 3.
+	SignificantDigits int32
+
+	// MinSignificantDigits pads results of SignificantDigits with trailing zeros to only this many significant digits.
+	// e.g. 1.2 => 1.2 and 1 => 1.0 with 2 and SignificantDigits 3. Default: SignificantDigits
+	MinSignificantDigits int32
+}
+
+// PrecisionRule is a rule for Rounder.Rules.
+type PrecisionRule struct {
+	Min    decimal.Decimal // Minimum absolute value the rule applies to.
+	Places int32           // Number of decimal places to round to.
 }
 
+// DefaultPrecisionRules rounds numbers of 100 or more to 0 places, numbers of 1 or more to 1 place, and smaller
+// numbers to 3 places. e.g. 1234.5 => 1,235, 12.34 => 12.3, and 0.01234 => 0.012.
+var DefaultPrecisionRules = []PrecisionRule{
+	{Min: decimal.New(100, 0), Places: 0},
+	{Min: decimal.New(1, 0), Places: 1},
+	{Min: decimal.Decimal{}, Places: 3},
+}
+
+// Round rounds d.
 func (r *Rounder) Round(d decimal.Decimal) decimal.Decimal {
-	return d.Round(r.Places)
+	return d.Round(r.places(d))
+}
+
+// padPlaces returns the number of decimal places Formatter pads d rounded by r to with SignificantDigits.
+func (r *Rounder) padPlaces(d decimal.Decimal) int32 {
+	if r.MinSignificantDigits > 0 {
+		return (&Rounder{SignificantDigits: r.MinSignificantDigits}).places(d)
+	}
+	return r.places(d)
+}
+
+func (r *Rounder) places(d decimal.Decimal) int32 {
+	if r.SignificantDigits > 0 {
+		if d.IsZero() {
+			return 0
+		}
+		places := r.SignificantDigits - 1 - magnitude(d)
+		if magnitude(d.Round(places)) > magnitude(d) {
+			places-- // Rounding carried to a new digit. e.g. 9.996 => 10.0
+		}
+		return places
+	}
+
+	if len(r.Rules) > 0 {
+		abs := d.Abs()
+		for _, rule := range r.Rules {
+			if abs.Cmp(rule.Min) >= 0 {
+				return rule.Places
+			}
+		}
+	}
+	return r.Places
+}
+
+// MagnitudeRule is a rule for Formatter.MagnitudeRules.
+type MagnitudeRule struct {
+	Min       decimal.Decimal // Minimum absolute value the rule applies to.
+	Formatter *Formatter      // Formatter for numbers the rule applies to.
 }
 
-// Formatter is a formatter of numbers. The zero value is usable. Do not change or copy a Formatter after it has been
-// used. The methods on Format are concurrency safe.
+// Formatter is a formatter of numbers. The zero value is usable. Do not change a Formatter after it has been used. A
+// Formatter can be copied, e.g. to embed it in a configuration struct, and a copy can be changed before it is used. The
+// methods on Format are concurrency safe.
 type Formatter struct {
-	GroupSeparator   string // Separator to place between groups of digits. Default: ","
-	GroupSize        int    // Number of digits in a group. Default: 3
+	GroupSeparator string // Separator to place between groups of digits. Default: ","
+	GroupSize      int    // Number of digits in a group. Default: 3
+
+	// Grouping determines the widths of groups of digits. It takes precedence over GroupSize. e.g. GroupingPattern{3, 2}
+	// for Indian grouping such as 12,34,56,789.
+	Grouping Grouping
+
+	// MinGroupingDigits is the minimum number of integer digits for group separators to be written. e.g. 9999 and
+	// 10,000 with 5 as recommended by some style guides and CLDR minimumGroupingDigits 2. Default: 0 (always group)
+	MinGroupingDigits int
+
+	// NoGrouping writes the integer digits without group separators. e.g. 1234567 for IDs and years.
+	NoGrouping bool
+
 	DecimalSeparator string // Default: "."
 	Rounder          *Rounder
 
+	MinusSign string // Written by the '-' and '+' verbs for negative numbers. e.g. MinusSign. Default: "-"
+	PlusSign  string // Written by the '+' verb for positive numbers. Default: "+"
+
+	// ZeroSign is what the '+' verb writes for zero. Default: ZeroSignPlus
+	ZeroSign ZeroSign
+
+	TrendUp   string // Written by the {trend} verb for positive numbers. Default: "▲"
+	TrendDown string // Written by the {trend} verb for negative numbers. Default: "▼"
+	TrendFlat string // Written by the {trend} verb for zero. Default: "–"
+
+	// Colors wraps the formatted number in ANSI escape sequences to color it for terminals. e.g. DefaultANSIColors.
+	// Colors are not written if the NO_COLOR environment variable is set. Default: nil
+	Colors *ANSIColors
+
+	// BidiIsolation wraps the formatted number in Unicode bidirectional formatting characters so that its sign,
+	// symbols, and separators keep their order when it is embedded in right-to-left text. Default: BidiNone
+	BidiIsolation BidiIsolation
+
+	// MyriadUnits replaces group separators with units for successive powers of 10,000 as used in East Asian languages.
+	// e.g. 123456789 => 1億2345万6789 with JapaneseMyriadUnits. Parse does not support MyriadUnits.
+	MyriadUnits []string
+
+	// Mask writes the integer digits of a number into the placeholders of a pattern instead of grouping them. It is
+	// intended for identifiers such as account and card numbers. Digits fill the '#' and '0' placeholders from the
+	// right. When no digits remain '0' writes a zero and '#' writes nothing, and text between placeholders that wrote
+	// nothing is omitted. Digits that do not fit are written at the first placeholder. Other characters are written
+	// literally and backslash escapes work as in Template. e.g. 123456789 => 123-45-6789 with "###-##-####" and 1234567
+	// => 001-23-4567 with "000-00-0000". Parse does not support Mask. Default: ""
+	Mask         string
+	compiledMask compiledMask
+
+	// Digits is a string of exactly ten characters that replace the digits 0 through 9. It is used for numbering systems
+	// such as ArabicIndicDigits. Digits of any other length are ignored and ASCII digits are written. Use
+	// ValidateDigits to check a configured value. Default: "0123456789"
+	Digits string
+	digits []string
+
 	// Number of places to shift decimal places to the left. Negative numbers are shifted to the right. If set to 2 this
 	// will convert a fraction to a percentage.
 	Shift int32
 
+	// RoundBeforeShift rounds numbers with Rounder before Shift and Scale are applied instead of after. e.g. with
+	// Rounder places of 2 and Shift of 2, 0.12345 => 12 instead of 12.35.
+	RoundBeforeShift bool
+
+	// Scale multiplies numbers before rounding. It is applied after Shift. It can be used for unit conversions such as
+	// inches to centimeters with 2.54. Zero disables scaling.
+	Scale decimal.Decimal
+
 	MinDecimalPlaces int32 // Minimum number of decimal places to display.
 
+	// AlwaysShowDecimalSeparator writes the decimal separator even when there are no decimal places to display. e.g.
+	// 120 => 120.
+	AlwaysShowDecimalSeparator bool
+
+	// PreserveScale displays the trailing zeros of the number being formatted. e.g. "1.50" => 1.50 instead of 1.5. The
+	// scale comes from a string representation or decimal.Decimal exponent. Rounding can reduce but not increase it.
+	PreserveScale bool
+
+	// LeadingZeros keeps the leading zeros of string inputs that are zero-padded codes instead of numbers. e.g. "00731"
+	// => 00731 with LeadingZerosKeepUngrouped. It applies only when Shift, Scale, Base, Humanizer, and Scientific do not
+	// change the digits of the number. Default: LeadingZerosDrop
+	LeadingZeros LeadingZeros
+
+	// PipSeparator is written before the last decimal place so the fractional pip of an exchange rate stands out. e.g.
+	// 1.09235 => 1.0923 5 with " ". It is only written for numbers with at least 2 decimal places. Parse does not
+	// support PipSeparator. Default: ""
+	PipSeparator string
+
+	// MinWidth is the minimum width in runes of the formatted number. Shorter numbers are padded with spaces according
+	// to Alignment. This is intended for fixed-width output.
+	MinWidth int
+
+	// Alignment controls where padding is added when the formatted number is shorter than MinWidth. AlignDecimal
+	// reserves room after the decimal separator for the larger of MinDecimalPlaces and the Rounder places.
+	// Default: AlignRight
+	Alignment Alignment
+
+	// Fill is the character used to pad to MinWidth. e.g. "*" for check protection. If the template includes the {fill}
+	// verb padding is placed there instead of according to Alignment. Default: " "
+	Fill string
+
+	// PositivePlaceholder is repeated after non-negative numbers once for each character the negative number template
+	// writes after the number beyond what Template writes. This keeps decimal separators aligned with negative numbers
+	// in parentheses like "_)" in spreadsheet number formats. e.g. with NegativeTemplate "(n)" and PositivePlaceholder
+	// " " 5 is written as "5 " to align with "(5)". Default: ""
+	PositivePlaceholder string
+
+	// MaxWidth is the maximum width in runes of the formatted number. Wider numbers are written with fewer decimal
+	// places, then with CompactHumanizer if Humanizer is not set, and finally truncated with an ellipsis. e.g. with
+	// MaxWidth 6 1234.5678 => 1,235 and 123456789 => 123.5M. Zero disables the limit. Default: 0
+	MaxWidth int
+
+	// MaxIntegerDigits is the maximum number of integer digits to display for fixed-width forms and displays. Numbers
+	// with more digits after rounding are written as IntegerOverflow describes. It does not apply to numbers written
+	// with Humanizer or Scientific. Zero disables the limit. Default: 0
+	MaxIntegerDigits int
+
+	// IntegerOverflow is what is written for numbers exceeding MaxIntegerDigits. Default: OverflowClamp
+	IntegerOverflow IntegerOverflow
+
+	// Nil is written for nil inputs including nil pointers. Pointers to numbers such as *int and *decimal.Decimal are
+	// otherwise formatted as the number they point to. Default: ""
+	Nil string
+
+	// Undefined is written for results that are not defined such as FormatPercentOf with a whole of zero. Default: "—"
+	Undefined string
+
+	// ZeroDenominator is what FormatRatio and FormatPercentOf write when the denominator is within
+	// ZeroDenominatorTolerance of zero. A tolerance such as 1e-9 treats denominators that would be zero but for float
+	// error as zero. Default: ZeroDenominatorUndefined
+	ZeroDenominator          ZeroDenominator
+	ZeroDenominatorTolerance decimal.Decimal
+
+	// Invalid is written instead of inputs that are not numbers such as "abc" or NaN. If it is empty the input is written
+	// as a string. e.g. "n/a" or "—". Default: ""
+	Invalid string
+
+	// Fallback formats inputs that f cannot: inputs that are not numbers and numbers exceeding MaxIntegerDigits with
+	// OverflowError. Fallbacks can be chained. e.g. for a column of mixed data a Formatter with German separators can
+	// fall back to one with the default separators which falls back to one with Invalid "n/a". The PostFormat of both
+	// Fallback and f are called. If Fallback cannot format the input either, Invalid of f is written if it is set and
+	// otherwise what Fallback writes. Default: nil
+	Fallback *Formatter
+
+	// Unit is the unit of measurement written after the number. e.g. 12.3 kg.
+	Unit *Unit
+
+	// UnitSeparator is written between the number and Unit. NewLocaleFormatter sets it to the separator for the locale.
+	// Default: " "
+	UnitSeparator string
+
+	// Currency is an ISO 4217 code such as "JPY". It sets defaults for the currency: CurrencySymbol defaults to its
+	// symbol as used by NewCurrencyFormatter, and if Rounder is nil numbers are rounded to its minor units and
+	// MinDecimalPlaces defaults to them. e.g. 0 places for JPY and 3 for KWD. Explicitly set fields take precedence.
+	Currency string
+
+	// currencySymbol, currencyRounder, and currencyMinDecimalPlaces are the defaults from Currency set by compile.
+	currencySymbol           string
+	currencyRounder          *Rounder
+	currencyMinDecimalPlaces int32
+
+	// CurrencySymbol is written before the number, or after it if CurrencySuffix is set, when Template is empty. e.g.
+	// "$" or "€".
+	CurrencySymbol string
+
+	// CurrencySuffix writes CurrencySymbol after the number. e.g. 1 234,56 €. NewLocaleFormatter sets it for locales
+	// that write the symbol after the number.
+	CurrencySuffix bool
+
+	// CurrencySeparator is written between the number and CurrencySymbol. NewLocaleFormatter sets it to the separator
+	// for the locale. Default: NoBreakSpace if CurrencySuffix is set, " " if the symbol is before the number and ends
+	// with a letter such as CHF 1,234.50, and otherwise none.
+	CurrencySeparator string
+
+	// CurrencySign is where the sign of negative numbers is written relative to CurrencySymbol. It is only used when
+	// Template is empty. Default: CurrencySignBeforeSymbol
+	CurrencySign CurrencySignPosition
+
+	// MarkApproximate writes ApproximateSign before the number when rounding changed its value. e.g. 1234.5 => ≈1,235
+	// with Rounder places of 0 while 1234 => 1,234. Parse ignores ApproximateSign.
+	MarkApproximate bool
+
+	// ApproximateSign is written by MarkApproximate. Default: "≈"
+	ApproximateSign string
+
+	// ApproximateAfter writes ApproximateSign after the number instead of before it.
+	ApproximateAfter bool
+
+	// DisplayMax is the largest absolute value to display. Larger numbers are displayed as DisplayMax followed by
+	// DisplayMaxSuffix. e.g. 999+ for notification badges. DisplayMax is compared after Shift and Scale. Zero disables
+	// the cap.
+	DisplayMax decimal.Decimal
+
+	// DisplayMaxSuffix is written after numbers capped by DisplayMax. Default: "+"
+	DisplayMaxSuffix string
+
+	// ClampMin and ClampMax bound the values formatted so gauges and scores never display out of range values even when
+	// the data is wrong. Values outside the bounds are formatted as the bound. They are compared before PreFormat,
+	// Shift, and Scale. nil disables a bound.
+	ClampMin *decimal.Decimal
+	ClampMax *decimal.Decimal
+
+	// ClampBelowMarker and ClampAboveMarker are written before numbers clamped to ClampMin and ClampMax. e.g. "<" and
+	// ">" to write <0 and >100. Default: "" (the bound is written unmarked)
+	ClampBelowMarker string
+	ClampAboveMarker string
+
+	// ConciseUncertainty makes FormatWithUncertainty write the uncertainty in parentheses. e.g. 1.234(4) instead of
+	// 1.234 ± 0.004.
+	ConciseUncertainty bool
+	uncertainty        string
+
+	// Ordinal appends an English ordinal suffix to integers. e.g. 22 => 22nd. Numbers with decimal places are not
+	// given a suffix. Use a Rounder with 0 places to round them to integers. The {ordinal} verb places the suffix in
+	// Template.
+	Ordinal bool
+
+	// Base is the base to write numbers in such as 16 for hexadecimal. Numbers are rounded to integers. Digits,
+	// Rounder, and options for decimal places do not apply. The default GroupSeparator is "_" and the default GroupSize
+	// is 4 for binary and hexadecimal. Parse does not support bases other than 10. Default: 10
+	Base int
+
+	// BasePrefix writes the Go literal prefix for Base before the digits. e.g. 0x for hexadecimal.
+	BasePrefix bool
+
+	// UppercaseDigits writes digits above 9 in upper case. e.g. 0xFF instead of 0xff.
+	UppercaseDigits bool
+
+	// Repeating writes the repeating digits of a *big.Rat such as 1/3 exactly instead of rounding. e.g. 0.(3). Rounder
+	// and MinDecimalPlaces do not apply to numbers written this way. Default: RepeatingNone
+	Repeating Repeating
+
+	// Fraction writes the fractional part of numbers as a common fraction such as ½ when the number is close enough to
+	// one. Fraction takes precedence over Scientific, Humanizer, and Rounder.
+	Fraction *Fraction
+
+	// Sexagesimal writes numbers in base 60 as degrees, minutes, and seconds such as 48° 51′ 24″. Rounder,
+	// MinDecimalPlaces, and grouping of the minutes and seconds do not apply.
+	Sexagesimal *Sexagesimal
+
+	// Clock writes a quantity of time as hours, minutes, and seconds separated by colons such as 1:02:03. Rounder,
+	// MinDecimalPlaces, and grouping do not apply.
+	Clock *Clock
+
+	// Scientific switches to scientific notation for numbers with a large or small magnitude. e.g. 4.2e-9. Rounder
+	// and MinDecimalPlaces apply to the mantissa. Scientific takes precedence over Humanizer.
+	Scientific *Scientific
+
+	// SanitizeInput removes currency symbols, whitespace, underscores, and plus signs from string inputs that are not
+	// otherwise numbers. The remaining string is read with the separators of f or, if that fails, by guessing which of
+	// '.' and ',' is the decimal separator. e.g. " $1 234,50 " => 1,234.5. Default: false
+	SanitizeInput bool
+
+	// InputLimits bounds the length of string inputs and the magnitude of numbers so that untrusted inputs cannot cause
+	// huge allocations. e.g. &DefaultInputLimits. Default: nil which does not limit inputs
+	InputLimits *InputLimits
+
+	// FloatConversion is how float32 and float64 inputs are converted to decimal numbers. e.g. float64(0.1) => 0.1 with
+	// FloatShortest and 0.1000000000000000055511151231257827021181583404541015625 with FloatExact. Default: FloatShortest
+	FloatConversion FloatConversion
+
+	// FloatDigits is the number of significant digits of floats for FloatSignificantDigits. Default: 15 for float64 and
+	// 6 for float32
+	FloatDigits int
+
+	// PreserveExponent writes string inputs that are in exponent form such as "3E-7" in scientific notation normalized
+	// to one digit before the decimal separator. e.g. "12e5" => 1.2e6. The exponent is written as configured by
+	// Scientific if it is set.
+	PreserveExponent bool
+
+	// Humanizer scales the number down to a unit such as thousands or megabytes and appends the unit suffix. e.g.
+	// 1500 => 1.5K. Humanizing happens after shifting and before rounding.
+	Humanizer *Humanizer
+
+	// MagnitudeRules picks a different Formatter from the magnitude of the number. The first rule whose Min is less
+	// than or equal to the absolute value of the number is used. If no rule matches the Formatter itself is used. This
+	// allows a compact Formatter for large numbers and more decimal places for small numbers in the same column. Parse
+	// does not use MagnitudeRules.
+	MagnitudeRules []MagnitudeRule
+
+	// PreFormat is called with the number before any other formatting such as clamping it or converting it to
+	// another unit. It is not called for a *big.Rat formatted with Repeating. Default: nil
+	PreFormat func(decimal.Decimal) decimal.Decimal
+
+	// PostFormat is called with the formatted number such as wrapping it in markup. FormatParts does not call it.
+	// Default: nil
+	PostFormat func(string) string
+
 	// Template is a simple format string. All text other than format verbs is passed through unmodified. Backslash '\'
 	// escaping can be used to include a character otherwise used as a verb. You must include '-' or '+' to have show
-	// the sign.
+	// the sign. Characters can also be written as the escape sequences \xXX, \uXXXX, and \UXXXXXXXX of their hex code
+	// point such as \u00a0 for NoBreakSpace. {@name} includes a template registered with RegisterTemplate and {name} is
+	// replaced by a value of Placeholders. Verbs other than n, -, and + are names in braces so that other letters and
+	// symbols are written literally.
 	//
 	// Verbs:
-	//   n    the number
-	//   -    optional negative sign
-	//   +    always include sign
+	//   n          the number
+	//   -          optional negative sign
+	//   +          always include sign
+	//   {fill}     position of Fill padding when the number is shorter than MinWidth
+	//   {trend}    trend indicator: TrendUp, TrendDown, or TrendFlat for positive, negative, or zero
+	//   {int}      the grouped integer part of the number only
+	//   {frac}     the fractional digits of the number only without the decimal separator
+	//   {ordinal}  the English ordinal suffix of integers such as st in 21st. Nothing is written for other numbers.
 	//
 	// Examples:
 	//   "n"    => 9.45
 	//   "- n"  => - 9.45
 	//   "+n"   => +9.45
 	//   "n +"  => 9.45 +
+	//   "n-"   => 9.45-
 	//   "-$n"   => -$9.45
 	//   "n%"   => 9.45%
+	//   "${fill}n"  => $***9.45 (with Fill "*" and MinWidth 8)
+	//   "{trend} +n" => ▲ +9.45
+	//   "${int}<sup>{frac}</sup>" => $9<sup>45</sup>
+	//   "#n{ordinal}" => #23rd (with 23)
 	//
 	// Default: "n"
 	Template         string
@@ -69,44 +516,561 @@ type Formatter struct {
 	NegativeTemplate         string
 	compiledNegativeTemplate compiledTemplate
 
-	compileTemplateOnce sync.Once
+	// localeNegativeTemplate is the negative template of the locale applied by Locale. It is used for numbers without a
+	// currency if no template or MinusSign is set.
+	localeNegativeTemplate string
+
+	// NegativeStyle derives the negative template from Template when NegativeTemplate is empty. e.g. with
+	// NegativeParentheses the Template "$-n" writes ($9.45) for negative values. Default: NegativeSign
+	NegativeStyle NegativeStyle
+
+	// DebitLabel follows negative numbers with NegativeDebitCredit. Default: "DR"
+	DebitLabel string
+
+	// CreditLabel follows positive numbers and zero with NegativeDebitCredit. Default: "CR"
+	CreditLabel string
+
+	// Placeholders are substituted for {name} in Template and NegativeTemplate so one template can be shared by
+	// Formatters for different currencies or units. e.g. the Template "{sym}n" writes €9.45 with {"sym": "€"}. Values
+	// are written literally. A {name} that is not in Placeholders is not replaced. Default: nil
+	Placeholders map[string]string
+
+	// inputFormatter parses string inputs written with the separators of f.
+	inputFormatter *Formatter
+
+	// intFastPath is true if integers can be formatted without converting them to decimal.Decimal because no option
+	// that changes an integer is set.
+	intFastPath bool
+
+	// scaledFastPath is true if FormatScaled can write the digits of the mantissa directly because no option other than
+	// rounding to Places and padding decimal places changes the number.
+	scaledFastPath bool
+
+	// localized caches copies of f with the separators of a locale for FormatContext. It maps *locale to *Formatter.
+	localized *sync.Map
+
+	// currencyFormatters caches copies of f for the currencies of *money.Money values. It maps currency codes to
+	// *Formatter.
+	currencyFormatters *sync.Map
+
+	// self is f if f was compiled by NewFormatter. A copy of a compiled Formatter has a different address so it is not
+	// considered compiled.
+	self *Formatter
+
+	// compiledCopy holds the *compiledCopy of a Formatter that was not compiled by NewFormatter. It is stored atomically
+	// instead of guarded by a sync.Once so Formatter contains no locks and can be copied.
+	compiledCopy atomic.Value
+}
+
+// compiledCopy is the compiled copy of owner.
+type compiledCopy struct {
+	owner *Formatter
+	f     *Formatter
+}
+
+// NewFormatter returns a copy of cfg that is compiled immediately instead of on first use. Its methods skip the check
+// for whether it has been compiled, so it is preferred for a Formatter shared by many goroutines. cfg is not used and
+// may still be changed. If cfg is nil the zero value Formatter is used.
+func NewFormatter(cfg *Formatter) *Formatter {
+	if cfg == nil {
+		cfg = &Formatter{}
+	}
+	f := cfg.clone()
+	f.compile()
+	f.localized = &sync.Map{}
+	f.currencyFormatters = &sync.Map{}
+	f.self = f
+	return f
 }
 
-// Format formats v. v can be anything that fmt.Sprint can convert to a parsable number.
+// compiled returns f if it was compiled by NewFormatter. Otherwise it returns a compiled copy of f that is created on
+// first use. A copy of f made after it was used creates its own compiled copy.
+func (f *Formatter) compiled() *Formatter {
+	if f.self == f {
+		return f
+	}
+	if cc, ok := f.compiledCopy.Load().(*compiledCopy); ok && cc.owner == f {
+		return cc.f
+	}
+	c := NewFormatter(f)
+	f.compiledCopy.Store(&compiledCopy{owner: f, f: c})
+	return c
+}
+
+// Format formats v. v can be a *big.Rat or anything that fmt.Sprint can convert to a parsable number. A string
+// written with the group separator, decimal separator, and digits of f such as "1,234.56" is also accepted. A
+// *money.Money from github.com/Rhymond/go-money is written with the symbol and minor units of its currency unless f
+// sets them. e.g. money.New(123450, "EUR") => €1,234.50.
 func (f *Formatter) Format(v interface{}) string {
+	fs, s, ok := f.state(v)
+	if !ok {
+		return s
+	}
+	return f.finish(fs, v)
+}
+
+// state returns the formatState for v. If v is not a number ok is false and s is v converted to a string.
+func (f *Formatter) state(v interface{}) (fs *formatState, s string, ok bool) {
+	f = f.compiled()
+
+	v, isNil := derefInput(v)
+	if isNil {
+		return nil, f.Nil, false
+	}
+
+	if r, ok := v.(*big.Rat); ok && f.Repeating != RepeatingNone {
+		if fs, ok := f.repeatingState(r); ok {
+			return fs, "", true
+		}
+	}
+
+	if m, ok := v.(*money.Money); ok {
+		return f.moneyState(m), "", true
+	}
+
+	if i, ok := toInt64(v); ok {
+		if f.intFastPath {
+			return f.intState(i), "", true
+		}
+	}
+
+	d, s, ok, exceeded := f.limitedToDecimal(v)
+	if !ok {
+		if f.Fallback != nil && !exceeded {
+			fs, fallbackS, ok := f.Fallback.state(v)
+			if ok || f.Invalid == "" {
+				return fs, fallbackS, ok
+			}
+		}
+		return nil, s, false
+	}
+	if s, isString := v.(string); isString && f.PreserveExponent && strings.ContainsAny(s, "eE") {
+		return f.numberState(d, true), "", true
+	}
+	fs = f.decimalState(d)
+	if s, isString := v.(string); isString && f.LeadingZeros != LeadingZerosDrop {
+		f.keepLeadingZeros(fs, s)
+	}
+	return fs, "", true
+}
+
+// toInt64 converts v to an int64 if it is a signed integer.
+func toInt64(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// intState returns the formatState for i without converting it to a decimal.Decimal. It must only be used when
+// f.intFastPath is true.
+func (f *Formatter) intState(i int64) *formatState {
+	intPart := strconv.FormatInt(i, 10)
+	neg := i < 0
+	if neg {
+		intPart = intPart[1:]
+	}
+
+	return &formatState{
+		f:       f,
+		neg:     neg,
+		zero:    i == 0,
+		intPart: intPart,
+
+		fillIndex: -1,
+	}
+}
+
+// toDecimal converts v to a decimal.Decimal. If v cannot be converted ok is false and s is v converted to a string.
+func toDecimal(v interface{}) (d decimal.Decimal, s string, ok bool) {
 	switch v := v.(type) {
 	case decimal.Decimal:
-		return f.formatDecimal(v)
+		return v, "", true
 	case string:
 		d, err := decimal.NewFromString(v)
 		if err != nil {
-			return fmt.Sprint(v)
+			return decimal.Decimal{}, v, false
 		}
-		return f.formatDecimal(d)
+		return d, "", true
+	case int:
+		return decimal.NewFromInt(int64(v)), "", true
+	case int8:
+		return decimal.NewFromInt(int64(v)), "", true
+	case int16:
+		return decimal.NewFromInt(int64(v)), "", true
 	case int32:
-		return f.formatDecimal(decimal.NewFromInt32(v))
+		return decimal.NewFromInt32(v), "", true
 	case int64:
-		return f.formatDecimal(decimal.NewFromInt(v))
+		return decimal.NewFromInt(v), "", true
+	case uint:
+		return uintDecimal(uint64(v)), "", true
+	case uint8:
+		return uintDecimal(uint64(v)), "", true
+	case uint16:
+		return uintDecimal(uint64(v)), "", true
+	case uint32:
+		return uintDecimal(uint64(v)), "", true
+	case uint64:
+		return uintDecimal(v), "", true
+	case float32:
+		return floatDecimal(float64(v), 32)
+	case float64:
+		return floatDecimal(v, 64)
+	case *big.Rat:
+		num := decimal.NewFromBigInt(v.Num(), 0)
+		den := decimal.NewFromBigInt(v.Denom(), 0)
+		return num.Div(den), "", true
+	case *money.Money:
+		return moneyAmount(v), "", true
+	case BigDecimal:
+		if d, ok := bigDecimalToDecimal(v); ok {
+			return d, "", true
+		}
+		return decimal.Decimal{}, inputString(v), false
 	default:
-		s := fmt.Sprint(v)
+		s := inputString(v)
 		d, err := decimal.NewFromString(s)
 		if err != nil {
-			return s
+			return decimal.Decimal{}, s, false
+		}
+		return d, "", true
+	}
+}
+
+// uintDecimal returns u as a decimal.Decimal.
+func uintDecimal(u uint64) decimal.Decimal {
+	if u <= math.MaxInt64 {
+		return decimal.NewFromInt(int64(u))
+	}
+	return decimal.NewFromBigInt(new(big.Int).SetUint64(u), 0)
+}
+
+// floatDecimal returns the shortest decimal that converts back to the float x of bitSize bits as written by
+// fmt.Sprint. If x is not finite ok is false and s is x as written by fmt.Sprint.
+func floatDecimal(x float64, bitSize int) (d decimal.Decimal, s string, ok bool) {
+	s = strconv.FormatFloat(x, 'g', -1, bitSize)
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Decimal{}, s, false
+	}
+	return d, "", true
+}
+
+// toDecimal converts v to a decimal.Decimal like the toDecimal function. In addition, a string written with the
+// separators, digits, and signs of f is accepted. e.g. "1.234,5" with the separators of German.
+func (f *Formatter) toDecimal(v interface{}) (d decimal.Decimal, s string, ok bool) {
+	d, s, ok, _ = f.limitedToDecimal(v)
+	return d, s, ok
+}
+
+// unlimitedToDecimal is toDecimal without InputLimits.
+func (f *Formatter) unlimitedToDecimal(v interface{}) (d decimal.Decimal, s string, ok bool) {
+	v, isNil := derefInput(v)
+	if isNil {
+		return decimal.Decimal{}, f.Nil, false
+	}
+
+	if f.FloatConversion != FloatShortest {
+		if d, ok := f.floatToDecimal(v); ok {
+			return d, "", true
+		}
+	}
+
+	d, s, ok = toDecimal(v)
+	if ok {
+		return d, s, ok
+	}
+
+	if str, isString := v.(string); isString {
+		f = f.compiled()
+		if d, err := f.inputFormatter.ParseStrict(strings.TrimSpace(str)); err == nil {
+			return d, "", true
+		}
+		if f.SanitizeInput {
+			if d, ok := f.sanitizeInput(str); ok {
+				return d, "", true
+			}
 		}
-		return f.formatDecimal(d)
 	}
+
+	if f.Invalid != "" {
+		s = f.Invalid
+	}
+	return d, s, false
+}
+
+// clone returns a copy of the exported configuration of f and the uncertainty set by FormatUncertainty. The copy has
+// not been used and can be modified. The fields are copied without reflection so formatting typed inputs does not
+// depend on reflect.
+func (f *Formatter) clone() *Formatter {
+	c := &Formatter{}
+	c.setConfig(f)
+	return c
 }
 
+// setConfig sets the exported configuration of f and the uncertainty set by FormatUncertainty to those of g. The
+// compiled state of g is not copied. f must not have been used.
+func (f *Formatter) setConfig(g *Formatter) {
+	f.GroupSeparator = g.GroupSeparator
+	f.GroupSize = g.GroupSize
+	f.Grouping = g.Grouping
+	f.MinGroupingDigits = g.MinGroupingDigits
+	f.NoGrouping = g.NoGrouping
+	f.DecimalSeparator = g.DecimalSeparator
+	f.Rounder = g.Rounder
+	f.MinusSign = g.MinusSign
+	f.PlusSign = g.PlusSign
+	f.ZeroSign = g.ZeroSign
+	f.TrendUp = g.TrendUp
+	f.TrendDown = g.TrendDown
+	f.TrendFlat = g.TrendFlat
+	f.Colors = g.Colors
+	f.BidiIsolation = g.BidiIsolation
+	f.MyriadUnits = g.MyriadUnits
+	f.Mask = g.Mask
+	f.Digits = g.Digits
+	f.Shift = g.Shift
+	f.RoundBeforeShift = g.RoundBeforeShift
+	f.Scale = g.Scale
+	f.MinDecimalPlaces = g.MinDecimalPlaces
+	f.AlwaysShowDecimalSeparator = g.AlwaysShowDecimalSeparator
+	f.PreserveScale = g.PreserveScale
+	f.LeadingZeros = g.LeadingZeros
+	f.PipSeparator = g.PipSeparator
+	f.MinWidth = g.MinWidth
+	f.Alignment = g.Alignment
+	f.Fill = g.Fill
+	f.PositivePlaceholder = g.PositivePlaceholder
+	f.MaxWidth = g.MaxWidth
+	f.MaxIntegerDigits = g.MaxIntegerDigits
+	f.IntegerOverflow = g.IntegerOverflow
+	f.Nil = g.Nil
+	f.Undefined = g.Undefined
+	f.ZeroDenominator = g.ZeroDenominator
+	f.ZeroDenominatorTolerance = g.ZeroDenominatorTolerance
+	f.Invalid = g.Invalid
+	f.Fallback = g.Fallback
+	f.Unit = g.Unit
+	f.UnitSeparator = g.UnitSeparator
+	f.Currency = g.Currency
+	f.CurrencySymbol = g.CurrencySymbol
+	f.CurrencySuffix = g.CurrencySuffix
+	f.CurrencySeparator = g.CurrencySeparator
+	f.CurrencySign = g.CurrencySign
+	f.MarkApproximate = g.MarkApproximate
+	f.ApproximateSign = g.ApproximateSign
+	f.ApproximateAfter = g.ApproximateAfter
+	f.DisplayMax = g.DisplayMax
+	f.DisplayMaxSuffix = g.DisplayMaxSuffix
+	f.ClampMin = g.ClampMin
+	f.ClampMax = g.ClampMax
+	f.ClampBelowMarker = g.ClampBelowMarker
+	f.ClampAboveMarker = g.ClampAboveMarker
+	f.ConciseUncertainty = g.ConciseUncertainty
+	f.Ordinal = g.Ordinal
+	f.Base = g.Base
+	f.BasePrefix = g.BasePrefix
+	f.UppercaseDigits = g.UppercaseDigits
+	f.Repeating = g.Repeating
+	f.Fraction = g.Fraction
+	f.Sexagesimal = g.Sexagesimal
+	f.Clock = g.Clock
+	f.Scientific = g.Scientific
+	f.SanitizeInput = g.SanitizeInput
+	f.InputLimits = g.InputLimits
+	f.FloatConversion = g.FloatConversion
+	f.FloatDigits = g.FloatDigits
+	f.PreserveExponent = g.PreserveExponent
+	f.Humanizer = g.Humanizer
+	f.MagnitudeRules = g.MagnitudeRules
+	f.PreFormat = g.PreFormat
+	f.PostFormat = g.PostFormat
+	f.Template = g.Template
+	f.NegativeTemplate = g.NegativeTemplate
+	f.NegativeStyle = g.NegativeStyle
+	f.DebitLabel = g.DebitLabel
+	f.CreditLabel = g.CreditLabel
+	f.Placeholders = g.Placeholders
+	f.uncertainty = g.uncertainty
+	f.localeNegativeTemplate = g.localeNegativeTemplate
+}
+
+// formatDecimal formats d. It checks InputLimits as Format does because d may not have come from Format's input such
+// as a ratio or a number from FormatScaled.
 func (f *Formatter) formatDecimal(d decimal.Decimal) string {
-	f.compileTemplateOnce.Do(f.compileTemplates)
+	if !f.InputLimits.allows(d) {
+		return f.exceededText()
+	}
+	return f.finish(f.decimalState(d), d)
+}
 
-	if f.Shift != 0 {
-		d = d.Shift(f.Shift)
+// finish renders fs, shortens it to MaxWidth, and calls PostFormat. v is the number fs was produced from. If fs was
+// produced by the Formatter of a MagnitudeRule both its PostFormat and that of f are called.
+func (f *Formatter) finish(fs *formatState, v interface{}) string {
+	s, _ := f.finishShortened(fs, v)
+	return s
+}
+
+// finishShortened is finish that also reports whether the result was shortened to MaxWidth.
+func (f *Formatter) finishShortened(fs *formatState, v interface{}) (s string, shortened bool) {
+	f = f.compiled()
+	s = fs.f.render(fs)
+	if f.MaxWidth > 0 && utf8.RuneCountInString(stripANSI(s)) > f.MaxWidth {
+		s = f.shorten(v, len(fs.fracPart))
+		shortened = true
+	}
+	if fs.f.PostFormat != nil {
+		s = fs.f.PostFormat(s)
+	}
+	if fs.f != f && f.PostFormat != nil {
+		s = f.PostFormat(s)
+	}
+	return s, shortened
+}
+
+// decimalState returns the formatState for d. fs.f is the Formatter that renders it which may be the Formatter of a
+// MagnitudeRule instead of f.
+func (f *Formatter) decimalState(d decimal.Decimal) *formatState {
+	return f.numberState(d, false)
+}
+
+// numberState returns the formatState for d like decimalState. If exponentForm is true non-zero d is written in
+// scientific notation.
+func (f *Formatter) numberState(d decimal.Decimal, exponentForm bool) *formatState {
+	f = f.compiled()
+	if f.ClampMin != nil || f.ClampMax != nil {
+		return f.clampedNumberState(d, exponentForm)
+	}
+	return f.unclampedNumberState(d, exponentForm)
+}
+
+// unclampedNumberState is numberState without ClampMin and ClampMax.
+func (f *Formatter) unclampedNumberState(d decimal.Decimal, exponentForm bool) *formatState {
+	input := d
+
+	if f.PreFormat != nil {
+		d = f.PreFormat(d)
+	}
+
+	if len(f.MagnitudeRules) > 0 {
+		abs := d.Abs()
+		for _, rule := range f.MagnitudeRules {
+			if abs.Cmp(rule.Min) >= 0 {
+				return rule.Formatter.numberState(d, exponentForm)
+			}
+		}
+	}
+
+	var rounded bool
+	rounder := f.rounder()
+	if f.RoundBeforeShift && rounder != nil {
+		r := rounder.Round(d)
+		rounded = !r.Equal(d)
+		d = r
+		rounder = nil
+	}
+
+	d = f.scale(d)
+	if f.base() != 10 {
+		return f.baseState(d)
+	}
+
+	if f.Fraction != nil {
+		if fs, ok := f.fractionState(d); ok {
+			return fs
+		}
+	}
+
+	if f.Sexagesimal != nil {
+		return f.sexagesimalState(d)
+	}
+
+	if f.Clock != nil {
+		return f.clockState(d)
+	}
+
+	minDecimalPlaces := int(f.minDecimalPlaces())
+
+	var capSuffix string
+	if !f.DisplayMax.IsZero() && d.Abs().Cmp(f.DisplayMax) > 0 {
+		d = f.DisplayMax.Mul(decimal.New(int64(d.Sign()), 0))
+		capSuffix = f.displayMaxSuffix()
 	}
-	if f.Rounder != nil {
-		d = d.Round(f.Rounder.Places)
+
+	scientific := (f.Scientific != nil && f.Scientific.applies(d)) || (exponentForm && !d.IsZero())
+	humanizer := f.Humanizer
+	var overflow bool
+	if f.MaxIntegerDigits > 0 && !scientific && humanizer == nil {
+		limit := decimal.New(1, int32(f.MaxIntegerDigits))
+		abs := d.Abs()
+		if rounder != nil {
+			abs = rounder.Round(abs)
+		}
+		if abs.Cmp(limit) >= 0 {
+			overflow = true
+			switch f.IntegerOverflow {
+			case OverflowClamp:
+				d = limit.Sub(decimal.New(1, 0)).Mul(decimal.New(int64(d.Sign()), 0))
+				capSuffix = f.displayMaxSuffix()
+			case OverflowScientific:
+				scientific = true
+			case OverflowCompact:
+				humanizer = CompactHumanizer
+			case OverflowError:
+				if f.Fallback != nil {
+					return f.Fallback.numberState(input, exponentForm)
+				}
+			}
+		}
 	}
 
+	var exponent, suffix, unitPrefix string
+	var roundedAfterScale bool
+	if scientific {
+		d, exponent, roundedAfterScale = f.scientific(d, rounder)
+	} else if humanizer != nil {
+		var unit humanizeUnit
+		d, unit, roundedAfterScale = humanizer.humanize(d, rounder)
+		suffix = unit.suffix
+		rounder = unit.roundWith(rounder)
+	} else if f.Unit != nil && f.Unit.Prefixes && !d.IsZero() {
+		var unit humanizeUnit
+		d, unit, roundedAfterScale = siPrefixHumanizer.humanize(d, rounder)
+		unitPrefix = unit.suffix
+	} else {
+		scale := -int(d.Exponent())
+		if rounder != nil {
+			r := rounder.Round(d)
+			roundedAfterScale = !r.Equal(d)
+			d = r
+			if roundedScale := -int(d.Exponent()); roundedScale < scale {
+				scale = roundedScale
+			}
+		}
+		if f.PreserveScale && scale > minDecimalPlaces {
+			minDecimalPlaces = scale
+		}
+	}
+
+	scaleSuffix := suffix
+	if rounder != nil && rounder.SignificantDigits > 0 {
+		if places := int(rounder.padPlaces(d)); places > minDecimalPlaces {
+			minDecimalPlaces = places
+		}
+	}
+
+	suffix += capSuffix + f.uncertainty
+
 	parts := strings.SplitN(d.String(), ".", 2)
 	intPart := parts[0]
 	var fracPart string
@@ -120,8 +1084,12 @@ func (f *Formatter) formatDecimal(d decimal.Decimal) string {
 		intPart = intPart[1:]
 	}
 
-	if len(fracPart) < int(f.MinDecimalPlaces) {
-		buf := make([]byte, int(f.MinDecimalPlaces))
+	if f.Ordinal && suffix == "" && f.Unit == nil && fracPart == "" && minDecimalPlaces == 0 {
+		suffix = ordinalSuffix(intPart)
+	}
+
+	if len(fracPart) < minDecimalPlaces {
+		buf := make([]byte, minDecimalPlaces)
 		copy(buf, fracPart)
 		for i := len(fracPart); i < len(buf); i++ {
 			buf[i] = '0'
@@ -129,14 +1097,167 @@ func (f *Formatter) formatDecimal(d decimal.Decimal) string {
 		fracPart = string(buf)
 	}
 
+	if f.Unit != nil {
+		suffix += f.unitSuffix(unitPrefix, intPart, fracPart)
+	}
+
+	fs := &formatState{
+		f:        f,
+		neg:      neg,
+		zero:     d.IsZero(),
+		intPart:  intPart,
+		fracPart: fracPart,
+		exponent: exponent,
+		suffix:   suffix,
+
+		rounded:     rounded || roundedAfterScale,
+		clamped:     capSuffix != "",
+		overflow:    overflow,
+		scaleSuffix: scaleSuffix,
+
+		fillIndex: -1,
+	}
+
+	return fs
+}
+
+// render writes fs with the template and applies padding and colors.
+func (f *Formatter) render(fs *formatState) string {
 	sb := &strings.Builder{}
-	if neg && f.compiledNegativeTemplate != nil {
-		f.compiledNegativeTemplate.write(sb, f, neg, intPart, fracPart)
+	writeBidiOpen(sb, f.BidiIsolation)
+	if fs.rounded && f.MarkApproximate && !f.ApproximateAfter {
+		sb.WriteString(f.approximateSign())
+	}
+	sb.WriteString(fs.clampMarker)
+	if fs.overflow && f.IntegerOverflow == OverflowError {
+		sb.WriteString(strings.Repeat("#", f.MaxIntegerDigits))
+	} else if fs.neg && f.compiledNegativeTemplate != nil {
+		f.compiledNegativeTemplate.write(sb, fs)
 	} else {
-		f.compiledTemplate.write(sb, f, neg, intPart, fracPart)
+		f.compiledTemplate.write(sb, fs)
+		f.writePositivePlaceholder(sb, fs)
+	}
+	if fs.rounded && f.MarkApproximate && f.ApproximateAfter {
+		sb.WriteString(f.approximateSign())
+	}
+	writeBidiClose(sb, f.BidiIsolation)
+
+	s := sb.String()
+	if f.MinWidth > 0 {
+		if fs.fillIndex >= 0 {
+			s = f.padAt(s, fs.fillIndex)
+		} else {
+			s = f.pad(s)
+		}
+	}
+
+	if f.Colors != nil {
+		s = f.Colors.colorize(s, fs)
+	}
+
+	return s
+}
+
+// writePositivePlaceholder writes PositivePlaceholder for the characters the negative number template writes after the
+// number beyond those written for fs.
+func (f *Formatter) writePositivePlaceholder(sb *strings.Builder, fs *formatState) {
+	if f.PositivePlaceholder == "" {
+		return
+	}
+
+	negTemplate := f.compiledTemplate
+	if f.compiledNegativeTemplate != nil {
+		negTemplate = f.compiledNegativeTemplate
+	}
+	neg := *fs
+	neg.neg = true
+
+	n := negTemplate.trailingWidth(&neg) - f.compiledTemplate.trailingWidth(fs)
+	for i := 0; i < n; i++ {
+		sb.WriteString(f.PositivePlaceholder)
 	}
+}
+
+// formatState is the state of formatting a single number.
+type formatState struct {
+	f        *Formatter
+	neg      bool
+	zero     bool
+	intPart  string // Integer digits without sign.
+	fracPart string // Fractional digits.
+	prefix   string // Base prefix written before intPart.
+	repeat   string // Repeating digits written after fracPart.
+	fraction string // Common fraction written after intPart.
+	exponent string // Scientific notation exponent written after fracPart.
+	suffix   string // Unit suffix written immediately after the number.
+
+	ungrouped bool // intPart is written without group separators.
+
+	rounded     bool   // Rounding changed the value.
+	clamped     bool   // The value was capped by DisplayMax, MaxIntegerDigits, ClampMin, or ClampMax.
+	clampMarker string // ClampBelowMarker or ClampAboveMarker written before the number.
+	overflow    bool   // The value exceeded MaxIntegerDigits.
+	scaleSuffix string // Humanizer suffix included in suffix.
 
-	return sb.String()
+	fillIndex int // Byte index in the output where Fill padding is inserted. -1 if not set.
+}
+
+func writeBidiOpen(sb *strings.Builder, bi BidiIsolation) {
+	switch bi {
+	case BidiFirstStrongIsolate:
+		sb.WriteString("\u2068")
+	case BidiLeftToRightIsolate:
+		sb.WriteString("\u2066")
+	case BidiLeftToRightMark:
+		sb.WriteString("\u200e")
+	}
+}
+
+func writeBidiClose(sb *strings.Builder, bi BidiIsolation) {
+	switch bi {
+	case BidiFirstStrongIsolate, BidiLeftToRightIsolate:
+		sb.WriteString("\u2069")
+	case BidiLeftToRightMark:
+		sb.WriteString("\u200e")
+	}
+}
+
+func (f *Formatter) compile() {
+	f.compileCurrency()
+	f.compileTemplates()
+	f.compileDigits()
+	f.compileMask()
+	f.inputFormatter = &Formatter{
+		GroupSeparator:   f.groupSeparator(),
+		GroupSize:        f.GroupSize,
+		Grouping:         f.Grouping,
+		DecimalSeparator: f.DecimalSeparator,
+		Digits:           f.Digits,
+		MinusSign:        f.MinusSign,
+		PlusSign:         f.PlusSign,
+	}
+	digitsOnly := f.PreFormat == nil &&
+		len(f.MagnitudeRules) == 0 &&
+		f.Shift == 0 &&
+		f.Scale.IsZero() &&
+		f.base() == 10 &&
+		f.Fraction == nil &&
+		f.Sexagesimal == nil &&
+		f.Clock == nil &&
+		f.DisplayMax.IsZero() &&
+		f.ClampMin == nil &&
+		f.ClampMax == nil &&
+		f.InputLimits == nil &&
+		f.Scientific == nil &&
+		f.Humanizer == nil &&
+		f.Unit == nil &&
+		f.uncertainty == "" &&
+		!f.Ordinal &&
+		f.MaxIntegerDigits == 0
+	r := f.rounder()
+	f.intFastPath = digitsOnly && r == nil && f.minDecimalPlaces() == 0
+	f.scaledFastPath = digitsOnly && f.MaxWidth == 0 &&
+		(r == nil || r.SignificantDigits == 0 && len(r.Rules) == 0 && r.Places >= 0)
 }
 
 func (f *Formatter) compileTemplates() {
@@ -144,216 +1265,461 @@ func (f *Formatter) compileTemplates() {
 		return
 	}
 
+	t, nt := f.templates()
+	f.compiledTemplate = cachedTemplate(t)
+
+	if nt == "" {
+		switch f.NegativeStyle {
+		case NegativeParentheses:
+			f.compiledNegativeTemplate = f.compiledTemplate.parenthesized()
+		case NegativeDebitCredit:
+			f.compiledNegativeTemplate = f.compiledTemplate.labeled(f.debitLabel())
+			f.compiledTemplate = f.compiledTemplate.labeled(f.creditLabel())
+		}
+		return
+	}
+
+	f.compiledNegativeTemplate = cachedTemplate(nt)
+}
+
+// templates returns the template and negative template used by f including the defaults and the templates for
+// currency symbols. f must be compiled.
+func (f *Formatter) templates() (template, negativeTemplate string) {
 	t := "-n"
+	nt := f.expandPlaceholders(f.NegativeTemplate)
 	if f.Template != "" {
-		t = f.Template
+		t = f.expandPlaceholders(f.Template)
+	} else if f.symbol() != "" {
+		var currencyNegative string
+		t, currencyNegative = f.currencyTemplates()
+		if nt == "" {
+			nt = currencyNegative
+		}
+	} else if nt == "" && f.NegativeStyle == NegativeSign && f.MinusSign == "" {
+		nt = f.localeNegativeTemplate
 	}
-	f.compiledTemplate = compileTemplate(t)
+	return t, nt
+}
 
-	if f.NegativeTemplate == "" {
+func (f *Formatter) compileDigits() {
+	if f.Digits == "" || f.Digits == asciiDigits {
 		return
 	}
 
-	f.compiledNegativeTemplate = compileTemplate(f.NegativeTemplate)
+	digits := make([]string, 0, 10)
+	for _, r := range f.Digits {
+		digits = append(digits, string(r))
+	}
+	if len(digits) == 10 {
+		f.digits = digits
+	}
+}
+
+func (f *Formatter) groupSeparator() string {
+	if f.GroupSeparator != "" {
+		return f.GroupSeparator
+	}
+	if f.base() != 10 {
+		return "_"
+	}
+	return ","
+}
+
+func (f *Formatter) groupSize() int {
+	if f.GroupSize != 0 {
+		return f.GroupSize
+	}
+	switch f.base() {
+	case 2, 16:
+		return 4
+	default:
+		return 3
+	}
+}
+
+// scale applies Shift and Scale to d.
+func (f *Formatter) scale(d decimal.Decimal) decimal.Decimal {
+	if f.Shift != 0 {
+		d = d.Shift(f.Shift)
+	}
+	if !f.Scale.IsZero() {
+		d = d.Mul(f.Scale)
+	}
+	return d
+}
+
+func (f *Formatter) approximateSign() string {
+	if f.ApproximateSign != "" {
+		return f.ApproximateSign
+	}
+	return "≈"
+}
+
+func (f *Formatter) displayMaxSuffix() string {
+	if f.DisplayMaxSuffix != "" {
+		return f.DisplayMaxSuffix
+	}
+	return "+"
+}
+
+func (f *Formatter) minusSign() string {
+	if f.MinusSign != "" {
+		return f.MinusSign
+	}
+	return "-"
+}
+
+func (f *Formatter) plusSign() string {
+	if f.PlusSign != "" {
+		return f.PlusSign
+	}
+	return "+"
+}
+
+func (f *Formatter) trendUp() string {
+	if f.TrendUp != "" {
+		return f.TrendUp
+	}
+	return "▲"
+}
+
+func (f *Formatter) trendDown() string {
+	if f.TrendDown != "" {
+		return f.TrendDown
+	}
+	return "▼"
+}
+
+func (f *Formatter) trendFlat() string {
+	if f.TrendFlat != "" {
+		return f.TrendFlat
+	}
+	return "–"
+}
+
+func (f *Formatter) undefined() string {
+	if f.Undefined != "" {
+		return f.Undefined
+	}
+	return "—"
+}
+
+func (f *Formatter) debitLabel() string {
+	if f.DebitLabel != "" {
+		return f.DebitLabel
+	}
+	return "DR"
+}
+
+func (f *Formatter) creditLabel() string {
+	if f.CreditLabel != "" {
+		return f.CreditLabel
+	}
+	return "CR"
+}
+
+func (f *Formatter) decimalSeparator() string {
+	if f.DecimalSeparator != "" {
+		return f.DecimalSeparator
+	}
+	return "."
 }
 
-func writeSeparateGroups(sb *strings.Builder, num, groupSeparator string, groupSize int) {
-	if len(groupSeparator) == 0 || groupSize == 0 || len(num) <= groupSize {
+// writeDigits writes the ASCII digits in num to sb replacing them with digits if it is not nil.
+func writeDigits(sb *strings.Builder, num string, digits []string) {
+	if digits == nil {
 		sb.WriteString(num)
 		return
 	}
 
-	sepCount := len(num) / groupSize
-	numIdx := len(num) % groupSize
-	if numIdx == 0 {
-		numIdx = groupSize
-		sepCount--
+	for i := 0; i < len(num); i++ {
+		sb.WriteString(digits[num[i]-'0'])
+	}
+}
+
+// writeSeparateGroups writes num with groupSeparator between groups of digits. widths are the widths of the groups
+// from the right as returned by Grouping.
+func writeSeparateGroups(sb *strings.Builder, num, groupSeparator string, widths []int, digits []string) {
+	if len(groupSeparator) == 0 || len(widths) == 0 {
+		writeDigits(sb, num, digits)
+		return
+	}
+
+	var starts []int // Start indexes of groups after the first in descending order.
+	pos := len(num)
+	for i := 0; ; i++ {
+		width := groupWidth(widths, i)
+		if width <= 0 || pos <= width {
+			break
+		}
+		pos -= width
+		starts = append(starts, pos)
 	}
-	sb.WriteString(num[:numIdx])
 
-	for i := 0; i < sepCount; i++ {
+	prev := 0
+	for i := len(starts) - 1; i >= 0; i-- {
+		writeDigits(sb, num[prev:starts[i]], digits)
 		sb.WriteString(groupSeparator)
-		lastNumIdx := numIdx
-		numIdx += groupSize
-		sb.WriteString(num[lastNumIdx:numIdx])
+		prev = starts[i]
 	}
+	writeDigits(sb, num[prev:], digits)
 }
 
 type compiledTemplatePart interface {
-	write(sb *strings.Builder, f *Formatter, neg bool, intPart, fracPart string)
+	write(sb *strings.Builder, fs *formatState)
+	parse(ps *parseState) bool
 }
 
 type compiledTemplate []compiledTemplatePart
 
-func (ct compiledTemplate) write(sb *strings.Builder, f *Formatter, neg bool, intPart, fracPart string) {
+func (ct compiledTemplate) write(sb *strings.Builder, fs *formatState) {
 	for _, part := range ct {
-		part.write(sb, f, neg, intPart, fracPart)
+		part.write(sb, fs)
+	}
+}
+
+// trailingWidth returns the number of characters ct writes after the number for fs.
+func (ct compiledTemplate) trailingWidth(fs *formatState) int {
+	fsCopy := *fs // Writing may change fs.
+	sb := &strings.Builder{}
+	for i := len(ct) - 1; i >= 0; i-- {
+		if isNumberPart(ct[i]) {
+			for _, part := range ct[i+1:] {
+				part.write(sb, &fsCopy)
+			}
+			break
+		}
 	}
+	return utf8.RuneCountInString(sb.String())
+}
+
+// parenthesized returns ct surrounded by parentheses with its sign verbs removed.
+func (ct compiledTemplate) parenthesized() compiledTemplate {
+	nt := compiledTemplate{compiledTemplatePartLiteral("(")}
+	for _, part := range ct {
+		switch part.(type) {
+		case compiledTemplatePartOptionalSign, compiledTemplatePartForceSign:
+		default:
+			nt = append(nt, part)
+		}
+	}
+	return append(nt, compiledTemplatePartLiteral(")"))
+}
+
+// labeled returns ct followed by a space and label with its sign verbs removed.
+func (ct compiledTemplate) labeled(label string) compiledTemplate {
+	nt := compiledTemplate{}
+	for _, part := range ct {
+		switch part.(type) {
+		case compiledTemplatePartOptionalSign, compiledTemplatePartForceSign:
+		default:
+			nt = append(nt, part)
+		}
+	}
+	return append(nt, compiledTemplatePartLiteral(" "+label))
+}
+
+func (ct compiledTemplate) parse(ps *parseState) bool {
+	for _, part := range ct {
+		if !part.parse(ps) {
+			return false
+		}
+	}
+	return true
 }
 
 type compiledTemplatePartLiteral string
 
-func (p compiledTemplatePartLiteral) write(sb *strings.Builder, f *Formatter, neg bool, intPart, fracPart string) {
+func (p compiledTemplatePartLiteral) write(sb *strings.Builder, fs *formatState) {
 	sb.WriteString(string(p))
 }
 
+func (p compiledTemplatePartLiteral) parse(ps *parseState) bool {
+	return ps.consumeLiteral(string(p))
+}
+
 type compiledTemplatePartNumber struct{}
 
-func (compiledTemplatePartNumber) write(sb *strings.Builder, f *Formatter, neg bool, intPart, fracPart string) {
-	groupSeparator := ","
-	if f.GroupSeparator != "" {
-		groupSeparator = f.GroupSeparator
+func (compiledTemplatePartNumber) write(sb *strings.Builder, fs *formatState) {
+	fs.writeInteger(sb)
+	sb.WriteString(fs.fraction)
+	if fs.hasDecimalSeparator() {
+		sb.WriteString(fs.f.decimalSeparator())
+		fs.writeFraction(sb)
 	}
-	groupSize := 3
-	if f.GroupSize != 0 {
-		groupSize = f.GroupSize
+	sb.WriteString(fs.exponent)
+	sb.WriteString(fs.suffix)
+}
+
+// writeInteger writes the base prefix and the grouped integer digits.
+func (fs *formatState) writeInteger(sb *strings.Builder) {
+	f := fs.f
+	digits := f.digits
+	if f.base() != 10 {
+		digits = nil
 	}
-	writeSeparateGroups(sb, intPart, groupSeparator, groupSize)
 
-	decimalSeparator := "."
-	if f.DecimalSeparator != "" {
-		decimalSeparator = f.DecimalSeparator
+	sb.WriteString(fs.prefix)
+	if f.compiledMask != nil {
+		f.compiledMask.write(sb, fs.intPart, digits)
+	} else if fs.ungrouped {
+		writeDigits(sb, fs.intPart, digits)
+	} else if len(f.MyriadUnits) > 0 && fs.intPart != "" {
+		writeMyriadGroups(sb, fs.intPart, f.MyriadUnits, digits)
+	} else {
+		writeSeparateGroups(sb, fs.intPart, f.groupSeparator(), f.groupWidths(len(fs.intPart)), digits)
+	}
+}
+
+func (fs *formatState) hasDecimalSeparator() bool {
+	return len(fs.fracPart) != 0 || fs.repeat != "" || (fs.f.AlwaysShowDecimalSeparator && fs.fraction == "")
+}
+
+// writeFraction writes the fractional digits including any repeating digits.
+func (fs *formatState) writeFraction(sb *strings.Builder) {
+	if n := len(fs.fracPart); fs.f.PipSeparator != "" && n >= 2 && fs.repeat == "" {
+		writeDigits(sb, fs.fracPart[:n-1], fs.f.digits)
+		sb.WriteString(fs.f.PipSeparator)
+		writeDigits(sb, fs.fracPart[n-1:], fs.f.digits)
+		return
 	}
-	if len(fracPart) != 0 {
-		sb.WriteString(decimalSeparator)
-		sb.WriteString(fracPart)
+	writeDigits(sb, fs.fracPart, fs.f.digits)
+	if fs.repeat != "" {
+		fs.f.writeRepeating(sb, fs.repeat)
 	}
 }
 
-type compiledTemplatePartOptionalSign struct{}
+func (compiledTemplatePartNumber) parse(ps *parseState) bool {
+	return ps.consumeNumber()
+}
 
-func (compiledTemplatePartOptionalSign) write(sb *strings.Builder, f *Formatter, neg bool, intPart, fracPart string) {
-	if neg {
-		sb.WriteByte('-')
+type compiledTemplatePartInteger struct{}
+
+func (compiledTemplatePartInteger) write(sb *strings.Builder, fs *formatState) {
+	fs.writeInteger(sb)
+}
+
+func (compiledTemplatePartInteger) parse(ps *parseState) bool {
+	ps.integerOnly = true
+	defer func() { ps.integerOnly = false }()
+	if !ps.consumeDigits() {
+		return ps.fail(ps.pos, ErrMissingDigits)
 	}
+	return true
 }
 
-type compiledTemplatePartForceSign struct{}
+type compiledTemplatePartFraction struct{}
 
-func (compiledTemplatePartForceSign) write(sb *strings.Builder, f *Formatter, neg bool, intPart, fracPart string) {
-	var sign byte
-	if neg {
-		sign = '-'
-	} else {
-		sign = '+'
+func (compiledTemplatePartFraction) write(sb *strings.Builder, fs *formatState) {
+	fs.writeFraction(sb)
+}
+
+func (compiledTemplatePartFraction) parse(ps *parseState) bool {
+	ps.consumeFractionDigits()
+	return true
+}
+
+// isNumberPart returns true if part writes digits of the number.
+func isNumberPart(part compiledTemplatePart) bool {
+	switch part.(type) {
+	case compiledTemplatePartNumber, compiledTemplatePartInteger, compiledTemplatePartFraction:
+		return true
 	}
-	sb.WriteByte(sign)
+	return false
 }
 
-func compileTemplate(s string) compiledTemplate {
-	sr := strings.NewReader(s)
+type compiledTemplatePartOptionalSign struct{}
 
-	ct := compiledTemplate{}
+func (compiledTemplatePartOptionalSign) write(sb *strings.Builder, fs *formatState) {
+	if fs.neg {
+		sb.WriteString(fs.f.minusSign())
+	}
+}
 
-	literal := &strings.Builder{}
-	escape := false
-	for {
-		b, err := sr.ReadByte()
-		if err != nil {
-			if literal.Len() > 0 {
-				ct = append(ct, compiledTemplatePartLiteral(literal.String()))
-			}
-			break
-		}
+func (compiledTemplatePartOptionalSign) parse(ps *parseState) bool {
+	ps.consumeSign()
+	return true
+}
 
-		if escape {
-			literal.WriteByte(b)
-			escape = false
-			continue
-		}
+type compiledTemplatePartForceSign struct{}
 
-		if b == '\\' {
-			escape = true
-			continue
-		}
+func (compiledTemplatePartForceSign) write(sb *strings.Builder, fs *formatState) {
+	switch {
+	case fs.neg:
+		sb.WriteString(fs.f.minusSign())
+	case !fs.zero:
+		sb.WriteString(fs.f.plusSign())
+	default:
+		sb.WriteString(fs.f.zeroSign())
+	}
+}
 
-		if b == 'n' || b == '-' || b == '+' {
-			if literal.Len() > 0 {
-				ct = append(ct, compiledTemplatePartLiteral(literal.String()))
-				literal.Reset()
-			}
+// zeroSign returns what the '+' verb writes for zero.
+func (f *Formatter) zeroSign() string {
+	switch f.ZeroSign {
+	case ZeroSignMinus:
+		return f.minusSign()
+	case ZeroSignPlusMinus:
+		return PlusMinusSign
+	case ZeroSignBlank:
+		return " "
+	case ZeroSignNone:
+		return ""
+	default:
+		return f.plusSign()
+	}
+}
 
-			switch b {
-			case 'n':
-				ct = append(ct, compiledTemplatePartNumber{})
-			case '-':
-				ct = append(ct, compiledTemplatePartOptionalSign{})
-			case '+':
-				ct = append(ct, compiledTemplatePartForceSign{})
-			}
-		} else {
-			literal.WriteByte(b)
-		}
+func (compiledTemplatePartForceSign) parse(ps *parseState) bool {
+	if ps.consumeSign() {
+		return true
 	}
 
-	return ct
-}
-
-// TemplateFunc is a helper method for use with text/template and html/template. args is a sequence of key-value pairs
-// configuring the formatting. If len(args) is even a formatting function is returned. If len(args) is odd the final
-// value is formatted and returned.
-//
-// Keys are generally named the same as matching the Formatter fields:
-//   GroupSeparator
-//   GroupSize
-//   DecimalSeparator
-//   RoundPlaces
-//   Shift
-//   MinDecimalPlaces
-//   Template
-//   NegativeTemplate
-func TemplateFunc(args ...interface{}) (interface{}, error) {
-	f := &Formatter{}
-	for i := 0; i < len(args)-1; i += 2 {
-		key := args[i]
-		strValue := fmt.Sprint(args[i+1])
-
-		switch key {
-		case "GroupSeparator":
-			f.GroupSeparator = strValue
-		case "GroupSize":
-			n, err := strconv.ParseInt(strValue, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			f.GroupSize = int(n)
-		case "DecimalSeparator":
-			f.DecimalSeparator = strValue
-		case "RoundPlaces":
-			n, err := strconv.ParseInt(strValue, 10, 32)
-			if err != nil {
-				return nil, err
-			}
-			if f.Rounder == nil {
-				f.Rounder = &Rounder{}
-			}
-			f.Rounder.Places = int32(n)
-		case "Shift":
-			n, err := strconv.ParseInt(strValue, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			f.Shift = int32(n)
-		case "MinDecimalPlaces":
-			n, err := strconv.ParseInt(strValue, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			f.MinDecimalPlaces = int32(n)
-		case "Template":
-			f.Template = strValue
-		case "NegativeTemplate":
-			f.NegativeTemplate = strValue
-		default:
-			return nil, fmt.Errorf("unknown key: %s", key)
+	switch ps.f.ZeroSign {
+	case ZeroSignBlank:
+		if strings.HasPrefix(ps.rest(), " ") {
+			ps.pos++
 		}
+		return true
+	case ZeroSignNone:
+		return true
 	}
 
-	if len(args)%2 == 1 {
-		return f.Format(args[len(args)-1]), nil
+	if ps.strict {
+		return ps.fail(ps.pos, ErrMissingSign)
+	}
+	return true
+}
+
+type compiledTemplatePartFill struct{}
+
+func (compiledTemplatePartFill) write(sb *strings.Builder, fs *formatState) {
+	fs.fillIndex = sb.Len()
+}
+
+func (compiledTemplatePartFill) parse(ps *parseState) bool {
+	ps.consumeFill()
+	return true
+}
+
+type compiledTemplatePartTrend struct{}
+
+func (compiledTemplatePartTrend) write(sb *strings.Builder, fs *formatState) {
+	switch {
+	case fs.zero:
+		sb.WriteString(fs.f.trendFlat())
+	case fs.neg:
+		sb.WriteString(fs.f.trendDown())
+	default:
+		sb.WriteString(fs.f.trendUp())
 	}
+}
 
-	return f.Format, nil
+func (compiledTemplatePartTrend) parse(ps *parseState) bool {
+	if !ps.consumeTrend() && ps.strict {
+		return ps.fail(ps.pos, ErrUnexpectedCharacter)
+	}
+	return true
 }
 
 // NewUSDFormatter returns a Formatter for US dollars.
@@ -364,6 +1730,94 @@ func NewUSDFormatter() *Formatter {
 	}
 }
 
+// NewFrenchFormatter returns a Formatter using French conventions such as 1 234,56 where the group separator is a
+// NarrowNoBreakSpace.
+func NewFrenchFormatter() *Formatter {
+	return &Formatter{
+		GroupSeparator:   NarrowNoBreakSpace,
+		DecimalSeparator: ",",
+	}
+}
+
+// NewEuropeanFormatter returns a Formatter using the common continental European conventions such as 1.234.567,89 as
+// in Germany, Italy, Spain, and the Netherlands. Use NewLocaleFormatter for full locale data such as currency
+// placement.
+func NewEuropeanFormatter() *Formatter {
+	return &Formatter{
+		GroupSeparator:   ".",
+		DecimalSeparator: ",",
+	}
+}
+
+// NewNordicFormatter returns a Formatter using Nordic and Eastern European conventions such as 1 234 567,89 where the
+// group separator is a NoBreakSpace.
+func NewNordicFormatter() *Formatter {
+	return &Formatter{
+		GroupSeparator:   NoBreakSpace,
+		DecimalSeparator: ",",
+	}
+}
+
+// NewSwissFormatter returns a Formatter using Swiss conventions such as 1'234'567.89 where the group separator is an
+// ASCII apostrophe as used in Swiss financial exports. The de-CH locale of NewLocaleFormatter uses the typographic
+// apostrophe ’ instead. Parse accepts either apostrophe. The apostrophe is safe in HTML text and double quoted attributes
+// but html/template escapes it as &#39; when the result of Format is used in a template.
+func NewSwissFormatter() *Formatter {
+	return &Formatter{
+		GroupSeparator: "'",
+	}
+}
+
+// NewSIFormatter returns a Formatter using the SI style such as 1 234.56 where the group separator is a
+// NarrowNoBreakSpace.
+func NewSIFormatter() *Formatter {
+	return &Formatter{
+		GroupSeparator: NarrowNoBreakSpace,
+	}
+}
+
+// NewPercentChangeFormatter returns a Formatter for percent changes that always includes the sign and displays places
+// decimal places. e.g. 0.042 => +4.2% and -0.018 => −1.8% with places of 1. The minus sign is MinusSign. Arrows or other
+// decorations can be added by changing Template and NegativeTemplate.
+func NewPercentChangeFormatter(places int32) *Formatter {
+	return &Formatter{
+		Shift:            2,
+		Rounder:          &Rounder{Places: places},
+		MinDecimalPlaces: places,
+		MinusSign:        MinusSign,
+		Template:         "+n%",
+	}
+}
+
+// NewFXFormatter returns a Formatter for currency pair exchange rates rounded to significantDigits significant
+// digits including the fractional pip. e.g. 1.092354 => 1.0924 and 151.2345 => 151.23 with 5. Trailing zeros are kept
+// so quotes line up. e.g. 1.1 => 1.1000. If pipSeparator is not empty it is written before the fractional pip. e.g.
+// 1.092354 => 1.0923 5 with 6 and " ".
+func NewFXFormatter(significantDigits int32, pipSeparator string) *Formatter {
+	return &Formatter{
+		Rounder:      &Rounder{SignificantDigits: significantDigits},
+		PipSeparator: pipSeparator,
+	}
+}
+
+// NewBasisPointsFormatter returns a Formatter that formats a fractional rate as whole basis points. e.g. 0.0025 => 25 bps.
+// Change Rounder to display fractional basis points and Template to change the suffix.
+func NewBasisPointsFormatter() *Formatter {
+	return &Formatter{
+		Shift:    4,
+		Rounder:  &Rounder{Places: 0},
+		Template: "-n bps",
+	}
+}
+
+// NewPerMilleFormatter returns a Formatter that formats a number such as 0.0125 to 12.5‰.
+func NewPerMilleFormatter() *Formatter {
+	return &Formatter{
+		Shift:    3,
+		Template: "-n‰",
+	}
+}
+
 // NewPercentFormatter returns a formatter that formats a number such as 0.75 to 75%.
 func NewPercentFormatter() *Formatter {
 	return &Formatter{
@@ -1,7 +1,10 @@
 package numfmt_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"os"
 	"strings"
 	"testing"
@@ -10,10 +13,45 @@ import (
 	"github.com/jackc/numfmt"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
 )
 
 type testFormatter numfmt.Formatter
 
+func intPtr(n int) *int             { return &n }
+func float64Ptr(n float64) *float64 { return &n }
+func stringPtr(s string) *string    { return &s }
+
+type stringerNumber struct{ cents int64 }
+
+func (n stringerNumber) String() string {
+	return fmt.Sprintf("%d.%02d", n.cents/100, n.cents%100)
+}
+
+type textMarshalerNumber struct{ s string }
+
+func (n textMarshalerNumber) MarshalText() ([]byte, error) {
+	if n.s == "" {
+		return nil, errors.New("empty")
+	}
+	return []byte(n.s), nil
+}
+
+func (n textMarshalerNumber) String() string {
+	return "stringer"
+}
+
+type (
+	cents         int64
+	ratio         float64
+	count         uint8
+	namedStringer int
+)
+
+func (n namedStringer) String() string {
+	return "not a number"
+}
+
 func (f *testFormatter) String() string {
 	parts := []string{}
 	if f.GroupSeparator != "" {
@@ -61,12 +99,46 @@ func TestFormatterFormat(t *testing.T) {
 		{&numfmt.Formatter{DecimalSeparator: ","}, "1.2", "1,2"},
 		{&numfmt.Formatter{GroupSeparator: " "}, "1234", "1 234"},
 		{&numfmt.Formatter{GroupSize: 1}, "1234", "1,2,3,4"},
+		{&numfmt.Formatter{GroupSeparator: numfmt.NoBreakSpace}, "1234567", "1\u00a0234\u00a0567"},
+		{&numfmt.Formatter{GroupSeparator: numfmt.NarrowNoBreakSpace, DecimalSeparator: "\u066b"}, "1234.5", "1\u202f234\u066b5"},
+		{numfmt.NewFrenchFormatter(), "-1234567.89", "-1\u202f234\u202f567,89"},
+		{numfmt.NewSIFormatter(), "1234567.89", "1\u202f234\u202f567.89"},
+
+		{&numfmt.Formatter{Digits: numfmt.ArabicIndicDigits, GroupSeparator: "٬", DecimalSeparator: "٫"}, "-1234567.89", "-١٬٢٣٤٬٥٦٧٫٨٩"},
+		{&numfmt.Formatter{Digits: numfmt.ExtendedArabicIndicDigits}, "1234.5", "۱,۲۳۴.۵"},
+		{&numfmt.Formatter{Digits: numfmt.DevanagariDigits, MinDecimalPlaces: 2}, "1234", "१,२३४.००"},
+		{&numfmt.Formatter{Digits: "0123"}, "1234", "1,234"},
+
+		{&numfmt.Formatter{BidiIsolation: numfmt.BidiFirstStrongIsolate}, "-1234", "\u2068-1,234\u2069"},
+		{&numfmt.Formatter{BidiIsolation: numfmt.BidiLeftToRightIsolate, Template: "-$n"}, "-1234", "\u2066-$1,234\u2069"},
+		{&numfmt.Formatter{BidiIsolation: numfmt.BidiLeftToRightMark}, "-1234", "\u200e-1,234\u200e"},
 
 		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}}, "1234.1", "1,234"},
 		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}}, "1234.5", "1,235"},
 		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}}, "1234.9", "1,235"},
 		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 3}}, "1234.5678", "1,234.568"},
 		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: -2}}, "1234.5678", "1,200"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Rules: numfmt.DefaultPrecisionRules}}, "1234.5678", "1,235"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Rules: numfmt.DefaultPrecisionRules}}, "-100", "-100"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Rules: numfmt.DefaultPrecisionRules}}, "12.345", "12.3"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Rules: numfmt.DefaultPrecisionRules}}, "-1", "-1"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Rules: numfmt.DefaultPrecisionRules}}, "0.012345", "0.012"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Rules: []numfmt.PrecisionRule{{Min: decimal.New(1, 3), Places: -2}}, Places: 2}}, "12345.678", "12,300"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Rules: []numfmt.PrecisionRule{{Min: decimal.New(1, 3), Places: -2}}, Places: 2}}, "12.345", "12.35"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}}, "1.2", "1.20"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}}, "1.23456", "1.23"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}}, "-0.0012", "-0.00120"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}}, "1234", "1,230"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}}, "9.996", "10.0"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}}, 2, "2.00"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}}, 0, "0"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}, MinDecimalPlaces: 4}, "1.2", "1.2000"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3, MinSignificantDigits: 2}}, "1.2", "1.2"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3, MinSignificantDigits: 2}}, 1, "1.0"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3, MinSignificantDigits: 1}}, "1.23456", "1.23"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3, MinSignificantDigits: 1}}, "1.50", "1.5"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}, Humanizer: numfmt.CompactHumanizer}, 1200, "1.20K"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}, Scientific: &numfmt.Scientific{Above: decimal.New(1, 5)}}, 120000, "1.20e5"},
 
 		{&numfmt.Formatter{Shift: 2}, "0.31", "31"},
 		{&numfmt.Formatter{Shift: -1}, "42", "4.2"},
@@ -76,17 +148,139 @@ func TestFormatterFormat(t *testing.T) {
 
 		{&numfmt.Formatter{MinDecimalPlaces: 2}, "123", "123.00"},
 
+		{&numfmt.Formatter{AlwaysShowDecimalSeparator: true}, "120", "120."},
+		{&numfmt.Formatter{AlwaysShowDecimalSeparator: true}, "120.5", "120.5"},
+		{&numfmt.Formatter{AlwaysShowDecimalSeparator: true, MinDecimalPlaces: 2}, "120", "120.00"},
+		{&numfmt.Formatter{AlwaysShowDecimalSeparator: true, DecimalSeparator: ",", Template: "-n €"}, "-120", "-120, €"},
+
+		// Preserve scale
+		{&numfmt.Formatter{PreserveScale: true}, "1.50", "1.50"},
+		{&numfmt.Formatter{PreserveScale: true}, "1.5", "1.5"},
+		{&numfmt.Formatter{PreserveScale: true}, "100", "100"},
+		{&numfmt.Formatter{PreserveScale: true}, "-1.000", "-1.000"},
+		{&numfmt.Formatter{PreserveScale: true}, decimal.New(150, -2), "1.50"},
+		{&numfmt.Formatter{PreserveScale: true, MinDecimalPlaces: 3}, "1.50", "1.500"},
+		{&numfmt.Formatter{PreserveScale: true, Rounder: &numfmt.Rounder{Places: 2}}, "1.5", "1.5"},
+		{&numfmt.Formatter{PreserveScale: true, Rounder: &numfmt.Rounder{Places: 2}}, "1.5000", "1.50"},
+		{&numfmt.Formatter{PreserveScale: true, Shift: 2}, "0.1250", "12.50"},
+		{&numfmt.Formatter{}, "1.50", "1.5"},
+
+		// Scientific notation
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Below: decimal.New(1, -6)}}, "0.0000000042", "4.2e-9"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Below: decimal.New(1, -6)}}, "0.000042", "0.000042"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Below: decimal.New(1, -6)}}, "0", "0"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 15)}}, "9300000000000000000", "9.3e18"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 15)}}, "-9300000000000000000", "-9.3e18"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 15)}}, "12345", "12,345"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 3)}, Rounder: &numfmt.Rounder{Places: 2}}, "12345", "1.23e4"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 3)}, Rounder: &numfmt.Rounder{Places: 1}}, "99960", "1e5"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 3)}, MinDecimalPlaces: 2, DecimalSeparator: ","}, "12000", "1,20e4"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 3)}, Template: "-n m"}, "-5000", "-5e3 m"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 3), Style: numfmt.ExponentSuperscript}}, "12300", "1.23 × 10⁴"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Below: decimal.New(1, -6), Style: numfmt.ExponentSuperscript}}, "0.000000000042", "4.2 × 10⁻¹¹"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 3), Style: numfmt.ExponentCaret}}, "12300", "1.23 x 10^4"},
+		{&numfmt.Formatter{PreserveExponent: true}, "1.2e5", "1.2e5"},
+		{&numfmt.Formatter{PreserveExponent: true}, "3E-7", "3e-7"},
+		{&numfmt.Formatter{PreserveExponent: true}, "-12e5", "-1.2e6"},
+		{&numfmt.Formatter{PreserveExponent: true}, "0e5", "0"},
+		{&numfmt.Formatter{PreserveExponent: true}, "120000", "120,000"},
+		{&numfmt.Formatter{PreserveExponent: true, Rounder: &numfmt.Rounder{Places: 1}}, "1.26e-9", "1.3e-9"},
+		{&numfmt.Formatter{PreserveExponent: true, Scientific: &numfmt.Scientific{UppercaseE: true}}, "1.5e-9", "1.5E-9"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 3), UppercaseE: true, ForceExponentSign: true, MinExponentDigits: 2}}, "150000", "1.5E+05"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Below: decimal.New(1, -3), MinExponentDigits: 3}}, "0.000015", "1.5e-005"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 3), MinExponentDigits: 2}}, "1.5e123", "1.5e123"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 3), Style: numfmt.ExponentSuperscript, ForceExponentSign: true}}, "12300", "1.23 × 10⁺⁴"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Below: decimal.New(1, -3), Style: numfmt.ExponentCaret}}, "-0.0000042", "-4.2 x 10^-6"},
+
+		// Width and alignment
+		{&numfmt.Formatter{MinWidth: 8}, "1234", "   1,234"},
+		{&numfmt.Formatter{MinWidth: 8, Alignment: numfmt.AlignLeft}, "1234", "1,234   "},
+		{&numfmt.Formatter{MinWidth: 3}, "1234", "1,234"},
+		{&numfmt.Formatter{MinWidth: 10, Alignment: numfmt.AlignDecimal, MinDecimalPlaces: 1, Rounder: &numfmt.Rounder{Places: 3}}, "1234", " 1,234.0  "},
+		{&numfmt.Formatter{MinWidth: 10, Alignment: numfmt.AlignDecimal, Rounder: &numfmt.Rounder{Places: 2}}, "1234", "  1,234   "},
+		{&numfmt.Formatter{MinWidth: 10, Alignment: numfmt.AlignDecimal, Rounder: &numfmt.Rounder{Places: 2}}, "1234.5", "  1,234.5 "},
+		{&numfmt.Formatter{MinWidth: 10, Alignment: numfmt.AlignDecimal, Rounder: &numfmt.Rounder{Places: 2}}, "-1.25", "     -1.25"},
+		{&numfmt.Formatter{MinWidth: 6, Alignment: numfmt.AlignDecimal}, "12", "    12"},
+		{&numfmt.Formatter{MinWidth: 6, GroupSeparator: numfmt.NarrowNoBreakSpace}, "1234", " 1\u202f234"},
+		{&numfmt.Formatter{MinWidth: 11, Fill: "*"}, "1234.56", "***1,234.56"},
+		{&numfmt.Formatter{MinWidth: 12, Fill: "*", Template: "${fill}n"}, "1234.56", "$***1,234.56"},
+		{&numfmt.Formatter{MinWidth: 12, Fill: "*", Template: "-${fill}n"}, "-1234.56", "-$**1,234.56"},
+		{&numfmt.Formatter{Fill: "*", Template: "${fill}n"}, "1234.56", "$1,234.56"},
+		{&numfmt.Formatter{MinWidth: 12, Fill: ".", Template: "-n * 2"}, "12", "......12 * 2"},
+		{&numfmt.Formatter{MinWidth: 6, Fill: "0", Template: "-{fill}n"}, "-12", "-00012"},
+		{&numfmt.Formatter{MinWidth: 8, Fill: "·", Alignment: numfmt.AlignLeft}, "12", "12······"},
+
 		// Template
 		{&numfmt.Formatter{Template: "+n"}, "123", "+123"},
 		{&numfmt.Formatter{Template: "-n"}, "123", "123"},
 		{&numfmt.Formatter{Template: "-n"}, "-123", "-123"},
 		{&numfmt.Formatter{Template: "n -"}, "-123", "123 -"},
+		{&numfmt.Formatter{Template: "n-"}, "123", "123"},
+		{&numfmt.Formatter{Template: "n-", GroupSeparator: ".", DecimalSeparator: ","}, "-1234.56", "1.234,56-"},
 		{&numfmt.Formatter{Template: `\n \- \+ \\ n`}, "123", `n - + \ 123`},
+		{&numfmt.Formatter{Template: "{trend} +n"}, "123", "▲ +123"},
+		{&numfmt.Formatter{Template: "{trend} +n"}, "-123", "▼ -123"},
+		{&numfmt.Formatter{Template: "{trend} +n"}, "0", "– +0"},
+		{&numfmt.Formatter{Template: "{trend} n", Rounder: &numfmt.Rounder{Places: 1}}, "0.04", "– 0"},
+		{&numfmt.Formatter{Template: "n{trend}", TrendUp: "↑", TrendDown: "↓", TrendFlat: "→"}, "-5", "5↓"},
+		{&numfmt.Formatter{Template: "-n ^2"}, "-3", "-3 ^2"},
+		{&numfmt.Formatter{Template: "-${int}<sup>{frac}</sup>", MinDecimalPlaces: 2}, "-1234.5", "-$1,234<sup>50</sup>"},
+		{&numfmt.Formatter{Template: `{int} u\nits {frac} hu\ndredths`, Rounder: &numfmt.Rounder{Places: 2}}, "12.345", "12 units 35 hundredths"},
+		{&numfmt.Formatter{Template: "{int}.{frac}"}, "12", "12."},
+		{&numfmt.Formatter{Template: "{int}", GroupSeparator: " "}, "1234.5", "1 234"},
+		{&numfmt.Formatter{Template: `n\u00a0\u20ac`}, "12", "12\u00a0€"},
+		{&numfmt.Formatter{Template: `\x24n\xa0`}, "12", "$12\u00a0"},
+		{&numfmt.Formatter{Template: `n \U0001F4B0`}, "12", "12 💰"},
+		{&numfmt.Formatter{Template: `\u\x\U`}, "12", "uxU"},
+		{&numfmt.Formatter{Template: `\u12n`}, "12", "u1212"},
+		{&numfmt.Formatter{Template: `\uzzzzn`}, "12", "uzzzz12"},
+		{&numfmt.Formatter{Template: `\UFFFFFFFFn`}, "12", "UFFFFFFFF12"},
+
+		{&numfmt.Formatter{MinusSign: numfmt.MinusSign}, "-123", "\u2212123"},
+		{&numfmt.Formatter{Template: "+n", MinusSign: "\u2212", PlusSign: "\u207a"}, "123", "\u207a123"},
+		{&numfmt.Formatter{Template: "+n", MinusSign: "\u2212", PlusSign: "\u207a"}, "-123", "\u2212123"},
+
+		// ZeroSign
+		{&numfmt.Formatter{Template: "+n", ZeroSign: numfmt.ZeroSignMinus}, "0", "-0"},
+		{&numfmt.Formatter{Template: "+n", ZeroSign: numfmt.ZeroSignPlusMinus}, "0", "±0"},
+		{&numfmt.Formatter{Template: "+n", ZeroSign: numfmt.ZeroSignBlank}, "0", " 0"},
+		{&numfmt.Formatter{Template: "+n", ZeroSign: numfmt.ZeroSignNone}, "0", "0"},
+		{&numfmt.Formatter{Template: "+n", ZeroSign: numfmt.ZeroSignNone}, "5", "+5"},
+		{&numfmt.Formatter{Template: "+n", ZeroSign: numfmt.ZeroSignNone, Rounder: &numfmt.Rounder{Places: 1}}, "-0.01", "0"},
+		{&numfmt.Formatter{Template: "-n", ZeroSign: numfmt.ZeroSignPlusMinus}, "0", "0"},
 
 		// Negative Template
 		{&numfmt.Formatter{NegativeTemplate: "(n)"}, "123", "123"},
 		{&numfmt.Formatter{NegativeTemplate: "(n)"}, "-123", "(123)"},
 
+		// NegativeStyle
+		{&numfmt.Formatter{NegativeStyle: numfmt.NegativeParentheses}, "-1234", "(1,234)"},
+		{&numfmt.Formatter{NegativeStyle: numfmt.NegativeParentheses}, "1234", "1,234"},
+		{&numfmt.Formatter{Template: "$-n USD", NegativeStyle: numfmt.NegativeParentheses}, "-5", "($5 USD)"},
+		{&numfmt.Formatter{Template: "+n", NegativeStyle: numfmt.NegativeParentheses}, "-5", "(5)"},
+		{&numfmt.Formatter{CurrencySymbol: "$", NegativeStyle: numfmt.NegativeParentheses}, "-5", "($5)"},
+		{&numfmt.Formatter{NegativeTemplate: "n-", NegativeStyle: numfmt.NegativeParentheses}, "-5", "5-"},
+		{&numfmt.Formatter{NegativeStyle: numfmt.NegativeDebitCredit}, "-1234.5", "1,234.5 DR"},
+		{&numfmt.Formatter{NegativeStyle: numfmt.NegativeDebitCredit}, "1234.5", "1,234.5 CR"},
+		{&numfmt.Formatter{NegativeStyle: numfmt.NegativeDebitCredit}, "0", "0 CR"},
+		{&numfmt.Formatter{Currency: "USD", NegativeStyle: numfmt.NegativeDebitCredit}, "-5", "$5.00 DR"},
+		{
+			&numfmt.Formatter{NegativeStyle: numfmt.NegativeDebitCredit, DebitLabel: "Dr", CreditLabel: "Cr"},
+			"-5",
+			"5 Dr",
+		},
+		{&numfmt.Formatter{NegativeTemplate: "(n)", NegativeStyle: numfmt.NegativeDebitCredit}, "-5", "(5)"},
+
+		// PositivePlaceholder
+		{&numfmt.Formatter{NegativeTemplate: "(n)", PositivePlaceholder: " "}, "123", "123 "},
+		{&numfmt.Formatter{NegativeTemplate: "(n)", PositivePlaceholder: " "}, "0", "0 "},
+		{&numfmt.Formatter{NegativeTemplate: "(n)", PositivePlaceholder: " "}, "-123", "(123)"},
+		{&numfmt.Formatter{NegativeTemplate: "(n) CR", PositivePlaceholder: "_"}, "123", "123____"},
+		{&numfmt.Formatter{Template: "n-", PositivePlaceholder: "\u2008"}, "123", "123\u2008"},
+		{&numfmt.Formatter{Template: "n+", PositivePlaceholder: " "}, "123", "123+"},
+		{&numfmt.Formatter{PositivePlaceholder: " "}, "123", "123"},
+		{&numfmt.Formatter{CurrencySymbol: "$", CurrencySign: numfmt.CurrencySignParentheses, PositivePlaceholder: " ", MinWidth: 8}, "5", "     $5 "},
+
 		// Different argument type tests
 		{&numfmt.Formatter{}, 1234, "1,234"},
 		{&numfmt.Formatter{}, 1234.0, "1,234"},
@@ -95,9 +289,41 @@ func TestFormatterFormat(t *testing.T) {
 		{&numfmt.Formatter{}, float32(1234.5), "1,234.5"},
 		{&numfmt.Formatter{}, float64(1234.5), "1,234.5"},
 		{&numfmt.Formatter{}, decimal.RequireFromString("1234"), "1,234"},
+		{&numfmt.Formatter{}, stringerNumber{cents: 123456}, "1,234.56"},
+		{&numfmt.Formatter{}, textMarshalerNumber{s: "1234.5"}, "1,234.5"},
+		{&numfmt.Formatter{}, textMarshalerNumber{}, "stringer"},
+		{&numfmt.Formatter{}, big.NewFloat(1234567890.5), "1,234,567,890.5"},
+		{&numfmt.Formatter{}, big.NewInt(12345678901), "12,345,678,901"},
+
+		// Pointers
+		{&numfmt.Formatter{}, intPtr(1234), "1,234"},
+		{&numfmt.Formatter{}, float64Ptr(1234.5), "1,234.5"},
+		{&numfmt.Formatter{}, &[]decimal.Decimal{decimal.New(12345, -2)}[0], "123.45"},
+		{&numfmt.Formatter{}, stringPtr("1234"), "1,234"},
+		{&numfmt.Formatter{}, (*int)(nil), ""},
+		{&numfmt.Formatter{Nil: "—"}, (*float64)(nil), "—"},
+		{&numfmt.Formatter{Nil: "—"}, (*decimal.Decimal)(nil), "—"},
+		{&numfmt.Formatter{Nil: "—"}, (*big.Rat)(nil), "—"},
+		{&numfmt.Formatter{Nil: "n/a"}, nil, "n/a"},
+		{&numfmt.Formatter{Invalid: "n/a"}, "abc", "n/a"},
+		{&numfmt.Formatter{Invalid: "n/a"}, math.NaN(), "n/a"},
+		{&numfmt.Formatter{Invalid: "n/a"}, "1,234", "1,234"},
+		{&numfmt.Formatter{Invalid: "n/a", Nil: "—"}, nil, "—"},
+		{&numfmt.Formatter{}, "abc", "abc"},
+		{&numfmt.Formatter{Template: "$n"}, &stringerNumber{cents: 995}, "$9.95"},
+
+		// Strings with separators
+		{&numfmt.Formatter{}, "1,234.56", "1,234.56"},
+		{&numfmt.Formatter{}, " -1,234,567 ", "-1,234,567"},
+		{&numfmt.Formatter{GroupSeparator: ".", DecimalSeparator: ","}, "1.234,56", "1.234,56"},
+		{&numfmt.Formatter{GroupSeparator: ".", DecimalSeparator: ","}, "1,5", "1,5"},
+		{&numfmt.Formatter{Shift: 2, Template: "n%"}, "1,234.5", "123,450%"},
+		{&numfmt.Formatter{Digits: numfmt.ArabicIndicDigits}, "١,٢٣٤", "١,٢٣٤"},
 
 		// Not a number
 		{&numfmt.Formatter{}, "foobar", "foobar"},
+		{&numfmt.Formatter{}, "12,34", "12,34"},
+		{&numfmt.Formatter{GroupSeparator: ".", DecimalSeparator: ","}, "1,234.56", "1,234.56"},
 	} {
 		actual := tt.formatter.Format(tt.arg)
 		if tt.expected != actual {
@@ -106,6 +332,389 @@ func TestFormatterFormat(t *testing.T) {
 	}
 }
 
+func TestFormatterScale(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Scale: decimal.RequireFromString("2.54")}, "10", "25.4"},
+		{&numfmt.Formatter{Scale: decimal.RequireFromString("0.453592"), Rounder: &numfmt.Rounder{Places: 2}}, "150", "68.04"},
+		{&numfmt.Formatter{Scale: decimal.RequireFromString("1.1"), Shift: 2, Template: "n%"}, "0.5", "55%"},
+		{&numfmt.Formatter{Scale: decimal.RequireFromString("-1")}, "5", "-5"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := &numfmt.Formatter{Scale: decimal.RequireFromString("2.54")}
+	d, err := f.Parse("25.4")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "10", d.String())
+	}
+}
+
+func TestFormatterMagnitudeRules(t *testing.T) {
+	f := &numfmt.Formatter{
+		Rounder: &numfmt.Rounder{Places: 2},
+		MagnitudeRules: []numfmt.MagnitudeRule{
+			{Min: decimal.New(1, 6), Formatter: numfmt.NewCompactFormatter()},
+			{Min: decimal.New(1, 0), Formatter: &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}}},
+		},
+	}
+
+	for i, tt := range []struct {
+		arg      interface{}
+		expected string
+	}{
+		{"1234567", "1.2M"},
+		{"-2500000", "-2.5M"},
+		{"999999", "999,999"},
+		{"12.345", "12"},
+		{"1", "1"},
+		{"0.12345", "0.12"},
+		{"-0.5", "-0.5"},
+	} {
+		actual := f.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterHooks(t *testing.T) {
+	f := &numfmt.Formatter{
+		PreFormat: func(d decimal.Decimal) decimal.Decimal {
+			if d.IsNegative() {
+				return decimal.Zero
+			}
+			return d
+		},
+		PostFormat: func(s string) string { return "<b>" + s + "</b>" },
+	}
+	assert.Equal(t, "<b>1,234</b>", f.Format(1234))
+	assert.Equal(t, "<b>0</b>", f.Format(-1234))
+	assert.Equal(t, "abc", f.Format("abc"))
+
+	f = &numfmt.Formatter{
+		PostFormat: func(s string) string { return "[" + s + "]" },
+		MagnitudeRules: []numfmt.MagnitudeRule{
+			{Min: decimal.New(1, 3), Formatter: &numfmt.Formatter{PostFormat: func(s string) string { return s + "!" }}},
+		},
+	}
+	assert.Equal(t, "[12]", f.Format(12))
+	assert.Equal(t, "[1,234!]", f.Format(1234))
+}
+
+func TestFormatterRoundBeforeShift(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}, Shift: 2}, "0.12345", "12.35"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}, Shift: 2, RoundBeforeShift: true}, "0.12345", "12"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}, Shift: 2, RoundBeforeShift: true}, "0.125", "13"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}, Shift: -3, RoundBeforeShift: true}, "1234.5678", "1.23457"},
+		{&numfmt.Formatter{RoundBeforeShift: true, Shift: 2}, "0.12345", "12.345"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterMarkApproximate(t *testing.T) {
+	round0 := &numfmt.Rounder{Places: 0}
+	round2 := &numfmt.Rounder{Places: 2}
+	sci := &numfmt.Scientific{Above: decimal.New(1, 5)}
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{MarkApproximate: true, Rounder: round0}, "1234.5", "≈1,235"},
+		{&numfmt.Formatter{MarkApproximate: true, Rounder: round0}, "1234", "1,234"},
+		{&numfmt.Formatter{MarkApproximate: true, Rounder: round0}, "1234.0", "1,234"},
+		{&numfmt.Formatter{MarkApproximate: true, Rounder: round0}, "-1234.5", "≈-1,235"},
+		{&numfmt.Formatter{MarkApproximate: true}, "1234.5", "1,234.5"},
+		{&numfmt.Formatter{Rounder: round0}, "1234.5", "1,235"},
+		{&numfmt.Formatter{MarkApproximate: true, Rounder: round0, ApproximateSign: "~ "}, "1.5", "~ 2"},
+		{&numfmt.Formatter{MarkApproximate: true, Rounder: round0, ApproximateSign: "*", ApproximateAfter: true}, "1.5", "2*"},
+		{&numfmt.Formatter{MarkApproximate: true, Rounder: round2, RoundBeforeShift: true, Shift: 2}, "0.125", "≈13"},
+		{&numfmt.Formatter{MarkApproximate: true, Rounder: round2, RoundBeforeShift: true, Shift: 2}, "0.12", "12"},
+		{&numfmt.Formatter{MarkApproximate: true, Humanizer: numfmt.CompactHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, 1500, "1.5K"},
+		{&numfmt.Formatter{MarkApproximate: true, Humanizer: numfmt.CompactHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, 1234, "≈1.2K"},
+		{&numfmt.Formatter{MarkApproximate: true, Humanizer: numfmt.CompactHumanizer, Rounder: &numfmt.Rounder{Places: 1}}, 999950, "≈1M"},
+		{&numfmt.Formatter{MarkApproximate: true, Scientific: sci, Rounder: round2}, "123400", "≈1.23e5"},
+		{&numfmt.Formatter{MarkApproximate: true, Scientific: sci, Rounder: round2}, "120000", "1.2e5"},
+		{&numfmt.Formatter{MarkApproximate: true, Currency: "JPY"}, "1234.5", "≈¥1,235"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := &numfmt.Formatter{MarkApproximate: true, Rounder: round0}
+	d, err := f.Parse("≈1,235")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1235", d.String())
+	}
+}
+
+func TestFormatterMaxIntegerDigits(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{MaxIntegerDigits: 4}, 9999, "9,999"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4}, 12345, "9,999+"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4}, -12345, "-9,999+"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4, Rounder: &numfmt.Rounder{Places: 0}}, "9999.5", "9,999+"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4, Rounder: &numfmt.Rounder{Places: 0}}, "9999.4", "9,999"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4, DisplayMaxSuffix: "▸"}, 12345, "9,999▸"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4, IntegerOverflow: numfmt.OverflowScientific}, 12345, "1.2345e4"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4, IntegerOverflow: numfmt.OverflowCompact}, 123456, "123.456K"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4, IntegerOverflow: numfmt.OverflowError}, 12345, "####"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4, IntegerOverflow: numfmt.OverflowError, MinWidth: 6}, -12345, "  ####"},
+		{&numfmt.Formatter{MaxIntegerDigits: 4, IntegerOverflow: numfmt.OverflowError}, "1234.5", "1,234.5"},
+		{&numfmt.Formatter{MaxIntegerDigits: 2, Humanizer: numfmt.CompactHumanizer}, 123456, "123.456K"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := &numfmt.Formatter{MaxIntegerDigits: 4, IntegerOverflow: numfmt.OverflowError}
+	_, err := f.Quantize(12345)
+	assert.True(t, errors.Is(err, numfmt.ErrIntegerOverflow))
+	assert.True(t, f.FormatResult(12345).Overflow)
+
+	f = &numfmt.Formatter{MaxIntegerDigits: 4}
+	d, err := f.Quantize(-12345)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-9999", d.String())
+	}
+}
+
+func TestFormatterDisplayMax(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{DisplayMax: decimal.New(999, 0)}, 5, "5"},
+		{&numfmt.Formatter{DisplayMax: decimal.New(999, 0)}, 999, "999"},
+		{&numfmt.Formatter{DisplayMax: decimal.New(999, 0)}, 1000, "999+"},
+		{&numfmt.Formatter{DisplayMax: decimal.New(999, 0)}, "999.5", "999+"},
+		{&numfmt.Formatter{DisplayMax: decimal.New(999, 0)}, -1000, "-999+"},
+		{&numfmt.Formatter{DisplayMax: decimal.New(9999, 0)}, 123456, "9,999+"},
+		{&numfmt.Formatter{DisplayMax: decimal.New(99, 0), DisplayMaxSuffix: " or more"}, 120, "99 or more"},
+		{&numfmt.Formatter{DisplayMax: decimal.New(99, 0), Shift: 2, Template: "n%"}, "1.5", "99+%"},
+		{&numfmt.Formatter{DisplayMax: decimal.New(1, 6), Humanizer: numfmt.CompactHumanizer}, 5000000, "1M+"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterParse(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       string
+		expected  string
+	}{
+		{&numfmt.Formatter{}, "0", "0"},
+		{&numfmt.Formatter{}, "1,234", "1234"},
+		{&numfmt.Formatter{}, "-12,345.6789", "-12345.6789"},
+		{&numfmt.Formatter{}, " 1,234 ", "1234"},
+		{&numfmt.Formatter{DecimalSeparator: ",", GroupSeparator: "."}, "1.234,5", "1234.5"},
+		{&numfmt.Formatter{GroupSeparator: " "}, "1 234 567", "1234567"},
+		{&numfmt.Formatter{Shift: 2}, "31", "0.31"},
+
+		// Multi-byte separators
+		{numfmt.NewFrenchFormatter(), "-1\u202f234\u202f567,89", "-1234567.89"},
+		{numfmt.NewFrenchFormatter(), "1 234 567,89", "1234567.89"},
+		{numfmt.NewFrenchFormatter(), "1\u00a0234,89", "1234.89"},
+		{&numfmt.Formatter{GroupSeparator: numfmt.ThinSpace, DecimalSeparator: "\u066b"}, "1\u2009234\u066b5", "1234.5"},
+
+		// Digits
+		{&numfmt.Formatter{Digits: numfmt.ArabicIndicDigits, GroupSeparator: "٬", DecimalSeparator: "٫"}, "-١٬٢٣٤٬٥٦٧٫٨٩", "-1234567.89"},
+		{&numfmt.Formatter{Digits: numfmt.DevanagariDigits}, "१,२३४", "1234"},
+		{&numfmt.Formatter{Digits: numfmt.DevanagariDigits}, "1,234", "1234"},
+
+		// Bidi isolation
+		{&numfmt.Formatter{BidiIsolation: numfmt.BidiFirstStrongIsolate}, "\u2068-1,234\u2069", "-1234"},
+		{&numfmt.Formatter{}, "\u200e-1,234\u200e", "-1234"},
+
+		// Trailing minus
+		{&numfmt.Formatter{Template: "n-", GroupSeparator: ".", DecimalSeparator: ","}, "1.234,56-", "-1234.56"},
+		{&numfmt.Formatter{Template: "n-", GroupSeparator: ".", DecimalSeparator: ","}, "1.234,56", "1234.56"},
+		{&numfmt.Formatter{Template: "n -"}, "123 -", "-123"},
+
+		// Literals
+		{numfmt.NewUSDFormatter(), "-$1,234.50", "-1234.5"},
+		{numfmt.NewUSDFormatter(), "1,234.50", "1234.5"},
+		{numfmt.NewPercentFormatter(), "75%", "0.75"},
+		{numfmt.NewPercentFormatter(), "75 %", "0.75"},
+		{&numfmt.Formatter{MinWidth: 12, Fill: "*", Template: "${fill}n"}, "$***1,234.56", "1234.56"},
+		{&numfmt.Formatter{MinWidth: 11, Fill: "*"}, "***1,234.56", "1234.56"},
+		{&numfmt.Formatter{MinWidth: 6, Fill: "0"}, "000100", "100"},
+		{&numfmt.Formatter{MinWidth: 6, Fill: "0"}, "000000", "0"},
+		{&numfmt.Formatter{MinWidth: 6, Fill: "0", Template: "-{fill}n"}, "-00000", "0"},
+		{&numfmt.Formatter{MinWidth: 6, Fill: "ab"}, "ab1234", "1234"},
+		{&numfmt.Formatter{MinWidth: 6, Fill: "ab", Alignment: numfmt.AlignLeft}, "12ab", "12"},
+		{&numfmt.Formatter{}, "4.2e-9", "0.0000000042"},
+		{&numfmt.Formatter{AlwaysShowDecimalSeparator: true}, "120.", "120"},
+		{&numfmt.Formatter{AlwaysShowDecimalSeparator: true, DecimalSeparator: ",", GroupSeparator: ".", Template: "-n €"}, "-1.234, €", "-1234"},
+		{&numfmt.Formatter{}, "-1.5E+3", "-1500"},
+		{&numfmt.Formatter{Template: "n euros"}, "1e3 euros", "1000"},
+		{&numfmt.Formatter{MinWidth: 6, Fill: "*", Alignment: numfmt.AlignLeft}, "100***", "100"},
+
+		// Sign
+		{&numfmt.Formatter{}, "\u22121,234", "-1234"},
+		{&numfmt.Formatter{MinusSign: "~"}, "~1,234", "-1234"},
+		{&numfmt.Formatter{Template: "+n", PlusSign: "\u207a"}, "\u207a1,234", "1234"},
+
+		// Trend
+		{&numfmt.Formatter{Template: "{trend} n"}, "▼ 1,234", "-1234"},
+		{&numfmt.Formatter{Template: "{trend} n"}, "▲ 1,234", "1234"},
+		{&numfmt.Formatter{Template: "{trend} -n"}, "▼ -1,234", "-1234"},
+
+		// Negative Template
+		{&numfmt.Formatter{NegativeTemplate: "(n)"}, "(1,234)", "-1234"},
+		{&numfmt.Formatter{NegativeTemplate: "(n)"}, "1,234", "1234"},
+		{&numfmt.Formatter{NegativeTemplate: "(n)"}, "-1,234", "-1234"},
+		{&numfmt.Formatter{Template: "$-n", NegativeStyle: numfmt.NegativeParentheses}, "($1,234)", "-1234"},
+		{&numfmt.Formatter{NegativeStyle: numfmt.NegativeDebitCredit}, "1,234.5 DR", "-1234.5"},
+		{&numfmt.Formatter{NegativeStyle: numfmt.NegativeDebitCredit}, "1,234.5 CR", "1234.5"},
+		{&numfmt.Formatter{NegativeStyle: numfmt.NegativeDebitCredit}, "1,234.5", "1234.5"},
+		{&numfmt.Formatter{NegativeStyle: numfmt.NegativeDebitCredit, DebitLabel: "D"}, "12 D", "-12"},
+	} {
+		actual, err := tt.formatter.Parse(tt.arg)
+		if assert.NoErrorf(t, err, "%d", i) {
+			if tt.expected != actual.String() {
+				t.Errorf("%d. expected parsing %v with %v to return %v, but got %v", i, tt.arg, (*testFormatter)(tt.formatter), tt.expected, actual)
+			}
+		}
+	}
+}
+
+func TestFormatterParseFillRoundTrip(t *testing.T) {
+	for i, f := range []*numfmt.Formatter{
+		{MinWidth: 6, Fill: "0"},
+		{MinWidth: 6, Fill: "0", Template: "-{fill}n"},
+		{MinWidth: 8, Fill: "*", Template: "-${fill}n"},
+		{MinWidth: 8, Fill: "ab"},
+	} {
+		for _, arg := range []string{"0", "7", "-12", "100", "1234.5"} {
+			d, err := f.Parse(f.Format(arg))
+			if assert.NoErrorf(t, err, "%d %s", i, arg) {
+				assert.Equalf(t, arg, d.String(), "%d %s", i, arg)
+			}
+		}
+	}
+}
+
+func TestFormatterParseError(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       string
+	}{
+		{&numfmt.Formatter{}, ""},
+		{&numfmt.Formatter{}, "foobar"},
+		{&numfmt.Formatter{}, "12abc"},
+		{&numfmt.Formatter{}, "1.2.3"},
+	} {
+		_, err := tt.formatter.Parse(tt.arg)
+		assert.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestFormatterParseStrict(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       string
+		expected  string
+	}{
+		{&numfmt.Formatter{}, "1234.5", "1234.5"},
+		{&numfmt.Formatter{}, "1,234.5", "1234.5"},
+		{&numfmt.Formatter{}, "12,345,678", "12345678"},
+		{&numfmt.Formatter{Template: "+n", ZeroSign: numfmt.ZeroSignPlusMinus}, "±0", "0"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Style: numfmt.ExponentSuperscript}}, "4.2 × 10⁻¹¹", "0.000000000042"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Style: numfmt.ExponentSuperscript}}, "1.23 × 10¹²", "1230000000000"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Style: numfmt.ExponentCaret}}, "-4.2 x 10^-6", "-0.0000042"},
+		{&numfmt.Formatter{}, "4.2e-6", "0.0000042"},
+		{&numfmt.Formatter{}, "1.5E+05", "150000"},
+		{&numfmt.Formatter{Template: "-${int}<sup>{frac}</sup>"}, "-$1,234<sup>50</sup>", "-1234.5"},
+		{&numfmt.Formatter{Template: "{int}.{frac}"}, "1,234.05", "1234.05"},
+		{&numfmt.Formatter{Template: "{int}.{frac}"}, "12.", "12"},
+		{&numfmt.Formatter{Scientific: &numfmt.Scientific{Style: numfmt.ExponentSuperscript}}, "1.23 × 10⁺⁴", "12300"},
+		{&numfmt.Formatter{Template: "+n", ZeroSign: numfmt.ZeroSignNone}, "0", "0"},
+		{&numfmt.Formatter{Template: "+n", ZeroSign: numfmt.ZeroSignBlank}, " 0", "0"},
+		{&numfmt.Formatter{NegativeTemplate: "(n)", PositivePlaceholder: "_"}, "1,234_", "1234"},
+		{&numfmt.Formatter{}, "-1,234", "-1234"},
+		{&numfmt.Formatter{}, "0.123456", "0.123456"},
+		{&numfmt.Formatter{Template: "$-n"}, "$-1,234.50", "-1234.5"},
+		{&numfmt.Formatter{Template: "+n"}, "+12", "12"},
+		{&numfmt.Formatter{NegativeTemplate: "(n)"}, "(1,234)", "-1234"},
+		{&numfmt.Formatter{GroupSeparator: ".", DecimalSeparator: ","}, "1.234,5", "1234.5"},
+	} {
+		actual, err := tt.formatter.ParseStrict(tt.arg)
+		if assert.NoErrorf(t, err, "%d", i) {
+			assert.Equalf(t, tt.expected, actual.String(), "%d", i)
+		}
+	}
+}
+
+func TestFormatterParseStrictError(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       string
+		err       error
+		offset    int
+	}{
+		{&numfmt.Formatter{}, "", numfmt.ErrMissingDigits, 0},
+		{&numfmt.Formatter{}, "foobar", numfmt.ErrMissingDigits, 0},
+		{&numfmt.Formatter{}, "12abc", numfmt.ErrUnexpectedCharacter, 2},
+		{&numfmt.Formatter{}, " 12", numfmt.ErrMissingDigits, 0},
+		{&numfmt.Formatter{}, "1.2.3", numfmt.ErrMultipleDecimalSeparators, 3},
+		{&numfmt.Formatter{}, "12,34", numfmt.ErrMisplacedGroupSeparator, 2},
+		{&numfmt.Formatter{}, "1234,567", numfmt.ErrMisplacedGroupSeparator, 4},
+		{&numfmt.Formatter{}, "1,234,56.7", numfmt.ErrMisplacedGroupSeparator, 5},
+		{&numfmt.Formatter{}, "1.234,5", numfmt.ErrMisplacedGroupSeparator, 5},
+		{&numfmt.Formatter{Template: "$n"}, "1", numfmt.ErrUnexpectedCharacter, 0},
+		{&numfmt.Formatter{Template: "+n"}, "1", numfmt.ErrMissingSign, 0},
+		{&numfmt.Formatter{NegativeTemplate: "(n)"}, "(1,23)", numfmt.ErrMisplacedGroupSeparator, 2},
+	} {
+		_, err := tt.formatter.ParseStrict(tt.arg)
+		assert.Truef(t, errors.Is(err, tt.err), "%d: %v", i, err)
+		var parseErr *numfmt.ParseError
+		if assert.Truef(t, errors.As(err, &parseErr), "%d", i) {
+			assert.Equalf(t, tt.offset, parseErr.Offset, "%d", i)
+			assert.Equalf(t, tt.arg, parseErr.Input, "%d", i)
+		}
+	}
+}
+
+func TestEscapeTemplate(t *testing.T) {
+	for i, s := range []string{"", "abc", "n", "req/min", `-+*^\nif`, "€", "units"} {
+		f := &numfmt.Formatter{Template: numfmt.EscapeTemplate(s) + "n"}
+		assert.Equalf(t, s+"1", f.Format(1), "%d", i)
+	}
+}
+
+func TestValidateDigits(t *testing.T) {
+	for i, s := range []string{"", numfmt.ArabicIndicDigits, numfmt.ThaiDigits, "0123456789"} {
+		assert.NoErrorf(t, numfmt.ValidateDigits(s), "%d", i)
+	}
+	for i, s := range []string{"0123", "01234567890", "٠١٢٣٤٥٦٧٨"} {
+		assert.Truef(t, errors.Is(numfmt.ValidateDigits(s), numfmt.ErrInvalidDigits), "%d", i)
+	}
+
+	_, err := numfmt.TemplateFunc("Digits", "0123", 1234)
+	assert.True(t, errors.Is(err, numfmt.ErrInvalidDigits))
+
+	_, err = numfmt.NewFormatterFromOptions(numfmt.FormatterOptions{Digits: "0123"})
+	assert.True(t, errors.Is(err, numfmt.ErrInvalidDigits))
+}
+
 func TestTemplateFunc(t *testing.T) {
 	for i, tt := range []struct {
 		format   []interface{}
@@ -116,12 +725,79 @@ func TestTemplateFunc(t *testing.T) {
 		{[]interface{}{"DecimalSeparator", ","}, "1.2", "1,2"},
 		{[]interface{}{"GroupSeparator", " "}, "1234", "1 234"},
 		{[]interface{}{"GroupSize", 1}, "1234", "1,2,3,4"},
+		{[]interface{}{"Grouping", "3,2"}, "1234567", "12,34,567"},
+		{[]interface{}{"Digits", numfmt.DevanagariDigits}, "1234", "१,२३४"},
+		{[]interface{}{"BidiIsolation", "FSI"}, "1234", "\u20681,234\u2069"},
 		{[]interface{}{"RoundPlaces", 0}, "1234.9", "1,235"},
+		{[]interface{}{"SignificantDigits", 2}, "3", "3.0"},
+		{[]interface{}{"MaxIntegerDigits", 3, "IntegerOverflow", "error"}, "1234", "###"},
 		{[]interface{}{"Shift", 2}, "0.31", "31"},
 		{[]interface{}{"Shift", 2, "RoundPlaces", 0}, "0.315", "32"},
 		{[]interface{}{"MinDecimalPlaces", 2}, "123", "123.00"},
 		{[]interface{}{"Template", "+n"}, "123", "+123"},
+		{[]interface{}{"Template", "+n", "ZeroSign", "plusminus"}, "0", "±0"},
 		{[]interface{}{"NegativeTemplate", "(n)"}, "-123", "(123)"},
+		{[]interface{}{"NegativeStyle", "parentheses"}, "-123", "(123)"},
+		{[]interface{}{"Preset", "european"}, "1234.5", "1.234,5"},
+		{[]interface{}{"NegativeTemplate", "(n)", "PositivePlaceholder", " "}, "123", "123 "},
+		{[]interface{}{"Locale", "de-DE"}, "1234.5", "1.234,5"},
+		{[]interface{}{"Locale", language.French}, "1234.5", "1\u202f234,5"},
+		{[]interface{}{"GroupSeparator", " ", "Locale", "de-DE"}, "1234.5", "1 234,5"},
+		{[]interface{}{"group_separator", " "}, "1234", "1 234"},
+		{[]interface{}{"groupseparator", " "}, "1234", "1 234"},
+		{[]interface{}{"round-places", 0, "min_decimal_places", 2}, "1234.9", "1,235.00"},
+		{[]interface{}{"PRESET", "usd", "locale", "de-DE"}, "1234.5", "$1.234,50"},
+		{[]interface{}{"Preset", "usd"}, "-1234.5", "-$1,234.50"},
+		{[]interface{}{"Preset", "ordinal"}, "22", "22nd"},
+		{[]interface{}{"DisplayMax", 99}, "150", "99+"},
+		{[]interface{}{"Currency", "JPY"}, "-1234.5", "-¥1,235"},
+		{[]interface{}{"Currency", "USD", "CurrencySign", "parentheses"}, "-1234.5", "($1,234.50)"},
+		{[]interface{}{"CurrencySymbol", "€", "CurrencySign", "after"}, "-1", "€-1"},
+		{[]interface{}{"Locale", "de-DE", "Currency", "EUR"}, "1234.5", "1.234,50\u00a0€"},
+		{[]interface{}{"Locale", "nl-NL", "Currency", "EUR"}, "-1234.5", "-€\u00a01.234,50"},
+		{[]interface{}{"CurrencySymbol", "€", "CurrencySuffix", true, "CurrencySeparator", " "}, "5", "5 €"},
+		{[]interface{}{"Scale", "2.54"}, "2", "5.08"},
+		{[]interface{}{"Unit", "kg", "UnitSeparator", ""}, "12.5", "12.5 kg"},
+		{[]interface{}{"Base", 16, "BasePrefix", true, "UppercaseDigits", true}, "65535", "0xFFFF"},
+		{[]interface{}{"SignificantDigits", 3, "MinSignificantDigits", 3}, "1.5", "1.50"},
+		{[]interface{}{"Repeating", "parentheses"}, big.NewRat(1, 3), "0.(3)"},
+		{[]interface{}{"FractionDenominators", "2,4", "FractionGlyphs", true}, "1.25", "1¼"},
+		{[]interface{}{"FractionDenominators", "8", "FractionRound", true, "FractionTolerance", "0.01"}, "1.3", "1 1/4"},
+		{[]interface{}{"MyriadUnits", "ja"}, "123456789", "1億2345万6789"},
+		{[]interface{}{"Unit", "kg", "UnitNoSpace", true}, "3", "3kg"},
+		{[]interface{}{"Unit", "g", "UnitPrefixes", true}, "1500", "1.5 kg"},
+		{[]interface{}{"Colors", "none"}, "1234", "1,234"},
+		{[]interface{}{"Preset", "usd", "RoundPlaces", 0, "MinDecimalPlaces", 0}, "-1234.5", "-$1,235"},
+		{[]interface{}{"RoundPlaces", 1, "Preset", "percent"}, "0.12345", "12.3%"},
+		{[]interface{}{"Preset", "usd", "Locale", "de-DE"}, "1234.5", "$1.234,50"},
+		{[]interface{}{"Humanizer", "compact", "RoundPlaces", 1}, "1234", "1.2K"},
+		{[]interface{}{"MinWidth", 6}, "123", "   123"},
+		{[]interface{}{"PreserveScale", true}, "1.50", "1.50"},
+		{[]interface{}{"AlwaysShowDecimalSeparator", true}, "120", "120."},
+		{[]interface{}{"PipSeparator", " "}, "1.25", "1.2 5"},
+		{[]interface{}{"Mask", "###-##-####"}, "123456789", "123-45-6789"},
+		{[]interface{}{"Preset", "inr"}, "1234567", "₹12,34,567.00"},
+		{[]interface{}{"NegativeStyle", "drcr", "DebitLabel", "Dr"}, "-12", "12 Dr"},
+		{[]interface{}{"Template", "n {u}", "Placeholders", map[string]interface{}{"u": "kg"}}, "5", "5 kg"},
+		{[]interface{}{"LeadingZeros", "ungrouped"}, "0001234", "0001234"},
+		{[]interface{}{"MinGroupingDigits", 5}, "9999", "9999"},
+		{[]interface{}{"NoGrouping", true}, "2024", "2024"},
+		{[]interface{}{"ZeroDenominator", "zero"}, "0", "0"},
+		{[]interface{}{map[string]interface{}{"RoundPlaces": 2, "Template": "$n"}}, "1234.567", "$1,234.57"},
+		{[]interface{}{map[string]string{"round_places": "0", "GroupSeparator": " "}}, "1234.5", "1 235"},
+		{[]interface{}{map[string]interface{}{}}, "1234.5", "1,234.5"},
+		{[]interface{}{"FloatConversion", "exact"}, float32(0.5), "0.5"},
+		{[]interface{}{"FloatConversion", "significant", "FloatDigits", 2}, 0.123, "0.12"},
+		{[]interface{}{"Template", "{trend}n", "TrendDown", "↓"}, "-123", "↓123"},
+		{[]interface{}{"ScientificAbove", "1e6", "ScientificBelow", "0.001"}, "1234567", "1.234567e6"},
+		{[]interface{}{"ScientificAbove", "1e6", "ScientificStyle", "superscript"}, "1234567", "1.234567 × 10⁶"},
+		{
+			[]interface{}{"ScientificAbove", "1e3", "ScientificUppercaseE", true, "ScientificForceExponentSign", true, "ScientificMinExponentDigits", 2},
+			"150000",
+			"1.5E+05",
+		},
+		{[]interface{}{"MinWidth", 6, "Alignment", "left"}, "123", "123   "},
+		{[]interface{}{"Preset", "bytes"}, "1500000", "1.5 MB"},
 	} {
 		fn, err := numfmt.TemplateFunc(tt.format...)
 		assert.NoError(t, err)
@@ -159,6 +835,118 @@ func TestNewUSDFormatter(t *testing.T) {
 	}
 }
 
+func TestNewEuropeanFormatter(t *testing.T) {
+	f := numfmt.NewEuropeanFormatter()
+	assert.Equal(t, "1.234.567,89", f.Format("1234567.89"))
+	assert.Equal(t, "-1.000", f.Format("-1000"))
+
+	d, err := f.Parse("1.234.567,89")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1234567.89", d.String())
+	}
+
+	f = numfmt.NewNordicFormatter()
+	assert.Equal(t, "1\u00a0234\u00a0567,89", f.Format("1234567.89"))
+
+	d, err = f.Parse("1\u00a0234\u00a0567,89")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1234567.89", d.String())
+	}
+}
+
+func TestNewSwissFormatter(t *testing.T) {
+	f := numfmt.NewSwissFormatter()
+	assert.Equal(t, "1'234'567.89", f.Format("1234567.89"))
+	assert.Equal(t, "-1'000", f.Format("-1000"))
+
+	for i, s := range []string{"1'234'567.89", "1’234’567.89", "1234567.89"} {
+		d, err := f.Parse(s)
+		if assert.NoErrorf(t, err, "%d", i) {
+			assert.Equalf(t, "1234567.89", d.String(), "%d", i)
+		}
+	}
+
+	d, err := f.ParseStrict("1'234'567.89")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1234567.89", d.String())
+	}
+
+	f = &numfmt.Formatter{GroupSeparator: "'", Template: `CHF -n`}
+	assert.Equal(t, "CHF -1'234.5", f.Format("-1234.5"))
+	d, err = f.Parse("CHF -1'234.5")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-1234.5", d.String())
+	}
+}
+
+func TestNewPercentChangeFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		places   int32
+		arg      interface{}
+		expected string
+	}{
+		{1, "0.042", "+4.2%"},
+		{1, "-0.018", "\u22121.8%"},
+		{1, "0.5", "+50.0%"},
+		{0, "-0.0149", "\u22121%"},
+		{2, "1.23456", "+123.46%"},
+	} {
+		actual := numfmt.NewPercentChangeFormatter(tt.places).Format(tt.arg)
+		if tt.expected != actual {
+			t.Errorf("%d. expected formatting %v to return %v, but got %v", i, tt.arg, tt.expected, actual)
+		}
+	}
+}
+
+func TestNewFXFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{numfmt.NewFXFormatter(5, ""), "1.092354", "1.0924"},
+		{numfmt.NewFXFormatter(5, ""), "151.2345", "151.23"},
+		{numfmt.NewFXFormatter(5, ""), "1.1", "1.1000"},
+		{numfmt.NewFXFormatter(5, ""), "15650.53", "15,651"},
+		{numfmt.NewFXFormatter(5, ""), "0.000123456", "0.00012346"},
+		{numfmt.NewFXFormatter(6, " "), "1.092354", "1.0923 5"},
+		{numfmt.NewFXFormatter(6, " "), "151.2345", "151.23 5"},
+		{numfmt.NewFXFormatter(6, " "), "15650.53", "15,650.5"},
+		{&numfmt.Formatter{PipSeparator: "'", DecimalSeparator: ","}, "1.25", "1,2'5"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		if tt.expected != actual {
+			t.Errorf("%d. expected formatting %v to return %v, but got %v", i, tt.arg, tt.expected, actual)
+		}
+	}
+
+	actual, err := numfmt.TemplateFunc("Preset", "fx", "1.092354")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0924", actual)
+}
+
+func TestNewBasisPointsFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{numfmt.NewBasisPointsFormatter(), "0.0025", "25 bps"},
+		{numfmt.NewBasisPointsFormatter(), "-0.00125", "-13 bps"},
+		{numfmt.NewBasisPointsFormatter(), "0.125", "1,250 bps"},
+		{&numfmt.Formatter{Shift: 4, Rounder: &numfmt.Rounder{Places: 1}, Template: "-nbp"}, "0.00125", "12.5bp"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		if tt.expected != actual {
+			t.Errorf("%d. expected formatting %v to return %v, but got %v", i, tt.arg, tt.expected, actual)
+		}
+	}
+
+	d, err := numfmt.NewBasisPointsFormatter().Parse("25 bps")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0025", d.String())
+}
+
 func TestNewPercentFormatter(t *testing.T) {
 	for i, tt := range []struct {
 		arg      interface{}
@@ -175,6 +963,38 @@ func TestNewPercentFormatter(t *testing.T) {
 	}
 }
 
+func TestNewPerMilleFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{numfmt.NewPerMilleFormatter(), "0.0125", "12.5‰"},
+		{numfmt.NewPerMilleFormatter(), "-0.5", "-500‰"},
+		{&numfmt.Formatter{Shift: 3, Rounder: &numfmt.Rounder{Places: 1}, Template: "-n ‰"}, "0.01234", "12.3 ‰"},
+		{&numfmt.Formatter{Shift: 3, Template: `-n\‰`}, "0.01", "10‰"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		if tt.expected != actual {
+			t.Errorf("%d. expected formatting %v to return %v, but got %v", i, tt.arg, tt.expected, actual)
+		}
+	}
+
+	for i, tt := range []struct {
+		arg      string
+		expected string
+	}{
+		{"12.5‰", "0.0125"},
+		{"12.5 ‰", "0.0125"},
+		{"-500‰", "-0.5"},
+	} {
+		actual, err := numfmt.NewPerMilleFormatter().Parse(tt.arg)
+		if assert.NoErrorf(t, err, "%d", i) {
+			assert.Equalf(t, tt.expected, actual.String(), "%d", i)
+		}
+	}
+}
+
 func ExampleTemplateFunc() {
 	t := template.New("root").Funcs(template.FuncMap{
 		"numfmt": numfmt.TemplateFunc,
@@ -199,6 +1019,40 @@ or it can return a function for later use:
 	// $1,234.57
 }
 
+func TestTemplateFuncError(t *testing.T) {
+	for i, tt := range [][]interface{}{
+		{"Unknown", "x"},
+		{"group__separatorx", "x"},
+		{"GroupSize", "x"},
+		{"Locale", "not a locale"},
+		{"Preset", "missing"},
+		{"NegativeStyle", "brackets"},
+		{"Repeating", "dots"},
+		{"LeadingZeros", "strip"},
+		{"FloatConversion", "rounded"},
+		{"Placeholders", "u=kg"},
+		{"FractionDenominators", "2,x"},
+		{"MyriadUnits", "ko"},
+	} {
+		_, err := numfmt.TemplateFunc(tt...)
+		assert.Errorf(t, err, "%d", i)
+	}
+}
+
+func ExampleFuncMap() {
+	t := template.Must(template.New("root").Funcs(numfmt.FuncMap()).Parse(
+		`{{numfmtUSD 1234.5}} {{numfmtPercent 0.25}} {{numfmtBytes 1500000}} {{numfmtCompact 1234567}}`,
+	))
+
+	err := t.Execute(os.Stdout, nil)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// $1,234.50 25% 1.5 MB 1.2M
+}
+
 func ExampleFormatter_zero() {
 	f := &numfmt.Formatter{}
 	fmt.Println(f.Format("1234.56789"))
@@ -235,3 +1089,88 @@ func ExampleNewPercentFormatter() {
 	// Output:
 	// 78.1%
 }
+
+func TestFormatterFormatIntFastPath(t *testing.T) {
+	formatters := []*numfmt.Formatter{
+		{},
+		numfmt.NewUSDFormatter(),
+		numfmt.NewFrenchFormatter(),
+		{Template: "+n", Digits: "٠١٢٣٤٥٦٧٨٩", Grouping: numfmt.GroupingPattern{3, 2}},
+		{NegativeTemplate: "(n)", MinWidth: 10},
+		{MyriadUnits: numfmt.JapaneseMyriadUnits},
+	}
+	for i, f := range formatters {
+		for _, n := range []int64{0, 1, -1, 999, 1000, -1234567, math.MaxInt64, math.MinInt64} {
+			expected := f.Format(decimal.NewFromInt(n))
+			assert.Equalf(t, expected, f.Format(n), "%d: %d", i, n)
+			assert.Equalf(t, expected, f.Format(int(n)), "%d: %d", i, n)
+		}
+		assert.Equalf(t, f.Format(decimal.NewFromInt(-8)), f.Format(int8(-8)), "%d", i)
+		assert.Equalf(t, f.Format(decimal.NewFromInt(300)), f.Format(int16(300)), "%d", i)
+		assert.Equalf(t, f.Format(decimal.NewFromInt(-70000)), f.Format(int32(-70000)), "%d", i)
+	}
+}
+
+func TestFormatterNamedNumericKinds(t *testing.T) {
+	usd := numfmt.NewUSDFormatter()
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{}, cents(123456), "123,456"},
+		{&numfmt.Formatter{}, cents(-7), "-7"},
+		{&numfmt.Formatter{}, func() *cents { c := cents(1000); return &c }(), "1,000"},
+		{&numfmt.Formatter{Shift: -2}, cents(123456), "1,234.56"},
+		{usd, cents(5), "$5.00"},
+		{&numfmt.Formatter{}, ratio(0.25), "0.25"},
+		{numfmt.NewPercentFormatter(), ratio(0.781), "78.1%"},
+		{&numfmt.Formatter{}, ratio(1e21), "1,000,000,000,000,000,000,000"},
+		{&numfmt.Formatter{}, count(255), "255"},
+		{&numfmt.Formatter{}, namedStringer(5), "not a number"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterFallback(t *testing.T) {
+	english := &numfmt.Formatter{Invalid: "n/a", Rounder: &numfmt.Rounder{Places: 1}}
+	german := &numfmt.Formatter{GroupSeparator: ".", DecimalSeparator: ",", Fallback: english}
+	limited := &numfmt.Formatter{
+		MaxIntegerDigits: 3,
+		IntegerOverflow:  numfmt.OverflowError,
+		Fallback:         numfmt.NewCompactFormatter(),
+	}
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{german, "1.234,5", "1.234,5"},
+		{german, 1234.5, "1.234,5"},
+		{german, "1,234.56", "1,234.6"},
+		{german, "abc", "n/a"},
+		{german, nil, ""},
+		{limited, 999, "999"},
+		{limited, 123456, "123.5K"},
+		{limited, "abc", "abc"},
+		{&numfmt.Formatter{Fallback: &numfmt.Formatter{Template: "[n]"}}, "abc", "abc"},
+		{&numfmt.Formatter{Invalid: "x", Fallback: english}, "abc", "x"},
+		{&numfmt.Formatter{DecimalSeparator: ",", GroupSeparator: ".", Invalid: "x", Fallback: english}, "1,234.56", "1,234.6"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	s, err := numfmt.TemplateFunc("GroupSeparator", ".", "DecimalSeparator", ",", "Fallback", "usd", "1,234.5")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "$1,234.50", s)
+	}
+
+	s, err = numfmt.TemplateFunc("Fallback", "usd", "Invalid", "x", "abc")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "x", s)
+	}
+}
@@ -0,0 +1,803 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/language"
+)
+
+// FormatterOptions is a typed alternative to the key-value pairs of TemplateFunc for Go code. Each field has the name
+// and meaning of a TemplateFunc key, so a misspelled option is a compile error instead of an unknown key error when a
+// template is executed. Fields with zero values are not applied so the values of Preset and Locale are kept. e.g.
+//
+//   f, err := numfmt.NewFormatterFromOptions(numfmt.FormatterOptions{
+//     Preset:      "usd",
+//     Locale:      language.German,
+//     RoundPlaces: numfmt.Places(0),
+//   })
+type FormatterOptions struct {
+	// Preset is the name of a Formatter registered with Register or of a built-in preset such as "usd" to start from.
+	Preset string
+
+	// Locale initializes the separators from the locale data used by NewLocaleFormatter after Preset is applied.
+	Locale language.Tag
+
+	GroupSeparator    string
+	GroupSize         int
+	Grouping          Grouping
+	MinGroupingDigits int
+	NoGrouping        bool
+	DecimalSeparator  string
+	Digits            string
+	MinusSign         string
+	PlusSign          string
+	ZeroSign          ZeroSign
+	TrendUp           string
+	TrendDown         string
+	TrendFlat         string
+	BidiIsolation     BidiIsolation
+	Humanizer         *Humanizer
+	MinWidth          int
+	MaxWidth          int
+	MaxIntegerDigits  int
+	IntegerOverflow   IntegerOverflow
+	Undefined         string
+	Nil               string
+	Invalid           string
+	Fallback          *Formatter
+	Alignment         Alignment
+	Fill              string
+
+	PositivePlaceholder         string
+	ScientificAbove             decimal.Decimal
+	ScientificBelow             decimal.Decimal
+	ScientificStyle             ExponentStyle
+	ScientificUppercaseE        bool
+	ScientificForceExponentSign bool
+	ScientificMinExponentDigits int
+	PreserveExponent            bool
+	FloatConversion             FloatConversion
+	FloatDigits                 int
+	SanitizeInput               bool
+	ZeroDenominator             ZeroDenominator
+	ZeroDenominatorTolerance    decimal.Decimal
+
+	// RoundPlaces is a pointer so rounding to 0 places can be set. See Places.
+	RoundPlaces       *int32
+	SignificantDigits int32
+
+	Shift                      int32
+	RoundBeforeShift           bool
+	Scale                      decimal.Decimal
+	MinDecimalPlaces           int32
+	PreserveScale              bool
+	LeadingZeros               LeadingZeros
+	AlwaysShowDecimalSeparator bool
+	PipSeparator               string
+	DisplayMax                 decimal.Decimal
+	DisplayMaxSuffix           string
+	ClampMin                   *decimal.Decimal
+	ClampMax                   *decimal.Decimal
+	ClampBelowMarker           string
+	ClampAboveMarker           string
+	MarkApproximate            bool
+	ApproximateSign            string
+	ApproximateAfter           bool
+	Currency                   string
+	CurrencySymbol             string
+	CurrencySuffix             bool
+	CurrencySeparator          string
+	CurrencySign               CurrencySignPosition
+	Unit                       string // Symbol of a Unit.
+	UnitPlural                 string // Plural of a Unit. UnitPlural, UnitNoSpace, and UnitPrefixes need a Unit.
+	UnitSeparator              string
+	Ordinal                    bool
+	Base                       int
+	BasePrefix                 bool
+	UppercaseDigits            bool
+	Template                   string
+	NegativeTemplate           string
+	NegativeStyle              NegativeStyle
+	DebitLabel                 string
+	CreditLabel                string
+	MinSignificantDigits       int32
+	ConciseUncertainty         bool
+	Repeating                  Repeating
+	FractionDenominators       []int64
+	FractionTolerance          decimal.Decimal
+	FractionRound              bool
+	FractionGlyphs             bool
+	SexagesimalPlaces          int32
+	SexagesimalOmitSeconds     bool
+	SexagesimalZeroPad         bool
+	SexagesimalSymbols         []string
+	SexagesimalNoSpace         bool
+	ClockUnit                  time.Duration
+	ClockSmallest              time.Duration
+	ClockPlaces                int32
+	ClockAlwaysHours           bool
+	ClockPadFirst              bool
+	InputMaxLength             int
+	InputMaxIntegerDigits      int
+	InputMaxExponent           int32
+	InputReplacement           string
+	MyriadUnits                []string
+	UnitNoSpace                bool
+	UnitPrefixes               bool
+	Colors                     *ANSIColors
+	Mask                       string
+	Placeholders               map[string]string
+}
+
+// Places returns a pointer to n for FormatterOptions.RoundPlaces.
+func Places(n int32) *int32 {
+	return &n
+}
+
+// NewFormatterFromOptions returns a new Formatter configured by o.
+func NewFormatterFromOptions(o FormatterOptions) (*Formatter, error) {
+	f := &Formatter{}
+	if err := o.Apply(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Apply configures f with o. If Preset is set f is first replaced by a copy of it. f must not have been used. An
+// error is returned if Preset is not a known preset or if Digits, Template, or NegativeTemplate is invalid.
+func (o *FormatterOptions) Apply(f *Formatter) error {
+	return o.apply(f, nil)
+}
+
+// apply is Apply with the fields named in explicit applied even if they are zero. TemplateFunc uses it so that keys
+// such as "NoGrouping" "false" override Preset and Locale.
+func (o *FormatterOptions) apply(f *Formatter, explicit map[string]bool) error {
+	if o.Preset != "" || explicit["Preset"] {
+		preset, ok := lookupPreset(o.Preset)
+		if !ok {
+			return fmt.Errorf("unknown preset: %s", o.Preset)
+		}
+		f.setConfig(preset)
+	}
+	if o.Locale != language.Und || explicit["Locale"] {
+		matchLocale(o.Locale).apply(f)
+	}
+
+	if err := ValidateDigits(o.Digits); err != nil {
+		return err
+	}
+	if err := ValidateTemplate(o.Template); err != nil {
+		return err
+	}
+	if err := ValidateTemplate(o.NegativeTemplate); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(o).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		field := formatterOptionFields[name]
+		if field.assign != nil && (explicit[name] || !isZeroOption(v.Field(i))) {
+			field.assign(f, o)
+		}
+	}
+
+	return nil
+}
+
+// set sets the field name of o from a TemplateFunc value.
+func (o *FormatterOptions) set(name string, arg interface{}) error {
+	field, ok := formatterOptionFields[name]
+	if !ok {
+		return fmt.Errorf("unknown key: %s", name)
+	}
+	fv := reflect.ValueOf(o).Elem().FieldByName(name)
+
+	var value reflect.Value
+	switch {
+	case field.parse != nil:
+		parsed, err := field.parse(arg)
+		if err != nil {
+			return err
+		}
+		value = reflect.ValueOf(parsed)
+	case field.values != nil:
+		parsed, ok := field.values[fmt.Sprint(arg)]
+		if !ok {
+			return fmt.Errorf("invalid %s: %v", name, arg)
+		}
+		value = reflect.ValueOf(parsed)
+	default:
+		var err error
+		value, err = parseOptionValue(fv.Type(), fmt.Sprint(arg))
+		if err != nil {
+			return err
+		}
+	}
+
+	fv.Set(value)
+	return nil
+}
+
+// optionField describes how a field of FormatterOptions is parsed from a TemplateFunc value and applied to a Formatter.
+type optionField struct {
+	// values maps the names accepted by TemplateFunc to values of the field. e.g. "plus" to ZeroSignPlus.
+	values map[string]interface{}
+
+	// parse converts a TemplateFunc value to a value of the field. If both values and parse are nil the value is parsed
+	// according to the type of the field by parseOptionValue.
+	parse func(arg interface{}) (interface{}, error)
+
+	// assign copies the field from o to f. It is nil for Preset and Locale which are applied first.
+	assign func(f *Formatter, o *FormatterOptions)
+}
+
+// formatterOptionFields has an entry for each field of FormatterOptions.
+var formatterOptionFields = map[string]optionField{
+	"Preset": {},
+	"Locale": {},
+	"GroupSeparator": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.GroupSeparator = o.GroupSeparator
+	}},
+	"GroupSize": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.GroupSize = o.GroupSize
+	}},
+	"Grouping": {
+		parse: func(arg interface{}) (interface{}, error) {
+			return ParseGroupingPattern(fmt.Sprint(arg))
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.Grouping = o.Grouping },
+	},
+	"MinGroupingDigits": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.MinGroupingDigits = o.MinGroupingDigits
+	}},
+	"NoGrouping": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.NoGrouping = o.NoGrouping
+	}},
+	"DecimalSeparator": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.DecimalSeparator = o.DecimalSeparator
+	}},
+	"Digits": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Digits = o.Digits
+	}},
+	"MinusSign": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.MinusSign = o.MinusSign
+	}},
+	"PlusSign": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.PlusSign = o.PlusSign
+	}},
+	"ZeroSign": {
+		values: map[string]interface{}{
+			"plus":      ZeroSignPlus,
+			"minus":     ZeroSignMinus,
+			"plusminus": ZeroSignPlusMinus,
+			"blank":     ZeroSignBlank,
+			"none":      ZeroSignNone,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.ZeroSign = o.ZeroSign },
+	},
+	"TrendUp": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.TrendUp = o.TrendUp
+	}},
+	"TrendDown": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.TrendDown = o.TrendDown
+	}},
+	"TrendFlat": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.TrendFlat = o.TrendFlat
+	}},
+	"BidiIsolation": {
+		values: map[string]interface{}{
+			"none": BidiNone,
+			"FSI":  BidiFirstStrongIsolate,
+			"LRI":  BidiLeftToRightIsolate,
+			"LRM":  BidiLeftToRightMark,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.BidiIsolation = o.BidiIsolation },
+	},
+	"Humanizer": {
+		values: map[string]interface{}{
+			"compact":   CompactHumanizer,
+			"finance":   FinanceHumanizer,
+			"si":        SIHumanizer,
+			"bytes":     BytesHumanizer,
+			"iec":       IECBytesHumanizer,
+			"bitrate":   BitRateHumanizer,
+			"duration":  DurationHumanizer,
+			"indian":    IndianHumanizer,
+			"thousands": ThousandsHumanizer,
+			"millions":  MillionsHumanizer,
+			"billions":  BillionsHumanizer,
+			"ja-myriad": JapaneseMyriadHumanizer,
+			"zh-myriad": ChineseMyriadHumanizer,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.Humanizer = o.Humanizer },
+	},
+	"MinWidth": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.MinWidth = o.MinWidth
+	}},
+	"MaxWidth": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.MaxWidth = o.MaxWidth
+	}},
+	"MaxIntegerDigits": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.MaxIntegerDigits = o.MaxIntegerDigits
+	}},
+	"IntegerOverflow": {
+		values: map[string]interface{}{
+			"clamp":      OverflowClamp,
+			"scientific": OverflowScientific,
+			"compact":    OverflowCompact,
+			"error":      OverflowError,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.IntegerOverflow = o.IntegerOverflow },
+	},
+	"Undefined": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Undefined = o.Undefined
+	}},
+	"Nil": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Nil = o.Nil
+	}},
+	"Invalid": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Invalid = o.Invalid
+	}},
+	"Fallback": {
+		parse: func(arg interface{}) (interface{}, error) {
+			name := fmt.Sprint(arg)
+			fallback, ok := lookupPreset(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown preset: %s", name)
+			}
+			return fallback, nil
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.Fallback = o.Fallback },
+	},
+	"Alignment": {
+		values: map[string]interface{}{
+			"right":   AlignRight,
+			"left":    AlignLeft,
+			"decimal": AlignDecimal,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.Alignment = o.Alignment },
+	},
+	"Fill": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Fill = o.Fill
+	}},
+	"PositivePlaceholder": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.PositivePlaceholder = o.PositivePlaceholder
+	}},
+	"ScientificAbove": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownScientific().Above = o.ScientificAbove
+	}},
+	"ScientificBelow": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownScientific().Below = o.ScientificBelow
+	}},
+	"ScientificStyle": {
+		values: map[string]interface{}{
+			"e":           ExponentE,
+			"superscript": ExponentSuperscript,
+			"caret":       ExponentCaret,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.ownScientific().Style = o.ScientificStyle },
+	},
+	"ScientificUppercaseE": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownScientific().UppercaseE = o.ScientificUppercaseE
+	}},
+	"ScientificForceExponentSign": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownScientific().ForceExponentSign = o.ScientificForceExponentSign
+	}},
+	"ScientificMinExponentDigits": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownScientific().MinExponentDigits = o.ScientificMinExponentDigits
+	}},
+	"PreserveExponent": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.PreserveExponent = o.PreserveExponent
+	}},
+	"FloatConversion": {
+		values: map[string]interface{}{
+			"shortest":    FloatShortest,
+			"exact":       FloatExact,
+			"significant": FloatSignificantDigits,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.FloatConversion = o.FloatConversion },
+	},
+	"FloatDigits": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.FloatDigits = o.FloatDigits
+	}},
+	"SanitizeInput": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.SanitizeInput = o.SanitizeInput
+	}},
+	"ZeroDenominator": {
+		values: map[string]interface{}{
+			"undefined": ZeroDenominatorUndefined,
+			"zero":      ZeroDenominatorZero,
+			"blank":     ZeroDenominatorBlank,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.ZeroDenominator = o.ZeroDenominator },
+	},
+	"ZeroDenominatorTolerance": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ZeroDenominatorTolerance = o.ZeroDenominatorTolerance
+	}},
+	"RoundPlaces": {assign: func(f *Formatter, o *FormatterOptions) {
+		if o.RoundPlaces != nil {
+			f.ownRounder().Places = *o.RoundPlaces
+		}
+	}},
+	"SignificantDigits": {assign: func(f *Formatter, o *FormatterOptions) {
+		// A new Rounder would round to 0 places so one is only created to round to significant digits.
+		if o.SignificantDigits != 0 || f.Rounder != nil {
+			f.ownRounder().SignificantDigits = o.SignificantDigits
+		}
+	}},
+	"Shift": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Shift = o.Shift
+	}},
+	"RoundBeforeShift": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.RoundBeforeShift = o.RoundBeforeShift
+	}},
+	"Scale": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Scale = o.Scale
+	}},
+	"MinDecimalPlaces": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.MinDecimalPlaces = o.MinDecimalPlaces
+	}},
+	"PreserveScale": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.PreserveScale = o.PreserveScale
+	}},
+	"LeadingZeros": {
+		values: map[string]interface{}{
+			"drop":      LeadingZerosDrop,
+			"keep":      LeadingZerosKeep,
+			"ungrouped": LeadingZerosKeepUngrouped,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.LeadingZeros = o.LeadingZeros },
+	},
+	"AlwaysShowDecimalSeparator": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.AlwaysShowDecimalSeparator = o.AlwaysShowDecimalSeparator
+	}},
+	"PipSeparator": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.PipSeparator = o.PipSeparator
+	}},
+	"DisplayMax": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.DisplayMax = o.DisplayMax
+	}},
+	"DisplayMaxSuffix": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.DisplayMaxSuffix = o.DisplayMaxSuffix
+	}},
+	"ClampMin": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ClampMin = o.ClampMin
+	}},
+	"ClampMax": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ClampMax = o.ClampMax
+	}},
+	"ClampBelowMarker": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ClampBelowMarker = o.ClampBelowMarker
+	}},
+	"ClampAboveMarker": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ClampAboveMarker = o.ClampAboveMarker
+	}},
+	"MarkApproximate": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.MarkApproximate = o.MarkApproximate
+	}},
+	"ApproximateSign": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ApproximateSign = o.ApproximateSign
+	}},
+	"ApproximateAfter": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ApproximateAfter = o.ApproximateAfter
+	}},
+	"Currency": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Currency = o.Currency
+	}},
+	"CurrencySymbol": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.CurrencySymbol = o.CurrencySymbol
+	}},
+	"CurrencySuffix": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.CurrencySuffix = o.CurrencySuffix
+	}},
+	"CurrencySeparator": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.CurrencySeparator = o.CurrencySeparator
+	}},
+	"CurrencySign": {
+		values: map[string]interface{}{
+			"before":      CurrencySignBeforeSymbol,
+			"after":       CurrencySignAfterSymbol,
+			"parentheses": CurrencySignParentheses,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.CurrencySign = o.CurrencySign },
+	},
+	"Unit": {assign: func(f *Formatter, o *FormatterOptions) {
+		if o.Unit == "" {
+			f.Unit = nil
+			return
+		}
+		f.ownUnit().Symbol = o.Unit
+	}},
+	"UnitPlural": {assign: func(f *Formatter, o *FormatterOptions) {
+		if f.Unit != nil {
+			f.ownUnit().Plural = o.UnitPlural
+		}
+	}},
+	"UnitSeparator": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.UnitSeparator = o.UnitSeparator
+	}},
+	"Ordinal": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Ordinal = o.Ordinal
+	}},
+	"Base": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Base = o.Base
+	}},
+	"BasePrefix": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.BasePrefix = o.BasePrefix
+	}},
+	"UppercaseDigits": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.UppercaseDigits = o.UppercaseDigits
+	}},
+	"Template": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Template = o.Template
+	}},
+	"NegativeTemplate": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.NegativeTemplate = o.NegativeTemplate
+	}},
+	"NegativeStyle": {
+		values: map[string]interface{}{
+			"sign":        NegativeSign,
+			"parentheses": NegativeParentheses,
+			"drcr":        NegativeDebitCredit,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.NegativeStyle = o.NegativeStyle },
+	},
+	"DebitLabel": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.DebitLabel = o.DebitLabel
+	}},
+	"CreditLabel": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.CreditLabel = o.CreditLabel
+	}},
+	"MinSignificantDigits": {assign: func(f *Formatter, o *FormatterOptions) {
+		// MinSignificantDigits only pads the results of SignificantDigits so it does not create a Rounder.
+		if f.Rounder != nil {
+			f.ownRounder().MinSignificantDigits = o.MinSignificantDigits
+		}
+	}},
+	"ConciseUncertainty": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ConciseUncertainty = o.ConciseUncertainty
+	}},
+	"Repeating": {
+		values: map[string]interface{}{
+			"none":        RepeatingNone,
+			"parentheses": RepeatingParentheses,
+			"overline":    RepeatingOverline,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.Repeating = o.Repeating },
+	},
+	"FractionDenominators": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownFraction().Denominators = o.FractionDenominators
+	}},
+	"FractionTolerance": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownFraction().Tolerance = o.FractionTolerance
+	}},
+	"FractionRound": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownFraction().Round = o.FractionRound
+	}},
+	"FractionGlyphs": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownFraction().Glyphs = o.FractionGlyphs
+	}},
+	"SexagesimalPlaces": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownSexagesimal().Places = o.SexagesimalPlaces
+	}},
+	"SexagesimalOmitSeconds": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownSexagesimal().OmitSeconds = o.SexagesimalOmitSeconds
+	}},
+	"SexagesimalZeroPad": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownSexagesimal().ZeroPad = o.SexagesimalZeroPad
+	}},
+	"SexagesimalSymbols": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownSexagesimal().Symbols = o.SexagesimalSymbols
+	}},
+	"SexagesimalNoSpace": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownSexagesimal().NoSpace = o.SexagesimalNoSpace
+	}},
+	"ClockUnit": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownClock().Unit = o.ClockUnit
+	}},
+	"ClockSmallest": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownClock().Smallest = o.ClockSmallest
+	}},
+	"ClockPlaces": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownClock().Places = o.ClockPlaces
+	}},
+	"ClockAlwaysHours": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownClock().AlwaysHours = o.ClockAlwaysHours
+	}},
+	"ClockPadFirst": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownClock().PadFirst = o.ClockPadFirst
+	}},
+	"InputMaxLength": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownInputLimits().MaxLength = o.InputMaxLength
+	}},
+	"InputMaxIntegerDigits": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownInputLimits().MaxIntegerDigits = o.InputMaxIntegerDigits
+	}},
+	"InputMaxExponent": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownInputLimits().MaxExponent = o.InputMaxExponent
+	}},
+	"InputReplacement": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.ownInputLimits().Replacement = o.InputReplacement
+	}},
+	"MyriadUnits": {
+		values: map[string]interface{}{
+			"ja": JapaneseMyriadUnits,
+			"zh": ChineseMyriadUnits,
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.MyriadUnits = o.MyriadUnits },
+	},
+	"UnitNoSpace": {assign: func(f *Formatter, o *FormatterOptions) {
+		if f.Unit != nil {
+			f.ownUnit().NoSpace = o.UnitNoSpace
+		}
+	}},
+	"UnitPrefixes": {assign: func(f *Formatter, o *FormatterOptions) {
+		if f.Unit != nil {
+			f.ownUnit().Prefixes = o.UnitPrefixes
+		}
+	}},
+	"Colors": {
+		values: map[string]interface{}{
+			"default": DefaultANSIColors,
+			"none":    (*ANSIColors)(nil),
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.Colors = o.Colors },
+	},
+	"Mask": {assign: func(f *Formatter, o *FormatterOptions) {
+		f.Mask = o.Mask
+	}},
+	"Placeholders": {
+		parse: func(arg interface{}) (interface{}, error) {
+			return placeholdersArg(arg)
+		},
+		assign: func(f *Formatter, o *FormatterOptions) { f.Placeholders = o.Placeholders },
+	},
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	tagType      = reflect.TypeOf(language.Tag{})
+)
+
+// parseOptionValue parses s as a value of type t. Slices are comma separated lists.
+func parseOptionValue(t reflect.Type, s string) (reflect.Value, error) {
+	switch t {
+	case decimalType:
+		d, err := decimal.NewFromString(s)
+		return reflect.ValueOf(d), err
+	case durationType:
+		d, err := time.ParseDuration(s)
+		return reflect.ValueOf(d), err
+	case tagType:
+		tag, err := language.Parse(s)
+		return reflect.ValueOf(tag), err
+	}
+
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(n)
+	case reflect.Ptr:
+		elem, err := parseOptionValue(t.Elem(), s)
+		if err != nil {
+			return v, err
+		}
+		v.Set(reflect.New(t.Elem()))
+		v.Elem().Set(elem)
+	case reflect.Slice:
+		for _, item := range strings.Split(s, ",") {
+			if t.Elem().Kind() != reflect.String {
+				item = strings.TrimSpace(item)
+			}
+			elem, err := parseOptionValue(t.Elem(), item)
+			if err != nil {
+				return v, err
+			}
+			v = reflect.Append(v, elem)
+		}
+	default:
+		return v, fmt.Errorf("unsupported option type: %v", t)
+	}
+	return v, nil
+}
+
+// isZeroOption reports whether v is the zero value of a FormatterOptions field. A decimal.Decimal is zero if it is
+// numerically zero.
+func isZeroOption(v reflect.Value) bool {
+	if d, ok := v.Interface().(decimal.Decimal); ok {
+		return d.IsZero()
+	}
+	return v.IsZero()
+}
+
+// ownScientific replaces f.Scientific with a copy that f owns and returns it so that a preset's Scientific is not
+// changed.
+func (f *Formatter) ownScientific() *Scientific {
+	s := &Scientific{}
+	if f.Scientific != nil {
+		*s = *f.Scientific
+	}
+	f.Scientific = s
+	return s
+}
+
+// ownRounder is ownScientific for Rounder.
+func (f *Formatter) ownRounder() *Rounder {
+	r := &Rounder{}
+	if f.Rounder != nil {
+		*r = *f.Rounder
+	}
+	f.Rounder = r
+	return r
+}
+
+// ownUnit is ownScientific for Unit.
+func (f *Formatter) ownUnit() *Unit {
+	u := &Unit{}
+	if f.Unit != nil {
+		*u = *f.Unit
+	}
+	f.Unit = u
+	return u
+}
+
+// ownFraction is ownScientific for Fraction.
+func (f *Formatter) ownFraction() *Fraction {
+	fr := &Fraction{}
+	if f.Fraction != nil {
+		*fr = *f.Fraction
+	}
+	f.Fraction = fr
+	return fr
+}
+
+// ownSexagesimal is ownScientific for Sexagesimal.
+func (f *Formatter) ownSexagesimal() *Sexagesimal {
+	sx := &Sexagesimal{}
+	if f.Sexagesimal != nil {
+		*sx = *f.Sexagesimal
+	}
+	f.Sexagesimal = sx
+	return sx
+}
+
+// ownClock is ownScientific for Clock.
+func (f *Formatter) ownClock() *Clock {
+	c := &Clock{}
+	if f.Clock != nil {
+		*c = *f.Clock
+	}
+	f.Clock = c
+	return c
+}
+
+// ownInputLimits is ownScientific for InputLimits.
+func (f *Formatter) ownInputLimits() *InputLimits {
+	l := &InputLimits{}
+	if f.InputLimits != nil {
+		*l = *f.InputLimits
+	}
+	f.InputLimits = l
+	return l
+}
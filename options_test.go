@@ -0,0 +1,177 @@
+package numfmt_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"text/template"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestNewFormatterFromOptions(t *testing.T) {
+	for i, tt := range []struct {
+		opts     numfmt.FormatterOptions
+		value    interface{}
+		expected string
+	}{
+		{numfmt.FormatterOptions{}, "1234.5", "1,234.5"},
+		{numfmt.FormatterOptions{Preset: "usd"}, "1234.5", "$1,234.50"},
+		{numfmt.FormatterOptions{Preset: "usd", RoundPlaces: numfmt.Places(0), MinDecimalPlaces: -1}, "1234.5", "$1,235"},
+		{numfmt.FormatterOptions{Locale: language.German}, "1234.5", "1.234,5"},
+		{numfmt.FormatterOptions{Locale: language.German, GroupSeparator: " "}, "1234.5", "1 234,5"},
+		{numfmt.FormatterOptions{Shift: 2, Template: "n%"}, "0.125", "12.5%"},
+		{numfmt.FormatterOptions{ScientificAbove: decimal.New(1, 6)}, "12345678", "1.2345678e7"},
+		{numfmt.FormatterOptions{SignificantDigits: 2}, "1234.5", "1,200"},
+		{numfmt.FormatterOptions{Unit: "kg"}, "3", "3 kg"},
+		{numfmt.FormatterOptions{Invalid: "n/a"}, "abc", "n/a"},
+	} {
+		f, err := numfmt.NewFormatterFromOptions(tt.opts)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, f.Format(tt.value), "%d", i)
+	}
+}
+
+func TestNewFormatterFromOptionsUnknownPreset(t *testing.T) {
+	_, err := numfmt.NewFormatterFromOptions(numfmt.FormatterOptions{Preset: "nope"})
+	require.Error(t, err)
+}
+
+func TestFormatterOptionsMatchTemplateFunc(t *testing.T) {
+	f, err := numfmt.NewFormatterFromOptions(numfmt.FormatterOptions{
+		Preset:      "usd",
+		RoundPlaces: numfmt.Places(0),
+		Template:    "n c",
+	})
+	require.NoError(t, err)
+
+	tmpl := template.Must(template.New("").Funcs(template.FuncMap{"numfmt": numfmt.TemplateFunc}).
+		Parse(`{{numfmt "Preset" "usd" "RoundPlaces" 0 "Template" "n c" .}}`))
+	buf := &bytes.Buffer{}
+	require.NoError(t, tmpl.Execute(buf, "1234.56"))
+
+	assert.Equal(t, buf.String(), f.Format("1234.56"))
+}
+
+func TestFormatterOptionsApplyDoesNotChangePreset(t *testing.T) {
+	numfmt.Register("options-test", &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}})
+
+	f, err := numfmt.NewFormatterFromOptions(numfmt.FormatterOptions{Preset: "options-test", RoundPlaces: numfmt.Places(0)})
+	require.NoError(t, err)
+	assert.Equal(t, "1,235", f.Format("1234.5"))
+
+	g, ok := numfmt.Lookup("options-test")
+	require.True(t, ok)
+	assert.Equal(t, "1,234.5", g.Format("1234.5"))
+}
+
+// TestFormatterOptionsCoverage checks that every exported Formatter setting is reachable from FormatterOptions and
+// therefore from TemplateFunc.
+func TestFormatterOptionsCoverage(t *testing.T) {
+	options := map[string]bool{}
+	ot := reflect.TypeOf(numfmt.FormatterOptions{})
+	for i := 0; i < ot.NumField(); i++ {
+		options[ot.Field(i).Name] = true
+	}
+
+	goOnly := map[string]bool{
+		"Rounder.Rules":  true,
+		"Unit.Forms":     true,
+		"Unit.Language":  true,
+		"MagnitudeRules": true,
+		"PreFormat":      true,
+		"PostFormat":     true,
+	}
+	nested := map[string]string{
+		"Rounder":     "",
+		"Scientific":  "Scientific",
+		"Unit":        "Unit",
+		"Fraction":    "Fraction",
+		"Sexagesimal": "Sexagesimal",
+		"Clock":       "Clock",
+		"InputLimits": "Input",
+	}
+	renamed := map[string]string{
+		"Rounder.Places": "RoundPlaces",
+		"Unit.Symbol":    "Unit",
+	}
+
+	ft := reflect.TypeOf(numfmt.Formatter{})
+	for i := 0; i < ft.NumField(); i++ {
+		field := ft.Field(i)
+		if field.PkgPath != "" || goOnly[field.Name] {
+			continue
+		}
+		prefix, ok := nested[field.Name]
+		if !ok {
+			assert.Truef(t, options[field.Name], "FormatterOptions is missing %s", field.Name)
+			continue
+		}
+
+		st := field.Type.Elem()
+		for j := 0; j < st.NumField(); j++ {
+			path := field.Name + "." + st.Field(j).Name
+			if goOnly[path] {
+				continue
+			}
+			name, ok := renamed[path]
+			if !ok {
+				name = prefix + st.Field(j).Name
+			}
+			assert.Truef(t, options[name], "FormatterOptions is missing %s for %s", name, path)
+		}
+	}
+}
+
+func TestTemplateFuncAcceptsAllFormatterOptions(t *testing.T) {
+	ot := reflect.TypeOf(numfmt.FormatterOptions{})
+	for i := 0; i < ot.NumField(); i++ {
+		_, err := numfmt.TemplateFunc(ot.Field(i).Name, "x")
+		if err != nil {
+			assert.NotContainsf(t, err.Error(), "unknown key", "%s", ot.Field(i).Name)
+		}
+	}
+}
+
+func TestTemplateFuncExplicitZeroValuesOverridePreset(t *testing.T) {
+	numfmt.Register("options-zero-test", &numfmt.Formatter{
+		NoGrouping: true,
+		Rounder:    &numfmt.Rounder{Places: 2},
+		Colors:     numfmt.DefaultANSIColors,
+	})
+
+	actual, err := numfmt.TemplateFunc("Preset", "options-zero-test", "NoGrouping", false, "RoundPlaces", 0, "Colors", "none",
+		"-1234.5")
+	require.NoError(t, err)
+	assert.Equal(t, "-1,235", actual)
+}
+
+func TestNewFormatterFromOptionsInvalidTemplate(t *testing.T) {
+	_, err := numfmt.NewFormatterFromOptions(numfmt.FormatterOptions{Template: `n\`})
+	require.Error(t, err)
+}
+
+func TestTemplateFuncRounderAndUnitKeys(t *testing.T) {
+	for i, tt := range []struct {
+		args     []interface{}
+		expected string
+	}{
+		{[]interface{}{"SignificantDigits", 0, "1.2345"}, "1.2345"},
+		{[]interface{}{"SignificantDigits", 3, "1.2345"}, "1.23"},
+		{[]interface{}{"MinSignificantDigits", 2, "1.2345"}, "1.2345"},
+		{[]interface{}{"SignificantDigits", 3, "MinSignificantDigits", 2, "1.2"}, "1.2"},
+		{[]interface{}{"Preset", "usd", "SignificantDigits", 0, "1.2345"}, "$1.2345"},
+		{[]interface{}{"Unit", "", "1.2345"}, "1.2345"},
+		{[]interface{}{"Unit", "kg", "Unit", "", "1.2345"}, "1.2345"},
+		{[]interface{}{"UnitNoSpace", true, "1.2345"}, "1.2345"},
+		{[]interface{}{"Unit", "kg", "UnitNoSpace", true, "1.2345"}, "1.2345kg"},
+	} {
+		actual, err := numfmt.TemplateFunc(tt.args...)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,74 @@
+package numfmt
+
+import "strings"
+
+// NewOrdinalFormatter returns a Formatter that formats a number such as 22 to 22nd.
+func NewOrdinalFormatter() *Formatter {
+	return &Formatter{
+		Rounder: &Rounder{Places: 0},
+		Ordinal: true,
+	}
+}
+
+// ordinalSuffix returns the English ordinal suffix for the integer with the decimal digits intPart.
+func ordinalSuffix(intPart string) string {
+	tens := 0
+	if len(intPart) >= 2 {
+		tens = int(intPart[len(intPart)-2] - '0')
+	}
+	if tens == 1 {
+		return "th"
+	}
+
+	switch intPart[len(intPart)-1] {
+	case '1':
+		return "st"
+	case '2':
+		return "nd"
+	case '3':
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+type compiledTemplatePartOrdinal struct{}
+
+// write writes the ordinal suffix of integers. Nothing is written for numbers with decimal places or that are not
+// written as plain decimal digits.
+func (compiledTemplatePartOrdinal) write(sb *strings.Builder, fs *formatState) {
+	if fs.intPart == "" || fs.fracPart != "" || fs.fraction != "" || fs.exponent != "" || fs.prefix != "" ||
+		fs.scaleSuffix != "" || fs.repeat != "" {
+		return
+	}
+	sb.WriteString(ordinalSuffix(fs.intPart))
+}
+
+func (compiledTemplatePartOrdinal) parse(ps *parseState) bool {
+	return ps.consumeOrdinalSuffix()
+}
+
+// consumeOrdinalSuffix consumes an ordinal suffix. In strict mode the suffix must be correct for the number parsed so
+// far.
+func (ps *parseState) consumeOrdinalSuffix() bool {
+	rest := ps.rest()
+	if ps.strict {
+		num := ps.num.String()
+		if strings.ContainsAny(num, ".e") {
+			return true
+		}
+		if suffix := ordinalSuffix(num); strings.HasPrefix(rest, suffix) {
+			ps.pos += len(suffix)
+			return true
+		}
+		return ps.fail(ps.pos, ErrUnexpectedCharacter)
+	}
+
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if len(rest) >= len(suffix) && strings.EqualFold(rest[:len(suffix)], suffix) {
+			ps.pos += len(suffix)
+			return true
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrdinalFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{numfmt.NewOrdinalFormatter(), 0, "0th"},
+		{numfmt.NewOrdinalFormatter(), 1, "1st"},
+		{numfmt.NewOrdinalFormatter(), 2, "2nd"},
+		{numfmt.NewOrdinalFormatter(), 3, "3rd"},
+		{numfmt.NewOrdinalFormatter(), 4, "4th"},
+		{numfmt.NewOrdinalFormatter(), 11, "11th"},
+		{numfmt.NewOrdinalFormatter(), 12, "12th"},
+		{numfmt.NewOrdinalFormatter(), 13, "13th"},
+		{numfmt.NewOrdinalFormatter(), 21, "21st"},
+		{numfmt.NewOrdinalFormatter(), 22, "22nd"},
+		{numfmt.NewOrdinalFormatter(), 111, "111th"},
+		{numfmt.NewOrdinalFormatter(), 1001, "1,001st"},
+		{numfmt.NewOrdinalFormatter(), "2.6", "3rd"},
+		{numfmt.NewOrdinalFormatter(), -1, "-1st"},
+		{&numfmt.Formatter{Ordinal: true}, "1.5", "1.5"},
+		{&numfmt.Formatter{Ordinal: true, Template: "n place"}, 2, "2nd place"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	for i, tt := range []struct {
+		arg      string
+		expected string
+	}{
+		{"1st", "1"},
+		{"22nd", "22"},
+		{"1,001st", "1001"},
+		{"3RD", "3"},
+		{"4", "4"},
+	} {
+		actual, err := numfmt.NewOrdinalFormatter().Parse(tt.arg)
+		if assert.NoErrorf(t, err, "%d", i) {
+			assert.Equalf(t, tt.expected, actual.String(), "%d", i)
+		}
+	}
+
+	_, err := numfmt.NewOrdinalFormatter().ParseStrict("22th")
+	assert.Error(t, err)
+}
+
+func TestTemplateOrdinalVerb(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Template: "n{ordinal}"}, 23, "23rd"},
+		{&numfmt.Formatter{Template: "-n{ordinal}"}, -1, "-1st"},
+		{&numfmt.Formatter{Template: "n{ordinal}"}, 12345, "12,345th"},
+		{&numfmt.Formatter{Template: "n{ordinal}"}, 1.5, "1.5"},
+		{&numfmt.Formatter{Template: "n{ordinal}", Rounder: &numfmt.Rounder{Places: 0}}, 1.5, "2nd"},
+		{&numfmt.Formatter{Template: "n{ordinal}", MinDecimalPlaces: 1}, 2, "2.0"},
+		{&numfmt.Formatter{Template: "#n{ordinal}"}, 112, "#112th"},
+		{&numfmt.Formatter{Template: "{int}<sup>{ordinal}</sup>"}, 22, "22<sup>nd</sup>"},
+		{&numfmt.Formatter{Template: "n{ordinal}", Humanizer: numfmt.CompactHumanizer}, 2000, "2K"},
+		{&numfmt.Formatter{Template: "n o"}, 3, "3 o"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	actual, err := (&numfmt.Formatter{Template: "-n{ordinal}"}).ParseStrict("-1,001st")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-1001", actual.String())
+	}
+	_, err = (&numfmt.Formatter{Template: "n{ordinal}"}).ParseStrict("22th")
+	assert.Error(t, err)
+}
@@ -0,0 +1,600 @@
+package numfmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+// Parse parses s as a number formatted by f. It reverses the parts of formatting that can be reversed: the template,
+// the group and decimal separators, Shift, and Scale. Rounding cannot be reversed.
+//
+// Parse is lenient. Surrounding whitespace, ANSI color escape sequences, and bidirectional formatting characters are
+// ignored and template literals such as a currency symbol may be omitted. ApproximateSign is ignored if MarkApproximate
+// is set. If NegativeTemplate is set then s is considered negative when it matches NegativeTemplate exactly.
+func (f *Formatter) Parse(s string) (decimal.Decimal, error) {
+	f = f.compiled()
+	if err := f.checkInputLength(s); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	s = strings.TrimSpace(strings.Map(stripBidi, stripANSI(s)))
+	if f.Fill != "" {
+		if f.Alignment != AlignLeft {
+			s = f.trimFill(s, true)
+		}
+		if f.Alignment != AlignRight {
+			s = f.trimFill(s, false)
+		}
+		s = strings.TrimSpace(s)
+	}
+	if f.MarkApproximate {
+		sign := f.approximateSign()
+		s = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(s, sign), sign))
+	}
+	for _, marker := range []string{f.ClampBelowMarker, f.ClampAboveMarker} {
+		if marker != "" {
+			s = strings.TrimSpace(strings.TrimPrefix(s, marker))
+		}
+	}
+
+	if f.compiledNegativeTemplate != nil {
+		ps := &parseState{f: f, s: s, strict: true}
+		if ps.parseTemplate(f.compiledNegativeTemplate) {
+			return ps.decimal(true)
+		}
+	}
+
+	ps := &parseState{f: f, s: trimPlaceholder(s, f.PositivePlaceholder)}
+	if ps.parseTemplate(f.compiledTemplate) {
+		return ps.decimal(false)
+	}
+
+	return decimal.Decimal{}, fmt.Errorf("cannot parse %q as number", s)
+}
+
+// Reasons for a ParseError. Use errors.Is to check the reason of an error returned by ParseStrict.
+var (
+	ErrUnexpectedCharacter       = errors.New("unexpected character")
+	ErrMissingDigits             = errors.New("missing digits")
+	ErrMissingSign               = errors.New("missing sign")
+	ErrMisplacedGroupSeparator   = errors.New("misplaced group separator")
+	ErrMultipleDecimalSeparators = errors.New("multiple decimal separators")
+)
+
+// ParseError is the error returned by ParseStrict.
+type ParseError struct {
+	Input  string // The string being parsed.
+	Offset int    // Byte offset in Input where parsing failed.
+	Err    error  // The reason parsing failed such as ErrUnexpectedCharacter.
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("cannot parse %q as number: %v at offset %d", e.Input, e.Err, e.Offset)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseStrict parses s as a number formatted by f. Unlike Parse, s must match the template exactly, including
+// literals and whitespace, and group separators must be in the correct positions. Group separators may be omitted
+// entirely. More decimal places than f would display are allowed. If s is not valid the error is a *ParseError.
+func (f *Formatter) ParseStrict(s string) (decimal.Decimal, error) {
+	f = f.compiled()
+	if err := f.checkInputLength(s); err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	var negErr *ParseError
+	if f.compiledNegativeTemplate != nil {
+		ps := &parseState{f: f, s: s, strict: true}
+		if ps.parseTemplate(f.compiledNegativeTemplate) {
+			return ps.decimal(true)
+		}
+		negErr = ps.parseError()
+	}
+
+	ps := &parseState{f: f, s: trimPlaceholder(s, f.PositivePlaceholder), strict: true}
+	if ps.parseTemplate(f.compiledTemplate) {
+		return ps.decimal(false)
+	}
+	err := ps.parseError()
+
+	// Report the error from the template that matched more of s.
+	if negErr != nil && negErr.Offset > err.Offset {
+		return decimal.Decimal{}, negErr
+	}
+	return decimal.Decimal{}, err
+}
+
+// trimPlaceholder returns s without any trailing repetitions of placeholder.
+func trimPlaceholder(s, placeholder string) string {
+	if placeholder == "" {
+		return s
+	}
+	for strings.HasSuffix(s, placeholder) {
+		s = s[:len(s)-len(placeholder)]
+	}
+	return s
+}
+
+// stripBidi is a strings.Map function that removes Unicode bidirectional formatting characters.
+func stripBidi(r rune) rune {
+	switch {
+	case r == '\u061c', r == '\u200e', r == '\u200f':
+		return -1
+	case r >= '\u202a' && r <= '\u202e':
+		return -1
+	case r >= '\u2066' && r <= '\u2069':
+		return -1
+	}
+	return r
+}
+
+type parseState struct {
+	f      *Formatter
+	s      string
+	pos    int
+	strict bool
+
+	neg bool
+	num strings.Builder
+
+	integerOnly bool // The decimal separator is not consumed as part of the number.
+
+	err       error // The first failure.
+	errOffset int
+}
+
+// fail records err at offset if no failure has been recorded yet. It always returns false.
+func (ps *parseState) fail(offset int, err error) bool {
+	if ps.err == nil {
+		ps.err = err
+		ps.errOffset = offset
+	}
+	return false
+}
+
+// parseError returns the recorded failure as a *ParseError.
+func (ps *parseState) parseError() *ParseError {
+	err := ps.err
+	offset := ps.errOffset
+	if err == nil {
+		err = ErrUnexpectedCharacter
+		offset = ps.pos
+	}
+	return &ParseError{Input: ps.s, Offset: offset, Err: err}
+}
+
+// parseTemplate reports whether ct matches all of ps.s.
+func (ps *parseState) parseTemplate(ct compiledTemplate) bool {
+	if !ct.parse(ps) {
+		return false
+	}
+	if !ps.strict {
+		ps.skipSpace()
+	}
+	if ps.num.Len() == 0 {
+		return ps.fail(ps.pos, ErrMissingDigits)
+	}
+	if ps.pos != len(ps.s) {
+		return ps.fail(ps.pos, ErrUnexpectedCharacter)
+	}
+	return true
+}
+
+func (ps *parseState) decimal(neg bool) (decimal.Decimal, error) {
+	d, err := decimal.NewFromString(ps.num.String())
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	if !ps.f.InputLimits.allows(d) {
+		return decimal.Decimal{}, &ParseError{Input: ps.s, Err: ErrInputLimit}
+	}
+	if neg || ps.neg {
+		d = d.Neg()
+	}
+	if !ps.f.Scale.IsZero() {
+		d = d.Div(ps.f.Scale)
+	}
+	if ps.f.Shift != 0 {
+		d = d.Shift(-ps.f.Shift)
+	}
+	return d, nil
+}
+
+func (ps *parseState) rest() string {
+	return ps.s[ps.pos:]
+}
+
+func (ps *parseState) skipSpace() {
+	ps.pos += len(ps.rest()) - len(strings.TrimLeftFunc(ps.rest(), unicode.IsSpace))
+}
+
+func (ps *parseState) consumeLiteral(lit string) bool {
+	if strings.HasPrefix(ps.rest(), lit) {
+		ps.pos += len(lit)
+		return true
+	}
+	if ps.strict {
+		return ps.fail(ps.pos, ErrUnexpectedCharacter)
+	}
+
+	// Leniently allow differences in whitespace and missing literals.
+	ps.skipSpace()
+	trimmed := strings.TrimSpace(lit)
+	if trimmed != "" && strings.HasPrefix(ps.rest(), trimmed) {
+		ps.pos += len(trimmed)
+		ps.skipSpace()
+	}
+	return true
+}
+
+func (ps *parseState) consumeFill() {
+	fill := ps.f.fill()
+	start := ps.pos
+	for strings.HasPrefix(ps.rest(), fill) {
+		ps.pos += len(fill)
+	}
+	if ps.pos > start && ps.f.containsDigit(fill) && !ps.f.containsDigit(ps.rest()) {
+		ps.pos -= len(fill)
+	}
+}
+
+// trimFill returns s without the repetitions of Fill at its start if prefix is true and at its end otherwise. If Fill
+// is a digit one repetition is kept when no other digit is left so that a Fill of "0" keeps the number 0.
+func (f *Formatter) trimFill(s string, prefix bool) string {
+	fill := f.fill()
+	trimmed := s
+	for prefix && strings.HasPrefix(trimmed, fill) {
+		trimmed = trimmed[len(fill):]
+	}
+	for !prefix && strings.HasSuffix(trimmed, fill) {
+		trimmed = trimmed[:len(trimmed)-len(fill)]
+	}
+
+	if trimmed != s && f.containsDigit(fill) && !f.containsDigit(trimmed) {
+		if prefix {
+			return s[len(s)-len(trimmed)-len(fill):]
+		}
+		return s[:len(trimmed)+len(fill)]
+	}
+	return trimmed
+}
+
+// containsDigit reports whether s contains a digit of f.
+func (f *Formatter) containsDigit(s string) bool {
+	ps := &parseState{f: f, s: s}
+	for i := 0; i < len(s); i++ {
+		if ps.digitAt(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// consumeTrend consumes a trend indicator. TrendDown makes the number negative.
+func (ps *parseState) consumeTrend() bool {
+	f := ps.f
+	switch {
+	case strings.HasPrefix(ps.rest(), f.trendDown()):
+		ps.neg = true
+		ps.pos += len(f.trendDown())
+	case strings.HasPrefix(ps.rest(), f.trendUp()):
+		ps.pos += len(f.trendUp())
+	case strings.HasPrefix(ps.rest(), f.trendFlat()):
+		ps.pos += len(f.trendFlat())
+	default:
+		return false
+	}
+	return true
+}
+
+// consumeSign consumes the Formatter's minus or plus sign, an ASCII sign, or MinusSign.
+func (ps *parseState) consumeSign() bool {
+	for _, sign := range []string{ps.f.minusSign(), "-", MinusSign} {
+		if strings.HasPrefix(ps.rest(), sign) {
+			ps.neg = true
+			ps.pos += len(sign)
+			return true
+		}
+	}
+	for _, sign := range []string{ps.f.plusSign(), "+", PlusMinusSign} {
+		if strings.HasPrefix(ps.rest(), sign) {
+			ps.pos += len(sign)
+			return true
+		}
+	}
+	return false
+}
+
+func (ps *parseState) consumeNumber() bool {
+	if ps.f.Sexagesimal != nil {
+		return ps.consumeSexagesimal()
+	}
+	if ps.f.Clock != nil {
+		return ps.consumeClock()
+	}
+	if !ps.consumeDigits() {
+		return ps.fail(ps.pos, ErrMissingDigits)
+	}
+	ps.consumeExponent()
+	if ps.f.Ordinal {
+		return ps.consumeOrdinalSuffix()
+	}
+	return true
+}
+
+// consumeExponent consumes a scientific notation exponent such as e-9 or × 10⁻⁹.
+func (ps *parseState) consumeExponent() {
+	if ps.f.Scientific != nil && ps.f.Scientific.Style == ExponentSuperscript {
+		ps.consumeSuperscriptExponent()
+		return
+	}
+
+	rest := ps.rest()
+	prefixLen := 1
+	if ps.f.Scientific != nil && ps.f.Scientific.Style == ExponentCaret {
+		prefix := ps.f.Scientific.exponentPrefix()
+		if !strings.HasPrefix(rest, prefix) {
+			return
+		}
+		prefixLen = len(prefix)
+	} else if len(rest) == 0 || (rest[0] != 'e' && rest[0] != 'E') {
+		return
+	}
+	i := ps.pos + prefixLen
+	sign := ""
+	if i < len(ps.s) && (ps.s[i] == '-' || ps.s[i] == '+') {
+		sign = ps.s[i : i+1]
+		i++
+	}
+	if !ps.digitAt(i) {
+		return
+	}
+
+	ps.num.WriteByte('e')
+	ps.num.WriteString(sign)
+	ps.pos = i
+	for {
+		digit, size := ps.digit(ps.pos)
+		if size == 0 {
+			return
+		}
+		ps.num.WriteByte(digit)
+		ps.pos += size
+	}
+}
+
+// consumeSuperscriptExponent consumes an exponent written with ExponentSuperscript such as × 10⁻⁹.
+func (ps *parseState) consumeSuperscriptExponent() {
+	rest := ps.rest()
+	prefix := ps.f.Scientific.exponentPrefix()
+	if !strings.HasPrefix(rest, prefix) {
+		return
+	}
+	rest = rest[len(prefix):]
+
+	exp := &strings.Builder{}
+	exp.WriteByte('e')
+	if strings.HasPrefix(rest, superscriptMinus) {
+		exp.WriteByte('-')
+		rest = rest[len(superscriptMinus):]
+	} else if strings.HasPrefix(rest, superscriptPlus) {
+		rest = rest[len(superscriptPlus):]
+	}
+
+	n := 0
+	for {
+		digit := -1
+		for i, s := range superscriptDigits {
+			if strings.HasPrefix(rest, s) {
+				digit = i
+				rest = rest[len(s):]
+				break
+			}
+		}
+		if digit < 0 {
+			break
+		}
+		exp.WriteByte(byte('0' + digit))
+		n++
+	}
+	if n == 0 {
+		return
+	}
+
+	ps.num.WriteString(exp.String())
+	ps.pos = len(ps.s) - len(rest)
+}
+
+// consumeFractionDigits consumes the fractional digits written by the {frac} verb.
+func (ps *parseState) consumeFractionDigits() {
+	for first := true; ; first = false {
+		digit, size := ps.digit(ps.pos)
+		if size == 0 {
+			return
+		}
+		if first {
+			ps.num.WriteByte('.')
+		}
+		ps.num.WriteByte(digit)
+		ps.pos += size
+	}
+}
+
+// consumeDigits consumes the digits and separators of a number. It returns false if there are no digits or in strict
+// mode if the separators are misplaced.
+func (ps *parseState) consumeDigits() bool {
+	groupSeparator := ps.f.groupSeparator()
+	decimalSeparator := ps.f.decimalSeparator()
+	seenDecimalSeparator := false
+	start := ps.num.Len()
+
+	r, _ := utf8.DecodeRuneInString(groupSeparator)
+	spaceSeparator := unicode.IsSpace(r)
+
+	gc := &groupChecker{f: ps.f}
+
+	for ps.pos < len(ps.s) {
+		rest := ps.rest()
+		if digit, size := ps.digit(ps.pos); size > 0 {
+			ps.num.WriteByte(digit)
+			ps.pos += size
+			if !seenDecimalSeparator {
+				gc.digits++
+			}
+			continue
+		}
+
+		if ps.strict {
+			if seenDecimalSeparator && strings.HasPrefix(rest, decimalSeparator) {
+				return ps.fail(ps.pos, ErrMultipleDecimalSeparators)
+			}
+			if strings.HasPrefix(rest, groupSeparator) && ps.digitAt(ps.pos+len(groupSeparator)) {
+				if seenDecimalSeparator || !gc.separator(ps.pos) {
+					return ps.fail(ps.pos, ErrMisplacedGroupSeparator)
+				}
+				ps.pos += len(groupSeparator)
+				continue
+			}
+		}
+
+		switch {
+		case ps.integerOnly && strings.HasPrefix(rest, decimalSeparator):
+			return ps.endDigits(start, seenDecimalSeparator, gc)
+		case !seenDecimalSeparator && strings.HasPrefix(rest, decimalSeparator) && ps.digitAt(ps.pos+len(decimalSeparator)):
+			if ps.strict {
+				if offset := gc.end(); offset >= 0 {
+					return ps.fail(offset, ErrMisplacedGroupSeparator)
+				}
+			}
+			ps.num.WriteByte('.')
+			ps.pos += len(decimalSeparator)
+			seenDecimalSeparator = true
+		case !seenDecimalSeparator && ps.num.Len() > start && ps.f.AlwaysShowDecimalSeparator && strings.HasPrefix(rest, decimalSeparator):
+			// A trailing decimal separator without a fraction.
+			ps.pos += len(decimalSeparator)
+			seenDecimalSeparator = true
+		case !seenDecimalSeparator && ps.num.Len() > start && ps.groupSeparatorLen(rest, groupSeparator) > 0 &&
+			ps.digitAt(ps.pos+ps.groupSeparatorLen(rest, groupSeparator)):
+			ps.pos += ps.groupSeparatorLen(rest, groupSeparator)
+		case !seenDecimalSeparator && ps.num.Len() > start && spaceSeparator && !ps.strict:
+			// A group separator that is a space such as NoBreakSpace is often entered as a plain space.
+			r, size := utf8.DecodeRuneInString(rest)
+			if !unicode.IsSpace(r) || !ps.digitAt(ps.pos+size) {
+				return true
+			}
+			ps.pos += size
+		default:
+			return ps.endDigits(start, seenDecimalSeparator, gc)
+		}
+	}
+
+	return ps.endDigits(start, seenDecimalSeparator, gc)
+}
+
+// groupSeparatorLen returns the length of the group separator at the start of s or 0 if there is none. An apostrophe
+// group separator leniently matches both the ASCII apostrophe and the right single quotation mark as both are used in
+// Swiss numbers.
+func (ps *parseState) groupSeparatorLen(s, groupSeparator string) int {
+	if strings.HasPrefix(s, groupSeparator) {
+		return len(groupSeparator)
+	}
+	if !ps.strict && (groupSeparator == "'" || groupSeparator == "’") {
+		for _, apostrophe := range []string{"'", "’"} {
+			if strings.HasPrefix(s, apostrophe) {
+				return len(apostrophe)
+			}
+		}
+	}
+	return 0
+}
+
+func (ps *parseState) endDigits(start int, seenDecimalSeparator bool, gc *groupChecker) bool {
+	if ps.num.Len() == start {
+		return false
+	}
+	if ps.strict && !seenDecimalSeparator {
+		if offset := gc.end(); offset >= 0 {
+			return ps.fail(offset, ErrMisplacedGroupSeparator)
+		}
+	}
+	return true
+}
+
+// groupChecker checks that group separators are in the correct positions in the integer part of a number.
+type groupChecker struct {
+	f          *Formatter
+	digits     int   // Number of digits since the last separator.
+	groups     []int // Number of digits in each group before the last separator.
+	separators []int // Offsets of the separators.
+}
+
+// separator records a separator at offset. It returns false if the separator does not follow a digit.
+func (gc *groupChecker) separator(offset int) bool {
+	if gc.digits == 0 {
+		return false
+	}
+	gc.groups = append(gc.groups, gc.digits)
+	gc.separators = append(gc.separators, offset)
+	gc.digits = 0
+	return true
+}
+
+// end returns the offset of a misplaced separator or -1 if all separators are in the correct positions.
+func (gc *groupChecker) end() int {
+	if len(gc.separators) == 0 {
+		return -1
+	}
+
+	groups := append(gc.groups, gc.digits)
+	n := 0
+	for _, g := range groups {
+		n += g
+	}
+	widths := gc.f.groupWidths(n)
+	if len(widths) == 0 {
+		return gc.separators[0]
+	}
+
+	for i := 0; i < len(groups); i++ {
+		g := groups[len(groups)-1-i]
+		width := groupWidth(widths, i)
+		if i == len(groups)-1 {
+			if width <= 0 || g > width {
+				return gc.separators[0]
+			}
+		} else if g != width {
+			return gc.separators[len(groups)-2-i]
+		}
+	}
+	return -1
+}
+
+// digit returns the ASCII digit and its encoded size at i in ps.s. Both ASCII digits and Formatter.Digits are
+// accepted. size is 0 if there is no digit at i.
+func (ps *parseState) digit(i int) (digit byte, size int) {
+	if i >= len(ps.s) {
+		return 0, 0
+	}
+	if ps.s[i] >= '0' && ps.s[i] <= '9' {
+		return ps.s[i], 1
+	}
+	for n, d := range ps.f.digits {
+		if strings.HasPrefix(ps.s[i:], d) {
+			return '0' + byte(n), len(d)
+		}
+	}
+	return 0, 0
+}
+
+func (ps *parseState) digitAt(i int) bool {
+	_, size := ps.digit(i)
+	return size > 0
+}
@@ -0,0 +1,88 @@
+package numfmt
+
+import (
+	"strings"
+)
+
+// Parts are the components of a number formatted by a Formatter. They allow styling pieces of the number such as
+// writing the fraction smaller without parsing the result of Format. Padding, colors, and bidi isolation are not
+// applied.
+type Parts struct {
+	Prefix           string // Template text before the number such as a currency symbol. Excludes the sign.
+	Sign             string // Sign written by the template regardless of its position. Empty if no sign is written.
+	Integer          string // Grouped integer digits including any base prefix.
+	DecimalSeparator string // Empty if there is no fractional part.
+	Fraction         string // Fractional digits or common fraction including any repeating digits.
+	Exponent         string // Scientific notation exponent. e.g. e-9
+	Suffix           string // Units and template text after the number. Excludes the sign.
+}
+
+// FormatParts formats v like Format and returns the components of the result. ok is false if v is not a number.
+func (f *Formatter) FormatParts(v interface{}) (p Parts, ok bool) {
+	fs, _, ok := f.state(v)
+	if !ok {
+		return Parts{}, false
+	}
+	return fs.parts(), true
+}
+
+func (fs *formatState) parts() Parts {
+	f := fs.f
+	ct := f.compiledTemplate
+	if fs.neg && f.compiledNegativeTemplate != nil {
+		ct = f.compiledNegativeTemplate
+	}
+
+	var p Parts
+	sb := &strings.Builder{}
+	afterNumber := false
+	for _, part := range ct {
+		switch part.(type) {
+		case compiledTemplatePartNumber:
+			p.Prefix = sb.String()
+			sb.Reset()
+
+			integer := &strings.Builder{}
+			fs.writeInteger(integer)
+			p.Integer = integer.String()
+
+			fraction := &strings.Builder{}
+			fraction.WriteString(fs.fraction)
+			if fs.hasDecimalSeparator() {
+				p.DecimalSeparator = f.decimalSeparator()
+				fs.writeFraction(fraction)
+			}
+			p.Fraction = fraction.String()
+
+			p.Exponent = fs.exponent
+			sb.WriteString(fs.suffix)
+			afterNumber = true
+		case compiledTemplatePartInteger:
+			p.Prefix = sb.String()
+			sb.Reset()
+
+			integer := &strings.Builder{}
+			fs.writeInteger(integer)
+			p.Integer = integer.String()
+			afterNumber = true
+		case compiledTemplatePartFraction:
+			fraction := &strings.Builder{}
+			fs.writeFraction(fraction)
+			p.Fraction = fraction.String()
+		case compiledTemplatePartOptionalSign, compiledTemplatePartForceSign:
+			sign := &strings.Builder{}
+			part.write(sign, fs)
+			p.Sign += sign.String()
+		default:
+			part.write(sb, fs)
+		}
+	}
+
+	if afterNumber {
+		p.Suffix = sb.String()
+	} else {
+		p.Prefix = sb.String()
+	}
+
+	return p
+}
@@ -0,0 +1,54 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterFormatParts(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  numfmt.Parts
+	}{
+		{&numfmt.Formatter{}, 1234567, numfmt.Parts{Integer: "1,234,567"}},
+		{
+			numfmt.NewUSDFormatter(),
+			"-1234.5",
+			numfmt.Parts{Prefix: "$", Sign: "-", Integer: "1,234", DecimalSeparator: ".", Fraction: "50"},
+		},
+		{
+			&numfmt.Formatter{Template: "n +", DecimalSeparator: ","},
+			"0.25",
+			numfmt.Parts{Sign: "+", Integer: "0", DecimalSeparator: ",", Fraction: "25", Suffix: " "},
+		},
+		{
+			&numfmt.Formatter{Scientific: &numfmt.Scientific{Above: decimal.New(1, 6)}, Rounder: &numfmt.Rounder{Places: 2}},
+			"4210000000",
+			numfmt.Parts{Integer: "4", DecimalSeparator: ".", Fraction: "21", Exponent: "e9"},
+		},
+		{
+			&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "m"}, Template: "(n)"},
+			"12.5",
+			numfmt.Parts{Prefix: "(", Integer: "12", DecimalSeparator: ".", Fraction: "5", Suffix: " m)"},
+		},
+		{
+			&numfmt.Formatter{Base: 16, BasePrefix: true},
+			255,
+			numfmt.Parts{Integer: "0xff"},
+		},
+	} {
+		actual, ok := tt.formatter.FormatParts(tt.arg)
+		require.Truef(t, ok, "%d", i)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterFormatPartsNotNumber(t *testing.T) {
+	_, ok := (&numfmt.Formatter{}).FormatParts("abc")
+	assert.False(t, ok)
+}
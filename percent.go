@@ -0,0 +1,9 @@
+package numfmt
+
+// FormatPercentOf formats part divided by whole with f. f is normally a percent Formatter such as one returned by
+// NewPercentFormatter. e.g. 3 of 4 => 75%. If whole is zero then 0% is written when part is also zero and Undefined
+// is written otherwise unless ZeroDenominator is set. If part or whole is not a number it is written as Format would
+// write it. It is the same as FormatRatio.
+func (f *Formatter) FormatPercentOf(part, whole interface{}) string {
+	return f.FormatRatio(part, whole)
+}
@@ -0,0 +1,32 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFormatPercentOf(t *testing.T) {
+	f := numfmt.NewPercentFormatter()
+	f.Rounder = &numfmt.Rounder{Places: 1}
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		part      interface{}
+		whole     interface{}
+		expected  string
+	}{
+		{f, 3, 4, "75%"},
+		{f, 1, 3, "33.3%"},
+		{f, "-1", "8", "-12.5%"},
+		{f, 0, 0, "0%"},
+		{f, 5, 0, "—"},
+		{&numfmt.Formatter{Shift: 2, Template: "n%", Undefined: "n/a"}, 5, 0, "n/a"},
+		{f, "abc", 4, "abc"},
+		{f, 1, "xyz", "xyz"},
+	} {
+		actual := tt.formatter.FormatPercentOf(tt.part, tt.whole)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
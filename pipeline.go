@@ -0,0 +1,103 @@
+package numfmt
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// FormatFunc formats a number.
+type FormatFunc func(d decimal.Decimal) string
+
+// Stage is a composable step of a Pipeline. It returns a FormatFunc that does its work and calls next with the
+// possibly changed number. It may also change the string next returns.
+type Stage func(next FormatFunc) FormatFunc
+
+// Pipeline formats numbers by passing them through a sequence of Stages and then a Formatter. This allows a display
+// pipeline such as round, scale, humanize, and template to be declared once. A Pipeline is immutable and concurrency
+// safe.
+type Pipeline struct {
+	format FormatFunc
+}
+
+// NewPipeline returns a Pipeline that passes numbers through stages in order and then formats them with f. If f is nil
+// the zero value Formatter is used. e.g.
+//
+//   p := numfmt.NewPipeline(numfmt.NewUSDFormatter(),
+//       numfmt.RoundStage(&numfmt.Rounder{Places: 2}),
+//       numfmt.ShiftStage(-3),
+//       numfmt.HumanizeStage(numfmt.CompactHumanizer, &numfmt.Rounder{Places: 1}),
+//   )
+func NewPipeline(f *Formatter, stages ...Stage) *Pipeline {
+	if f == nil {
+		f = &Formatter{}
+	}
+
+	format := FormatFunc(f.formatDecimal)
+	for i := len(stages) - 1; i >= 0; i-- {
+		format = stages[i](format)
+	}
+
+	return &Pipeline{format: format}
+}
+
+// Format formats v. v is converted to a number the same as Formatter.Format. If v is not a number it is returned
+// converted to a string.
+func (p *Pipeline) Format(v interface{}) string {
+	d, s, ok := toDecimal(v)
+	if !ok {
+		return s
+	}
+	return p.format(d)
+}
+
+// FormatDecimal formats d.
+func (p *Pipeline) FormatDecimal(d decimal.Decimal) string {
+	return p.format(d)
+}
+
+// TransformStage returns a Stage that replaces the number with the result of fn.
+func TransformStage(fn func(decimal.Decimal) decimal.Decimal) Stage {
+	return func(next FormatFunc) FormatFunc {
+		return func(d decimal.Decimal) string {
+			return next(fn(d))
+		}
+	}
+}
+
+// RoundStage returns a Stage that rounds the number with r.
+func RoundStage(r *Rounder) Stage {
+	return TransformStage(r.Round)
+}
+
+// ShiftStage returns a Stage that shifts the decimal point of the number by places. e.g. 2 for percentages.
+func ShiftStage(places int32) Stage {
+	return TransformStage(func(d decimal.Decimal) decimal.Decimal {
+		return d.Shift(places)
+	})
+}
+
+// ScaleStage returns a Stage that multiplies the number by factor.
+func ScaleStage(factor decimal.Decimal) Stage {
+	return TransformStage(func(d decimal.Decimal) decimal.Decimal {
+		return d.Mul(factor)
+	})
+}
+
+// HumanizeStage returns a Stage that scales the number down with h, rounds it with r if r is not nil, and appends the
+// unit suffix to the formatted result. e.g. 1234567 => 1.2M with CompactHumanizer and Rounder places of 1.
+func HumanizeStage(h *Humanizer, r *Rounder) Stage {
+	return func(next FormatFunc) FormatFunc {
+		return func(d decimal.Decimal) string {
+			d, unit, _ := h.humanize(d, r)
+			return next(d) + unit.suffix
+		}
+	}
+}
+
+// WrapStage returns a Stage that replaces the formatted result with the result of fn such as wrapping it in markup.
+func WrapStage(fn func(string) string) Stage {
+	return func(next FormatFunc) FormatFunc {
+		return func(d decimal.Decimal) string {
+			return fn(next(d))
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	for i, tt := range []struct {
+		pipeline *numfmt.Pipeline
+		arg      interface{}
+		expected string
+	}{
+		{numfmt.NewPipeline(nil), 1234567, "1,234,567"},
+		{
+			numfmt.NewPipeline(&numfmt.Formatter{Template: "$n"},
+				numfmt.RoundStage(&numfmt.Rounder{Places: 2}),
+				numfmt.ShiftStage(-3),
+				numfmt.HumanizeStage(numfmt.CompactHumanizer, &numfmt.Rounder{Places: 1}),
+			),
+			"1234567890.123",
+			"$1.2M",
+		},
+		{
+			numfmt.NewPipeline(nil,
+				numfmt.RoundStage(&numfmt.Rounder{Places: 2}),
+				numfmt.ScaleStage(decimal.New(100, 0)),
+				numfmt.WrapStage(func(s string) string { return s + "%" }),
+			),
+			"0.12345",
+			"12%",
+		},
+		{
+			numfmt.NewPipeline(nil,
+				numfmt.TransformStage(func(d decimal.Decimal) decimal.Decimal { return d.Abs() }),
+			),
+			-42,
+			"42",
+		},
+		{numfmt.NewPipeline(nil, numfmt.ShiftStage(2)), "abc", "abc"},
+	} {
+		actual := tt.pipeline.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
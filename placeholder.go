@@ -0,0 +1,57 @@
+package numfmt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// expandPlaceholders returns the template t with the registered templates it includes with {@name} expanded and the
+// {name} placeholders that are in Placeholders replaced by their escaped values. Escaped characters are copied
+// unchanged so \{ is not the start of a placeholder.
+func (f *Formatter) expandPlaceholders(t string) string {
+	t = expandIncludes(t, 0)
+	if len(f.Placeholders) == 0 || !strings.Contains(t, "{") {
+		return t
+	}
+
+	sb := &strings.Builder{}
+	for i := 0; i < len(t); i++ {
+		switch t[i] {
+		case '\\':
+			sb.WriteByte('\\')
+			if i+1 < len(t) {
+				i++
+				sb.WriteByte(t[i])
+			}
+		case '{':
+			end := strings.IndexByte(t[i:], '}')
+			if end > 0 && bracedVerb(t[i:]) == "" {
+				if value, ok := f.Placeholders[t[i+1:i+end]]; ok {
+					sb.WriteString(EscapeTemplate(value))
+					i += end
+					continue
+				}
+			}
+			sb.WriteByte('{')
+		default:
+			sb.WriteByte(t[i])
+		}
+	}
+	return sb.String()
+}
+
+// placeholdersArg converts the value of the Placeholders key of TemplateFunc to a map.
+func placeholdersArg(v interface{}) (map[string]string, error) {
+	switch v := v.(type) {
+	case map[string]string:
+		return v, nil
+	case map[string]interface{}:
+		m := make(map[string]string, len(v))
+		for k, value := range v {
+			m[k] = fmt.Sprint(value)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("invalid Placeholders: %v", v)
+	}
+}
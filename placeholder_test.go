@@ -0,0 +1,43 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterPlaceholders(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Template: "-{sym}n", Placeholders: map[string]string{"sym": "€"}}, "9.45", "€9.45"},
+		{&numfmt.Formatter{Template: "-n {unit}", Placeholders: map[string]string{"unit": "kg"}}, "-12", "-12 kg"},
+		{
+			&numfmt.Formatter{Template: "-{sym}n {unit}", Placeholders: map[string]string{"sym": "$", "unit": "per unit"}},
+			"9.45",
+			"$9.45 per unit",
+		},
+		{&numfmt.Formatter{Template: "-n {unit}", Placeholders: map[string]string{"unit": "finite"}}, "1", "1 finite"},
+		{&numfmt.Formatter{Template: "-n {spare}", Placeholders: map[string]string{"unit": "kg"}}, "1", "1 {spare}"},
+		{&numfmt.Formatter{Template: `-n \{sym}`, Placeholders: map[string]string{"sym": "kg"}}, "1", "1 {sym}"},
+		{&numfmt.Formatter{Template: "-n {sym}"}, "1", "1 {sym}"},
+		{
+			&numfmt.Formatter{Template: "-n", NegativeTemplate: "({sym}n)", Placeholders: map[string]string{"sym": "£"}},
+			"-5",
+			"(£5)",
+		},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterPlaceholdersParse(t *testing.T) {
+	f := &numfmt.Formatter{Template: "-n {unit}", Placeholders: map[string]string{"unit": "in"}}
+	d, err := f.Parse("-1,234.5 in")
+	assert.NoError(t, err)
+	assert.Equal(t, "-1234.5", d.String())
+}
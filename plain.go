@@ -0,0 +1,51 @@
+package numfmt
+
+import (
+	"strings"
+)
+
+// FormatPlain formats v as a plain machine-readable number such as -1234.50. Shift, Scale, rounding, and the minimum
+// decimal places of f are applied as in Format but the number is written without grouping, templates, units, or
+// localized digits using '.' as the decimal separator and an ASCII '-' for negative numbers. This allows the same
+// Formatter to produce both the displayed value and the value written to a CSV file or API field. nil is written as
+// "" and inputs that are not numbers are written as is.
+func (f *Formatter) FormatPlain(v interface{}) string {
+	pf := f.plainFormatter()
+	v, isNil := derefInput(v)
+	if isNil {
+		return ""
+	}
+	fs, s, ok := pf.state(v)
+	if !ok {
+		return s
+	}
+
+	sb := &strings.Builder{}
+	if fs.neg {
+		sb.WriteByte('-')
+	}
+	sb.WriteString(fs.intPart)
+	if fs.fracPart != "" {
+		sb.WriteByte('.')
+		sb.WriteString(fs.fracPart)
+	}
+	return sb.String()
+}
+
+// plainFormatter returns a Formatter with only the options of f that change the value or the number of decimal places
+// written.
+func (f *Formatter) plainFormatter() *Formatter {
+	return &Formatter{
+		Rounder:          f.Rounder,
+		Shift:            f.Shift,
+		RoundBeforeShift: f.RoundBeforeShift,
+		Scale:            f.Scale,
+		MinDecimalPlaces: f.MinDecimalPlaces,
+		PreserveScale:    f.PreserveScale,
+		Currency:         f.Currency,
+		SanitizeInput:    f.SanitizeInput,
+		FloatConversion:  f.FloatConversion,
+		FloatDigits:      f.FloatDigits,
+		PreFormat:        f.PreFormat,
+	}
+}
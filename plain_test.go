@@ -0,0 +1,37 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFormatPlain(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{}, 1234567, "1234567"},
+		{&numfmt.Formatter{}, -1234.5, "-1234.5"},
+		{&numfmt.Formatter{}, "0.000123", "0.000123"},
+		{&numfmt.Formatter{GroupSeparator: ".", DecimalSeparator: ","}, 1234.5, "1234.5"},
+		{&numfmt.Formatter{MinusSign: "−", Template: "(n)"}, -42, "-42"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}, MinDecimalPlaces: 2}, 1234.5678, "1234.57"},
+		{&numfmt.Formatter{MinDecimalPlaces: 2}, 7, "7.00"},
+		{&numfmt.Formatter{Shift: 2, Rounder: &numfmt.Rounder{Places: 1}}, 0.12345, "12.3"},
+		{&numfmt.Formatter{Scale: decimal.New(1, -3)}, 1500, "1.5"},
+		{&numfmt.Formatter{Currency: "USD"}, 1234.5, "1234.50"},
+		{&numfmt.Formatter{Currency: "JPY"}, 1234.5, "1235"},
+		{&numfmt.Formatter{Humanizer: numfmt.SIHumanizer}, 1234567, "1234567"},
+		{&numfmt.Formatter{Digits: numfmt.ArabicIndicDigits, Template: "n kg"}, 123, "123"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 1}}, -0.04, "0"},
+		{&numfmt.Formatter{Nil: "n/a"}, nil, ""},
+		{&numfmt.Formatter{}, "abc", "abc"},
+	} {
+		actual := tt.formatter.FormatPlain(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,76 @@
+package numfmt
+
+import (
+	"errors"
+	"fmt"
+
+	money "github.com/Rhymond/go-money"
+	"github.com/shopspring/decimal"
+)
+
+// Quantize returns v as the number f displays. PreFormat, MagnitudeRules, Shift, Scale, DisplayMax, and Rounder are
+// applied the same as Format so the result can be stored or compared with the displayed value. e.g. 0.12345 => 12.35
+// with Shift 2 and Rounder places of 2. Humanizer, Scientific, Fraction, and Base do not change the result. Numbers
+// exceeding MaxIntegerDigits are clamped with OverflowClamp and return ErrIntegerOverflow with OverflowError. If v is
+// not a number an error is returned.
+func (f *Formatter) Quantize(v interface{}) (decimal.Decimal, error) {
+	d, s, ok := f.toDecimal(v)
+	if !ok {
+		return decimal.Decimal{}, fmt.Errorf("cannot quantize %q: not a number", s)
+	}
+	if m, ok := v.(*money.Money); ok {
+		f = f.forCurrency(m.Currency())
+	}
+	return f.quantize(d)
+}
+
+// ErrIntegerOverflow is returned by Quantize for numbers exceeding MaxIntegerDigits when IntegerOverflow is
+// OverflowError.
+var ErrIntegerOverflow = errors.New("integer overflow")
+
+func (f *Formatter) quantize(d decimal.Decimal) (decimal.Decimal, error) {
+	if f.PreFormat != nil {
+		d = f.PreFormat(d)
+	}
+
+	if len(f.MagnitudeRules) > 0 {
+		abs := d.Abs()
+		for _, rule := range f.MagnitudeRules {
+			if abs.Cmp(rule.Min) >= 0 {
+				return rule.Formatter.quantize(d)
+			}
+		}
+	}
+
+	f = f.compiled()
+
+	rounder := f.rounder()
+	if f.RoundBeforeShift && rounder != nil {
+		d = rounder.Round(d)
+		rounder = nil
+	}
+
+	d = f.scale(d)
+
+	if !f.DisplayMax.IsZero() && d.Abs().Cmp(f.DisplayMax) > 0 {
+		d = f.DisplayMax.Mul(decimal.New(int64(d.Sign()), 0))
+	}
+
+	if rounder != nil {
+		d = rounder.Round(d)
+	}
+
+	if f.MaxIntegerDigits > 0 {
+		limit := decimal.New(1, int32(f.MaxIntegerDigits))
+		if d.Abs().Cmp(limit) >= 0 {
+			switch f.IntegerOverflow {
+			case OverflowClamp:
+				d = limit.Sub(decimal.New(1, 0)).Mul(decimal.New(int64(d.Sign()), 0))
+			case OverflowError:
+				return decimal.Decimal{}, ErrIntegerOverflow
+			}
+		}
+	}
+
+	return d, nil
+}
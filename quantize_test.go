@@ -0,0 +1,43 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	money "github.com/Rhymond/go-money"
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterQuantize(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{}, "1234.5678", "1234.5678"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}, 1234.5678, "1234.57"},
+		{&numfmt.Formatter{Shift: 2, Rounder: &numfmt.Rounder{Places: 2}}, "0.12345", "12.35"},
+		{&numfmt.Formatter{Shift: 2, Rounder: &numfmt.Rounder{Places: 2}, RoundBeforeShift: true}, "0.12345", "12"},
+		{&numfmt.Formatter{Scale: decimal.RequireFromString("2.54"), Rounder: &numfmt.Rounder{Places: 1}}, 10, "25.4"},
+		{&numfmt.Formatter{DisplayMax: decimal.New(999, 0)}, -1500, "-999"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Rules: numfmt.DefaultPrecisionRules}}, "12.345", "12.3"},
+		{
+			&numfmt.Formatter{MagnitudeRules: []numfmt.MagnitudeRule{
+				{Min: decimal.New(1000, 0), Formatter: &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}}},
+			}},
+			"1234.5", "1235",
+		},
+		{&numfmt.Formatter{}, "1,234.5", "1234.5"},
+		{&numfmt.Formatter{}, money.New(12345, money.USD), "123.45"},
+		{&numfmt.Formatter{}, money.New(12345, money.KWD), "12.345"},
+	} {
+		actual, err := tt.formatter.Quantize(tt.arg)
+		if assert.NoErrorf(t, err, "%d", i) {
+			assert.Equalf(t, tt.expected, actual.String(), "%d", i)
+		}
+	}
+
+	_, err := (&numfmt.Formatter{}).Quantize("abc")
+	assert.Error(t, err)
+}
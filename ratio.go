@@ -0,0 +1,66 @@
+package numfmt
+
+import (
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
+
+// ZeroDenominator is what FormatRatio and FormatPercentOf write when the denominator is zero.
+type ZeroDenominator int
+
+const (
+	ZeroDenominatorUndefined ZeroDenominator = iota // Write Undefined. 0 divided by 0 is written as zero.
+	ZeroDenominatorZero                             // Write zero as Format would.
+	ZeroDenominatorBlank                            // Write "".
+)
+
+// FormatRatio formats numerator divided by denominator with f including Shift so a percent Formatter writes the ratio
+// as a percentage. e.g. 1 and 3 => 0.333 with 3 decimal places or 33.3% with NewPercentFormatter. The division keeps
+// enough digits for the decimal places f writes so that small ratios are not lost. Denominators within
+// ZeroDenominatorTolerance of zero are handled according to ZeroDenominator. If numerator or denominator is not a
+// number it is written as Format would write it.
+func (f *Formatter) FormatRatio(numerator, denominator interface{}) string {
+	n, s, ok := f.toDecimal(numerator)
+	if !ok {
+		return s
+	}
+	d, s, ok := f.toDecimal(denominator)
+	if !ok {
+		return s
+	}
+
+	if d.Abs().Cmp(f.ZeroDenominatorTolerance.Abs()) <= 0 {
+		switch f.ZeroDenominator {
+		case ZeroDenominatorZero:
+			return f.formatDecimal(decimal.Decimal{})
+		case ZeroDenominatorBlank:
+			return ""
+		}
+		if n.IsZero() {
+			return f.formatDecimal(n)
+		}
+		return f.undefined()
+	}
+
+	return f.formatDecimal(n.DivRound(d, f.ratioPrecision(n, d)))
+}
+
+// ratioPrecision returns the decimal places to divide n by d to. It keeps at least decimal.DivisionPrecision
+// significant digits of the ratio and one more decimal place than f rounds to after Shift.
+func (f *Formatter) ratioPrecision(n, d decimal.Decimal) int32 {
+	magnitude := numDigits(n) + n.Exponent() - numDigits(d) - d.Exponent()
+	precision := int32(decimal.DivisionPrecision) - magnitude
+	if r := f.rounder(); r != nil && r.Places+f.Shift+1 > precision {
+		precision = r.Places + f.Shift + 1
+	}
+	if precision < 0 {
+		precision = 0
+	}
+	return precision
+}
+
+// numDigits returns the number of digits of the coefficient of d.
+func numDigits(d decimal.Decimal) int32 {
+	return int32(len(new(big.Int).Abs(d.Coefficient()).String()))
+}
@@ -0,0 +1,39 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFormatRatio(t *testing.T) {
+	for i, tt := range []struct {
+		formatter   *numfmt.Formatter
+		numerator   interface{}
+		denominator interface{}
+		expected    string
+	}{
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 3}}, 1, 3, "0.333"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}, 2, 3, "0.67"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}, -10, 4, "-2.5"},
+		{numfmt.NewPercentFormatter(), 1, 8, "12.5%"},
+		{&numfmt.Formatter{Shift: 2, Rounder: &numfmt.Rounder{Places: 1}, Template: "-n%"}, 1, 3, "33.3%"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 25}}, 1, 3, "0.3333333333333333333333333"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}}, "1", "3e20", "0.00000000000000000000333"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 3}}, "2e30", "3", "667,000,000,000,000,000,000,000,000,000"},
+		{&numfmt.Formatter{}, 0, 0, "0"},
+		{&numfmt.Formatter{}, 1, 0, "—"},
+		{&numfmt.Formatter{Undefined: "n/a"}, -1, 0.0, "n/a"},
+		{&numfmt.Formatter{ZeroDenominator: numfmt.ZeroDenominatorZero, MinDecimalPlaces: 2}, 5, 0, "0.00"},
+		{&numfmt.Formatter{ZeroDenominator: numfmt.ZeroDenominatorBlank}, 5, 0, ""},
+		{&numfmt.Formatter{ZeroDenominatorTolerance: decimal.New(1, -9)}, 1, 1e-12, "—"},
+		{&numfmt.Formatter{ZeroDenominatorTolerance: decimal.New(1, -9), Rounder: &numfmt.Rounder{Places: 0}}, 1, 1e-6, "1,000,000"},
+		{&numfmt.Formatter{}, "abc", 2, "abc"},
+		{&numfmt.Formatter{}, 2, nil, ""},
+	} {
+		actual := tt.formatter.FormatRatio(tt.numerator, tt.denominator)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
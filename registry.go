@@ -0,0 +1,71 @@
+package numfmt
+
+import (
+	"sync"
+)
+
+var registry struct {
+	mux        sync.RWMutex
+	formatters map[string]*Formatter
+}
+
+// Register makes f available by name to Lookup. Registering a name that is already registered replaces the previous
+// Formatter. f must not be changed after it is registered. Register is concurrency safe.
+func Register(name string, f *Formatter) {
+	registry.mux.Lock()
+	defer registry.mux.Unlock()
+
+	if registry.formatters == nil {
+		registry.formatters = make(map[string]*Formatter)
+	}
+	registry.formatters[name] = f
+	resetArgFormatters()
+}
+
+// Lookup returns the Formatter registered as name. ok is false if name is not registered. Lookup is concurrency safe.
+func Lookup(name string) (f *Formatter, ok bool) {
+	registry.mux.RLock()
+	defer registry.mux.RUnlock()
+
+	f, ok = registry.formatters[name]
+	return f, ok
+}
+
+// presets are the built-in presets available to TemplateFunc.
+var presets = map[string]func() *Formatter{
+	"usd":       NewUSDFormatter,
+	"inr":       NewINRFormatter,
+	"percent":   NewPercentFormatter,
+	"french":    NewFrenchFormatter,
+	"european":  NewEuropeanFormatter,
+	"nordic":    NewNordicFormatter,
+	"swiss":     NewSwissFormatter,
+	"si":        NewSIFormatter,
+	"compact":   NewCompactFormatter,
+	"finance":   NewFinanceFormatter,
+	"bytes":     NewBytesFormatter,
+	"bitrate":   NewBitRateFormatter,
+	"bps":       NewBasisPointsFormatter,
+	"fx":        func() *Formatter { return NewFXFormatter(5, "") },
+	"btc":       func() *Formatter { return NewCryptoFormatter("BTC", 8, false) },
+	"eth":       func() *Formatter { return NewCryptoFormatter("ETH", 18, false) },
+	"permille":  NewPerMilleFormatter,
+	"ordinal":   NewOrdinalFormatter,
+	"latitude":  NewLatitudeFormatter,
+	"longitude": NewLongitudeFormatter,
+	"clock":     func() *Formatter { return NewClockFormatter(0) },
+	"go":        func() *Formatter { return NewGoLiteralFormatter(10) },
+	"rate":      func() *Formatter { return NewRateFormatter(CompactHumanizer, "/s") },
+	"bytes/s":   func() *Formatter { return NewRateFormatter(BytesHumanizer, "/s") },
+}
+
+// lookupPreset returns a copy of the Formatter registered as name or of the built-in preset name.
+func lookupPreset(name string) (*Formatter, bool) {
+	if f, ok := Lookup(name); ok {
+		return f.clone(), true
+	}
+	if newFn, ok := presets[name]; ok {
+		return newFn(), true
+	}
+	return nil, false
+}
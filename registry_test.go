@@ -0,0 +1,48 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAndLookup(t *testing.T) {
+	_, ok := numfmt.Lookup("test-registry-missing")
+	assert.False(t, ok)
+
+	numfmt.Register("test-registry-percent1", &numfmt.Formatter{
+		Shift:    2,
+		Rounder:  &numfmt.Rounder{Places: 1},
+		Template: "-n%",
+	})
+
+	f, ok := numfmt.Lookup("test-registry-percent1")
+	require.True(t, ok)
+	assert.Equal(t, "12.3%", f.Format("0.12345"))
+
+	numfmt.Register("test-registry-percent1", numfmt.NewPercentFormatter())
+	f, ok = numfmt.Lookup("test-registry-percent1")
+	require.True(t, ok)
+	assert.Equal(t, "12.345%", f.Format("0.12345"))
+}
+
+func TestTemplateFuncRegisteredPreset(t *testing.T) {
+	registered := &numfmt.Formatter{
+		Rounder:  &numfmt.Rounder{Places: 1},
+		Template: `n u\nits`,
+	}
+	numfmt.Register("test-registry-units", registered)
+
+	actual, err := numfmt.TemplateFunc("Preset", "test-registry-units", "1234.56")
+	require.NoError(t, err)
+	assert.Equal(t, "1,234.6 units", actual)
+
+	actual, err = numfmt.TemplateFunc("Preset", "test-registry-units", "GroupSeparator", " ", "1234.56")
+	require.NoError(t, err)
+	assert.Equal(t, "1 234.6 units", actual)
+
+	// The registered formatter is not modified.
+	assert.Equal(t, "1,234.6 units", registered.Format("1234.56"))
+}
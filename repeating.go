@@ -0,0 +1,81 @@
+package numfmt
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Repeating is how a Formatter writes the repeating digits of a *big.Rat such as 1/3.
+type Repeating int
+
+const (
+	RepeatingNone        Repeating = iota // Round the number with Rounder.
+	RepeatingParentheses                  // Surround the repeating digits with parentheses. e.g. 0.(3)
+	RepeatingOverline                     // Follow each repeating digit with a combining overline. e.g. 0.3̅
+)
+
+// maxRepeatingDigits is the maximum number of decimal places written for a repeating decimal. Numbers with longer
+// expansions are rounded as if Repeating was RepeatingNone.
+const maxRepeatingDigits = 64
+
+// repeatingState returns the formatState for r written with its repeating digits marked. ok is false if r has a
+// terminating decimal expansion or its expansion is longer than maxRepeatingDigits.
+func (f *Formatter) repeatingState(r *big.Rat) (*formatState, bool) {
+	if !f.Scale.IsZero() {
+		scale, _ := new(big.Rat).SetString(f.Scale.String())
+		r = new(big.Rat).Mul(r, scale)
+	}
+	num := new(big.Int).Abs(r.Num())
+	den := new(big.Int).Set(r.Denom())
+	if f.Shift > 0 {
+		num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(f.Shift)), nil))
+	} else if f.Shift < 0 {
+		den.Mul(den, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-f.Shift)), nil))
+	}
+
+	intPart, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	ten := big.NewInt(10)
+	digit := new(big.Int)
+	seen := make(map[string]int)
+	digits := make([]byte, 0, maxRepeatingDigits)
+	for rem.Sign() != 0 {
+		key := rem.String()
+		if pos, ok := seen[key]; ok {
+			fs := &formatState{
+				f:        f,
+				neg:      r.Sign() < 0,
+				intPart:  intPart.String(),
+				fracPart: string(digits[:pos]),
+				repeat:   string(digits[pos:]),
+
+				fillIndex: -1,
+			}
+			return fs, true
+		}
+		if len(digits) == maxRepeatingDigits {
+			return nil, false
+		}
+		seen[key] = len(digits)
+
+		rem.Mul(rem, ten)
+		digit.QuoRem(rem, den, rem)
+		digits = append(digits, byte('0'+digit.Int64()))
+	}
+
+	return nil, false
+}
+
+// writeRepeating writes the repeating digits repeat.
+func (f *Formatter) writeRepeating(sb *strings.Builder, repeat string) {
+	switch f.Repeating {
+	case RepeatingOverline:
+		for i := 0; i < len(repeat); i++ {
+			writeDigits(sb, repeat[i:i+1], f.digits)
+			sb.WriteString("̅")
+		}
+	default:
+		sb.WriteByte('(')
+		writeDigits(sb, repeat, f.digits)
+		sb.WriteByte(')')
+	}
+}
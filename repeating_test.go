@@ -0,0 +1,35 @@
+package numfmt_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterRepeating(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses}, big.NewRat(1, 3), "0.(3)"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses}, big.NewRat(1, 6), "0.1(6)"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses}, big.NewRat(1, 7), "0.(142857)"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses}, big.NewRat(-22, 7), "-3.(142857)"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses}, big.NewRat(100000, 3), "33,333.(3)"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses}, big.NewRat(1, 4), "0.25"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses}, big.NewRat(4, 2), "2"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses, Shift: 2, Template: "n%"}, big.NewRat(1, 3), "33.(3)%"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses, Rounder: &numfmt.Rounder{Places: 2}}, big.NewRat(1, 97), "0.01"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingOverline}, big.NewRat(1, 3), "0.3̅"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingOverline}, big.NewRat(5, 11), "0.4̅5̅"},
+		{&numfmt.Formatter{Repeating: numfmt.RepeatingParentheses, Digits: numfmt.DevanagariDigits}, big.NewRat(2, 3), "०.(६)"},
+		{&numfmt.Formatter{}, big.NewRat(1, 3), "0.3333333333333333"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}, big.NewRat(2, 3), "0.67"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,36 @@
+package numfmt
+
+// Result is the result of FormatResult. It describes what formatting did to the number so callers can drive styling
+// and tooltips without inspecting the text.
+type Result struct {
+	Text        string // The same string as Format returns.
+	Number      bool   // False if v is not a number. Text is then v converted to a string.
+	Negative    bool   // The number is written as negative.
+	Zero        bool   // The number is written as zero.
+	Rounded     bool   // Rounding changed the value. e.g. 1234.5 written as 1,235.
+	Clamped     bool   // The number was capped by DisplayMax, MaxIntegerDigits, ClampMin, or ClampMax.
+	Overflow    bool   // The number has more integer digits than MaxIntegerDigits.
+	Shortened   bool   // The text was shortened to fit MaxWidth.
+	ScaleSuffix string // Suffix of the Humanizer unit the number was scaled to. e.g. "K" or " MB".
+}
+
+// FormatResult formats v like Format and returns the string with metadata about the formatting.
+func (f *Formatter) FormatResult(v interface{}) Result {
+	fs, s, ok := f.state(v)
+	if !ok {
+		return Result{Text: s}
+	}
+
+	text, shortened := f.finishShortened(fs, v)
+	return Result{
+		Text:        text,
+		Number:      true,
+		Negative:    fs.neg,
+		Zero:        fs.zero,
+		Rounded:     fs.rounded,
+		Clamped:     fs.clamped,
+		Overflow:    fs.overflow,
+		Shortened:   shortened,
+		ScaleSuffix: fs.scaleSuffix,
+	}
+}
@@ -0,0 +1,55 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFormatResult(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  numfmt.Result
+	}{
+		{&numfmt.Formatter{}, 1234, numfmt.Result{Text: "1,234", Number: true}},
+		{&numfmt.Formatter{}, "-1234.5", numfmt.Result{Text: "-1,234.5", Number: true, Negative: true}},
+		{&numfmt.Formatter{}, 0, numfmt.Result{Text: "0", Number: true, Zero: true}},
+		{&numfmt.Formatter{}, "abc", numfmt.Result{Text: "abc"}},
+		{
+			&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}},
+			"1234.5",
+			numfmt.Result{Text: "1,235", Number: true, Rounded: true},
+		},
+		{
+			&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}},
+			"-0.2",
+			numfmt.Result{Text: "0", Number: true, Zero: true, Rounded: true},
+		},
+		{
+			numfmt.NewCompactFormatter(),
+			1234567,
+			numfmt.Result{Text: "1.2M", Number: true, Rounded: true, ScaleSuffix: "M"},
+		},
+		{
+			numfmt.NewBytesFormatter(),
+			1500000,
+			numfmt.Result{Text: "1.5 MB", Number: true, ScaleSuffix: " MB"},
+		},
+		{
+			&numfmt.Formatter{DisplayMax: decimal.New(99, 0)},
+			150,
+			numfmt.Result{Text: "99+", Number: true, Clamped: true},
+		},
+		{
+			&numfmt.Formatter{MaxWidth: 5},
+			"1234567",
+			numfmt.Result{Text: "1.2M", Number: true, Shortened: true},
+		},
+	} {
+		actual := tt.formatter.FormatResult(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
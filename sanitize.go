@@ -0,0 +1,69 @@
+package numfmt
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+)
+
+// sanitizeInput converts s to a decimal.Decimal as described by Formatter.SanitizeInput.
+func (f *Formatter) sanitizeInput(s string) (decimal.Decimal, bool) {
+	s = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Sc, r) || unicode.IsSpace(r) || r == '_' || r == '+' {
+			return -1
+		}
+		return r
+	}, s)
+	if s == "" {
+		return decimal.Decimal{}, false
+	}
+
+	if d, err := f.inputFormatter.ParseStrict(s); err == nil {
+		return d, true
+	}
+
+	d, err := decimal.NewFromString(normalizeSeparators(s))
+	if err != nil {
+		return decimal.Decimal{}, false
+	}
+	return d, true
+}
+
+// normalizeSeparators returns s with group separators removed and the decimal separator replaced with '.'. Which of '.'
+// and ',' is the decimal separator is guessed. If both occur the last one is the decimal separator. If only one occurs
+// it is the decimal separator when it occurs once and is not followed by exactly three digits. A single '.' followed by
+// three digits is also treated as the decimal separator. e.g. 1.234,5 => 1234.5, 1,234 => 1234, and 1.234 => 1.234.
+func normalizeSeparators(s string) string {
+	lastDot := strings.LastIndexByte(s, '.')
+	lastComma := strings.LastIndexByte(s, ',')
+
+	decimalIndex := -1
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		decimalIndex = lastDot
+		if lastComma > lastDot {
+			decimalIndex = lastComma
+		}
+	case lastDot >= 0:
+		if strings.Count(s, ".") == 1 {
+			decimalIndex = lastDot
+		}
+	case lastComma >= 0:
+		if strings.Count(s, ",") == 1 && len(s)-lastComma-1 != 3 {
+			decimalIndex = lastComma
+		}
+	}
+
+	sb := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		switch {
+		case i == decimalIndex:
+			sb.WriteByte('.')
+		case s[i] == '.' || s[i] == ',':
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,33 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterSanitizeInput(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{SanitizeInput: true}, " $1 234,50 ", "1,234.5"},
+		{&numfmt.Formatter{SanitizeInput: true}, "€1.234.567,89", "1,234,567.89"},
+		{&numfmt.Formatter{SanitizeInput: true}, "1,234", "1,234"},
+		{&numfmt.Formatter{SanitizeInput: true}, "1.234", "1.234"},
+		{&numfmt.Formatter{SanitizeInput: true}, "1,5", "1.5"},
+		{&numfmt.Formatter{SanitizeInput: true}, "1_000_000", "1,000,000"},
+		{&numfmt.Formatter{SanitizeInput: true}, "+42", "42"},
+		{&numfmt.Formatter{SanitizeInput: true}, "-£ 12.50", "-12.5"},
+		{&numfmt.Formatter{SanitizeInput: true}, "1 234 567", "1,234,567"},
+		{&numfmt.Formatter{SanitizeInput: true, GroupSeparator: ".", DecimalSeparator: ","}, "$1.234", "1.234"},
+		{&numfmt.Formatter{SanitizeInput: true}, "$ abc", "$ abc"},
+		{&numfmt.Formatter{SanitizeInput: true}, " $ ", " $ "},
+		{&numfmt.Formatter{}, " $1 234,50 ", " $1 234,50 "},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
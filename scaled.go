@@ -0,0 +1,115 @@
+package numfmt
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// FormatScaled formats the number mantissa × 10^exponent. e.g. FormatScaled(123456, -2) => 1,234.56. It is intended
+// for numbers stored as an integer and a decimal exponent such as prices in minor units or telemetry values. Unless f
+// uses options other than rounding to Places and padding decimal places the digits of mantissa are written directly
+// without converting the number to a decimal.Decimal. InputLimits are checked before the digits are written.
+func (f *Formatter) FormatScaled(mantissa int64, exponent int32) string {
+	f = f.compiled()
+	if f.InputLimits != nil {
+		digits := len(strconv.FormatInt(mantissa, 10))
+		if mantissa < 0 {
+			digits--
+		}
+		if !f.InputLimits.allowsExponent(int64(digits) + int64(exponent) - 1) {
+			return f.exceededText()
+		}
+	}
+	if !f.scaledFastPath {
+		return f.formatDecimal(decimal.New(mantissa, exponent))
+	}
+	return f.finish(f.scaledState(mantissa, exponent), nil) // v is only used for MaxWidth which is not set.
+}
+
+// scaledState returns the formatState for mantissa × 10^exponent. It is the same as decimalState for the decimal but
+// must only be used when f.scaledFastPath is true.
+func (f *Formatter) scaledState(mantissa int64, exponent int32) *formatState {
+	neg := mantissa < 0
+	abs := uint64(mantissa)
+	if neg {
+		abs = -abs
+	}
+	num := strconv.FormatUint(abs, 10)
+
+	scale := 0
+	if exponent < 0 {
+		scale = int(-exponent)
+	}
+
+	var intPart, fracPart string
+	switch {
+	case abs == 0:
+		intPart = "0"
+	case exponent >= 0:
+		intPart = num + strings.Repeat("0", int(exponent))
+	default:
+		if len(num) <= scale {
+			num = strings.Repeat("0", scale-len(num)+1) + num
+		}
+		intPart, fracPart = num[:len(num)-scale], num[len(num)-scale:]
+	}
+
+	var rounded bool
+	if r := f.rounder(); r != nil && scale > int(r.Places) {
+		places := int(r.Places)
+		if len(fracPart) > places {
+			intPart, fracPart, rounded = roundDigits(intPart, fracPart, places)
+		}
+		scale = places
+	}
+
+	fracPart = strings.TrimRight(fracPart, "0")
+	zero := fracPart == "" && strings.Trim(intPart, "0") == ""
+	if zero {
+		neg = false
+	}
+
+	minDecimalPlaces := int(f.minDecimalPlaces())
+	if f.PreserveScale && scale > minDecimalPlaces {
+		minDecimalPlaces = scale
+	}
+	if len(fracPart) < minDecimalPlaces {
+		fracPart += strings.Repeat("0", minDecimalPlaces-len(fracPart))
+	}
+
+	return &formatState{
+		f:        f,
+		neg:      neg,
+		zero:     zero,
+		intPart:  intPart,
+		fracPart: fracPart,
+		rounded:  rounded,
+
+		fillIndex: -1,
+	}
+}
+
+// roundDigits rounds the number with the ASCII digits intPart and fracPart half away from zero to places decimal
+// places. fracPart must have more than places digits. rounded reports whether the value changed.
+func roundDigits(intPart, fracPart string, places int) (roundedInt, roundedFrac string, rounded bool) {
+	dropped := fracPart[places:]
+	rounded = strings.Trim(dropped, "0") != ""
+	digits := []byte(intPart + fracPart[:places])
+
+	if dropped[0] >= '5' {
+		i := len(digits) - 1
+		for ; i >= 0 && digits[i] == '9'; i-- {
+			digits[i] = '0'
+		}
+		if i >= 0 {
+			digits[i]++
+		} else {
+			digits = append([]byte{'1'}, digits...)
+		}
+	}
+
+	n := len(digits) - places
+	return string(digits[:n]), string(digits[n:]), rounded
+}
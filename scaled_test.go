@@ -0,0 +1,63 @@
+package numfmt_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFormatScaled(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		mantissa  int64
+		exponent  int32
+		expected  string
+	}{
+		{&numfmt.Formatter{}, 123456, -2, "1,234.56"},
+		{&numfmt.Formatter{}, 123456, 2, "12,345,600"},
+		{&numfmt.Formatter{}, 5, -4, "0.0005"},
+		{&numfmt.Formatter{}, -1250, -3, "-1.25"},
+		{&numfmt.Formatter{}, 0, -2, "0"},
+		{&numfmt.Formatter{PreserveScale: true}, 1250, -3, "1.250"},
+		{&numfmt.Formatter{PreserveScale: true}, 0, -2, "0.00"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}, 999999, -4, "100"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}, -12345, -3, "-12.35"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}}, -4, -3, "0"},
+		{numfmt.NewCurrencyFormatter("USD"), 123456, -2, "$1,234.56"},
+		{numfmt.NewCurrencyFormatter("USD"), -5, -1, "-$0.50"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 1}, MarkApproximate: true}, 1234, -3, "≈1.2"},
+		{&numfmt.Formatter{}, math.MinInt64, -18, "-9.223372036854775808"},
+		{&numfmt.Formatter{Shift: 2, Template: "n%"}, 125, -3, "12.5%"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{SignificantDigits: 2}}, 123456, -2, "1,200"},
+		{&numfmt.Formatter{InputLimits: &numfmt.DefaultInputLimits}, 12345, -2, "123.45"},
+		{&numfmt.Formatter{InputLimits: &numfmt.DefaultInputLimits}, 1, 5000, "—"},
+		{&numfmt.Formatter{InputLimits: &numfmt.DefaultInputLimits}, -1, math.MaxInt32, "—"},
+		{&numfmt.Formatter{InputLimits: &numfmt.DefaultInputLimits}, 1, math.MinInt32, "—"},
+		{&numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxIntegerDigits: 3}, Template: "-n%"}, -999, 0, "-999%"},
+		{&numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxIntegerDigits: 3}, Template: "-n%"}, 1000, 0, "—"},
+	} {
+		actual := tt.formatter.FormatScaled(tt.mantissa, tt.exponent)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterFormatScaledMatchesFormat(t *testing.T) {
+	formatters := []*numfmt.Formatter{
+		{},
+		{Rounder: &numfmt.Rounder{Places: 0}},
+		{Rounder: &numfmt.Rounder{Places: 3}, MinDecimalPlaces: 1},
+		{PreserveScale: true, Rounder: &numfmt.Rounder{Places: 2}},
+		numfmt.NewCurrencyFormatter("EUR"),
+	}
+	for _, f := range formatters {
+		for _, mantissa := range []int64{0, 1, -1, 5, 49, 50, -95, 99999, 1234567, -987654321, math.MaxInt64} {
+			for exponent := int32(-12); exponent <= 3; exponent++ {
+				expected := f.Format(decimal.New(mantissa, exponent))
+				assert.Equalf(t, expected, f.FormatScaled(mantissa, exponent), "%v %d %d", f, mantissa, exponent)
+			}
+		}
+	}
+}
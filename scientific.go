@@ -0,0 +1,127 @@
+package numfmt
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Scientific configures when a Formatter switches to scientific notation such as 4.2e-9. Numbers between the
+// thresholds are formatted normally.
+type Scientific struct {
+	// Above is the absolute value at or above which scientific notation is used. Zero disables this threshold.
+	Above decimal.Decimal
+
+	// Below is the absolute value below which non-zero numbers use scientific notation. Zero disables this threshold.
+	Below decimal.Decimal
+
+	// Style is how the exponent is written. Default: ExponentE
+	Style ExponentStyle
+
+	UppercaseE        bool // Write E instead of e with ExponentE. e.g. 1.5E5
+	ForceExponentSign bool // Write a plus sign for non-negative exponents. e.g. 1.5e+5
+	MinExponentDigits int  // Pad the exponent with leading zeros to this many digits. e.g. 1.5e05 with 2
+}
+
+// ExponentStyle is how Scientific writes the exponent.
+type ExponentStyle int
+
+const (
+	ExponentE           ExponentStyle = iota // e.g. 1.23e4
+	ExponentSuperscript                      // Unicode superscript digits. e.g. 1.23 × 10⁴
+	ExponentCaret                            // ASCII fallback for ExponentSuperscript. e.g. 1.23 x 10^4
+)
+
+// superscriptDigits are the Unicode superscript forms of 0 to 9.
+var superscriptDigits = [10]string{"⁰", "¹", "²", "³", "⁴", "⁵", "⁶", "⁷", "⁸", "⁹"}
+
+const (
+	superscriptMinus = "⁻"
+	superscriptPlus  = "⁺"
+)
+
+// exponentPrefix returns what is written before the exponent digits and sign.
+func (s *Scientific) exponentPrefix() string {
+	switch s.Style {
+	case ExponentSuperscript:
+		return " × 10"
+	case ExponentCaret:
+		return " x 10^"
+	default:
+		if s.UppercaseE {
+			return "E"
+		}
+		return "e"
+	}
+}
+
+func (s *Scientific) applies(d decimal.Decimal) bool {
+	if d.IsZero() {
+		return false
+	}
+	abs := d.Abs()
+	return (!s.Above.IsZero() && abs.Cmp(s.Above) >= 0) || (!s.Below.IsZero() && abs.Cmp(s.Below) < 0)
+}
+
+// scientific returns the mantissa of d rounded with r and the exponent suffix. rounded reports whether rounding changed
+// the value. d must not be zero.
+func (f *Formatter) scientific(d decimal.Decimal, r *Rounder) (mantissa decimal.Decimal, exponent string, rounded bool) {
+	exp := magnitude(d)
+	mantissa = d.Shift(-exp)
+	if r != nil {
+		mantissa = r.Round(mantissa)
+		if mantissa.Abs().Cmp(decimal.New(10, 0)) >= 0 {
+			exp++
+			mantissa = r.Round(d.Shift(-exp))
+		}
+		rounded = !mantissa.Shift(exp).Equal(d)
+	}
+
+	return mantissa, f.scientificConfig().exponent(exp, f.digits), rounded
+}
+
+// defaultScientific is used to write exponents when Scientific is not set.
+var defaultScientific = &Scientific{}
+
+func (f *Formatter) scientificConfig() *Scientific {
+	if f.Scientific != nil {
+		return f.Scientific
+	}
+	return defaultScientific
+}
+
+// exponent returns exp written in s.Style with digits.
+func (s *Scientific) exponent(exp int32, digits []string) string {
+	sb := &strings.Builder{}
+	sb.WriteString(s.exponentPrefix())
+
+	neg := exp < 0
+	if neg {
+		exp = -exp
+	}
+	num := strconv.FormatInt(int64(exp), 10)
+	if len(num) < s.MinExponentDigits {
+		num = strings.Repeat("0", s.MinExponentDigits-len(num)) + num
+	}
+
+	if s.Style == ExponentSuperscript {
+		if neg {
+			sb.WriteString(superscriptMinus)
+		} else if s.ForceExponentSign {
+			sb.WriteString(superscriptPlus)
+		}
+		for i := 0; i < len(num); i++ {
+			sb.WriteString(superscriptDigits[num[i]-'0'])
+		}
+		return sb.String()
+	}
+
+	if neg {
+		sb.WriteByte('-')
+	} else if s.ForceExponentSign {
+		sb.WriteByte('+')
+	}
+	writeDigits(sb, num, digits)
+	return sb.String()
+}
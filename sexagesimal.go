@@ -0,0 +1,191 @@
+package numfmt
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Sexagesimal configures a Formatter to write numbers in base 60 as degrees, minutes, and seconds such as
+// 48° 51′ 24″. The sign is written by the template so hemispheres can be written with Template and NegativeTemplate.
+// e.g. Template "n N" and NegativeTemplate "n S". Parse reverses the conversion.
+type Sexagesimal struct {
+	// Places is the number of decimal places of the last component. Default: 0
+	Places int32
+
+	// OmitSeconds writes degrees and decimal minutes such as 48° 51.4′ instead of degrees, minutes, and seconds.
+	OmitSeconds bool
+
+	// ZeroPad writes minutes and seconds with at least two integer digits. e.g. 2° 05′ 03″
+	ZeroPad bool
+
+	// Symbols are written after the degrees, minutes, and seconds. Default: "°", "′", "″"
+	Symbols []string
+
+	// NoSpace writes the components without spaces between them. e.g. 48°51′24″
+	NoSpace bool
+}
+
+// NewLatitudeFormatter returns a Formatter that formats decimal degrees as a latitude with a hemisphere. e.g.
+// 48.8566 => 48° 51′ 24″ N and -33.8688 => 33° 52′ 8″ S.
+func NewLatitudeFormatter() *Formatter {
+	return &Formatter{Sexagesimal: &Sexagesimal{}, Template: "n N", NegativeTemplate: "n S"}
+}
+
+// NewLongitudeFormatter returns a Formatter that formats decimal degrees as a longitude with a hemisphere. e.g.
+// 2.3522 => 2° 21′ 8″ E and -74.006 => 74° 0′ 22″ W.
+func NewLongitudeFormatter() *Formatter {
+	return &Formatter{Sexagesimal: &Sexagesimal{}, Template: "n E", NegativeTemplate: "n W"}
+}
+
+var defaultSexagesimalSymbols = []string{"°", "′", "″"}
+
+// symbol returns the symbol of component i.
+func (sx *Sexagesimal) symbol(i int) string {
+	if i < len(sx.Symbols) {
+		return sx.Symbols[i]
+	}
+	if sx.Symbols != nil {
+		return ""
+	}
+	return defaultSexagesimalSymbols[i]
+}
+
+func (sx *Sexagesimal) separator() string {
+	if sx.NoSpace {
+		return ""
+	}
+	return " "
+}
+
+// components returns the number of components written.
+func (sx *Sexagesimal) components() int {
+	if sx.OmitSeconds {
+		return 2
+	}
+	return 3
+}
+
+// sexagesimalState returns the formatState for d written in base 60. The degrees are the integer digits and the
+// minutes and seconds are written as a common fraction is.
+func (f *Formatter) sexagesimalState(d decimal.Decimal) *formatState {
+	sx := f.Sexagesimal
+	n := sx.components()
+	values, rounded := splitBase60(d.Abs().Mul(decimal.New(60, 0).Pow(decimal.New(int64(n-1), 0))), n, sx.Places)
+
+	fs := &formatState{
+		f:       f,
+		neg:     d.Sign() < 0 && !allZero(values),
+		zero:    allZero(values),
+		intPart: values[0].String(),
+		rounded: rounded,
+
+		fillIndex: -1,
+	}
+
+	sb := &strings.Builder{}
+	sb.WriteString(sx.symbol(0))
+	for i := 1; i < n; i++ {
+		sb.WriteString(sx.separator())
+		places := int32(0)
+		if i == n-1 {
+			places = sx.Places
+		}
+		f.writeBase60Component(sb, values[i], places, sx.ZeroPad)
+		sb.WriteString(sx.symbol(i))
+	}
+	fs.fraction = sb.String()
+
+	return fs
+}
+
+// splitBase60 splits smallest, a non-negative number of the smallest unit, into n components of which each is 60 of
+// the next. smallest is rounded to places decimal places first so that carries propagate to the larger units. e.g.
+// 59.9996″ => 1′. rounded reports whether rounding changed the value.
+func splitBase60(smallest decimal.Decimal, n int, places int32) (values []decimal.Decimal, rounded bool) {
+	unit := decimal.New(60, 0).Pow(decimal.New(int64(n-1), 0))
+	total := smallest.Round(places)
+	rounded = !total.Equal(smallest)
+
+	values = make([]decimal.Decimal, n)
+	for i := 0; i < n-1; i++ {
+		values[i] = total.Div(unit).Truncate(0)
+		total = total.Sub(values[i].Mul(unit))
+		unit = unit.Div(decimal.New(60, 0))
+	}
+	values[n-1] = total
+	return values, rounded
+}
+
+func allZero(values []decimal.Decimal) bool {
+	for _, v := range values {
+		if !v.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// writeBase60Component writes v with places decimal places. If pad is true it is written with at least two integer
+// digits.
+func (f *Formatter) writeBase60Component(sb *strings.Builder, v decimal.Decimal, places int32, pad bool) {
+	s := v.StringFixed(places)
+	intPart, fracPart := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		intPart, fracPart = s[:dot], s[dot+1:]
+	}
+	if pad && len(intPart) < 2 {
+		writeDigits(sb, "0", f.digits)
+	}
+	writeDigits(sb, intPart, f.digits)
+	if fracPart != "" {
+		sb.WriteString(f.decimalSeparator())
+		writeDigits(sb, fracPart, f.digits)
+	}
+}
+
+// sexagesimalParseSymbols are the symbols accepted for each component in addition to the Sexagesimal symbols.
+var sexagesimalParseSymbols = [][]string{{"°", "º", "d"}, {"′", "'", "’", "m"}, {"″", "\"", "”", "''", "s"}}
+
+// consumeSexagesimal consumes degrees, minutes, and seconds such as 48° 51′ 24″ and writes the number of degrees.
+// Trailing components may be omitted.
+func (ps *parseState) consumeSexagesimal() bool {
+	sx := ps.f.Sexagesimal
+	prefix := ps.num.String()
+	value := decimal.Decimal{}
+	unit := decimal.New(1, 0)
+
+	for i := 0; i < len(sexagesimalParseSymbols); i++ {
+		start := ps.pos
+		if i > 0 {
+			ps.skipSpace()
+		}
+
+		ps.num.Reset()
+		if !ps.consumeDigits() {
+			ps.pos = start
+			if i == 0 {
+				return ps.fail(ps.pos, ErrMissingDigits)
+			}
+			break
+		}
+		component, err := decimal.NewFromString(ps.num.String())
+		if err != nil {
+			return ps.fail(start, ErrUnexpectedCharacter)
+		}
+		value = value.Add(component.Div(unit))
+		unit = unit.Mul(decimal.New(60, 0))
+
+		for _, symbol := range append([]string{sx.symbol(i)}, sexagesimalParseSymbols[i]...) {
+			if symbol != "" && strings.HasPrefix(ps.rest(), symbol) {
+				ps.pos += len(symbol)
+				break
+			}
+		}
+	}
+
+	ps.num.Reset()
+	ps.num.WriteString(prefix)
+	ps.num.WriteString(value.String())
+	return true
+}
@@ -0,0 +1,81 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterSexagesimal(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{numfmt.NewLatitudeFormatter(), 48.8566, "48° 51′ 24″ N"},
+		{numfmt.NewLatitudeFormatter(), -33.8688, "33° 52′ 8″ S"},
+		{numfmt.NewLongitudeFormatter(), 2.3522, "2° 21′ 8″ E"},
+		{numfmt.NewLongitudeFormatter(), -74.006, "74° 0′ 22″ W"},
+		{numfmt.NewLatitudeFormatter(), 0, "0° 0′ 0″ N"},
+		{numfmt.NewLatitudeFormatter(), -0.00001, "0° 0′ 0″ N"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{}}, -12.5, "-12° 30′ 0″"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{}}, 10.99999, "11° 0′ 0″"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{Places: 2}}, 48.8566, "48° 51′ 23.76″"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{Places: 1, OmitSeconds: true}}, 48.8566, "48° 51.4′"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{ZeroPad: true, Places: 1}}, 2.0842, "2° 05′ 03.1″"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{Symbols: []string{"d", "m", "s"}, NoSpace: true}}, 1.5, "1d30m0s"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{Places: 1}, DecimalSeparator: ","}, 1.00001, "1° 0′ 0,0″"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{}, Digits: numfmt.ArabicIndicDigits}, 12.5, "١٢° ٣٠′ ٠″"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{}, Rounder: &numfmt.Rounder{Places: 2}}, 12.5, "12° 30′ 0″"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterSexagesimalParse(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		s         string
+		expected  string
+	}{
+		{numfmt.NewLatitudeFormatter(), "48° 51′ 24″ N", "48.8566666666666667"},
+		{numfmt.NewLatitudeFormatter(), "33° 52′ 8″ S", "-33.8688888888888889"},
+		{numfmt.NewLatitudeFormatter(), `33°52'8" S`, "-33.8688888888888889"},
+		{numfmt.NewLatitudeFormatter(), "48° 30′ N", "48.5"},
+		{numfmt.NewLatitudeFormatter(), "48° N", "48"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{}}, "-12° 30′ 36″", "-12.51"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{OmitSeconds: true}}, "48° 51.5′", "48.8583333333333333"},
+		{&numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{Symbols: []string{"d", "m", "s"}, NoSpace: true}}, "1d30m0s", "1.5"},
+	} {
+		actual, err := tt.formatter.Parse(tt.s)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, actual.String(), "%d", i)
+	}
+
+	_, err := numfmt.NewLatitudeFormatter().Parse("N")
+	require.Error(t, err)
+}
+
+func TestFormatterSexagesimalRoundTrip(t *testing.T) {
+	f := &numfmt.Formatter{Sexagesimal: &numfmt.Sexagesimal{Places: 3}}
+	for _, s := range []string{"48.8566", "-74.006", "0.5", "179.999999"} {
+		d := decimal.RequireFromString(s)
+		parsed, err := f.Parse(f.Format(d))
+		require.NoError(t, err)
+		assert.Truef(t, parsed.Sub(d).Abs().LessThan(decimal.New(1, -6)), "%s => %s", s, parsed)
+	}
+}
+
+func TestTemplateFuncSexagesimal(t *testing.T) {
+	actual, err := numfmt.TemplateFunc("SexagesimalPlaces", "1", "SexagesimalOmitSeconds", "true", 48.8566)
+	require.NoError(t, err)
+	assert.Equal(t, "48° 51.4′", actual)
+
+	actual, err = numfmt.TemplateFunc("Preset", "latitude", -33.8688)
+	require.NoError(t, err)
+	assert.Equal(t, "33° 52′ 8″ S", actual)
+}
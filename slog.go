@@ -0,0 +1,18 @@
+//go:build go1.21
+// +build go1.21
+
+package numfmt
+
+import (
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer. The value is logged as the string returned by Format.
+func (l Lazy) LogValue() slog.Value {
+	return slog.StringValue(l.String())
+}
+
+// SlogAttr returns a slog.Attr with key and v formatted by f.
+func (f *Formatter) SlogAttr(key string, v interface{}) slog.Attr {
+	return slog.Any(key, f.Lazy(v))
+}
@@ -0,0 +1,29 @@
+//go:build go1.21
+// +build go1.21
+
+package numfmt_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterSlog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	f := &numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 1}, Template: "n ms"}
+	logger.Info("request", "latency", f.Lazy(12.345), numfmt.NewBytesFormatter().SlogAttr("size", 1500000))
+	assert.Equal(t, "level=INFO msg=request latency=\"12.3 ms\" size=\"1.5 MB\"\n", buf.String())
+}
@@ -0,0 +1,108 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseSpec returns a Formatter configured by spec. spec is a sequence of key=value pairs separated by semicolons.
+// e.g. "group=' ';dec=',';round=2;tmpl=-$n". This allows a complete Formatter to be defined in a single command line
+// flag, environment variable, or struct tag. A value may be surrounded by single quotes to include a semicolon or
+// leading or trailing spaces. Two single quotes in a quoted value are an apostrophe. e.g. group=.
+//
+// The keys are the same as TemplateFunc. The following short aliases are also accepted:
+//   group     GroupSeparator
+//   size      GroupSize
+//   dec       DecimalSeparator
+//   round     RoundPlaces
+//   min       MinDecimalPlaces
+//   shift     Shift
+//   tmpl      Template
+//   neg       NegativeTemplate
+//   preset    Preset
+//   locale    Locale
+func ParseSpec(spec string) (*Formatter, error) {
+	args, err := parseSpecArgs(spec)
+	if err != nil {
+		return nil, err
+	}
+	return newFormatterFromArgs(args)
+}
+
+// specKeyAliases maps the short keys accepted by ParseSpec to TemplateFunc keys.
+var specKeyAliases = map[string]string{
+	"group":  "GroupSeparator",
+	"size":   "GroupSize",
+	"dec":    "DecimalSeparator",
+	"round":  "RoundPlaces",
+	"min":    "MinDecimalPlaces",
+	"shift":  "Shift",
+	"tmpl":   "Template",
+	"neg":    "NegativeTemplate",
+	"preset": "Preset",
+	"locale": "Locale",
+}
+
+// parseSpecArgs parses s as a sequence of semicolon separated key=value pairs into TemplateFunc style args.
+func parseSpecArgs(s string) ([]interface{}, error) {
+	var args []interface{}
+
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		eqIdx := strings.IndexByte(s, '=')
+		if eqIdx == -1 {
+			return nil, fmt.Errorf("missing value for key: %s", s)
+		}
+		key := strings.TrimSpace(s[:eqIdx])
+		s = strings.TrimSpace(s[eqIdx+1:])
+
+		var value string
+		if strings.HasPrefix(s, "'") {
+			var ok bool
+			value, s, ok = cutQuoted(s)
+			if !ok {
+				return nil, fmt.Errorf("unterminated quote for key: %s", key)
+			}
+			s = strings.TrimSpace(s)
+			if s != "" && s[0] != ';' {
+				return nil, fmt.Errorf("unexpected text after quoted value for key: %s", key)
+			}
+		} else {
+			endIdx := strings.IndexByte(s, ';')
+			if endIdx == -1 {
+				endIdx = len(s)
+			}
+			value = strings.TrimSpace(s[:endIdx])
+			s = s[endIdx:]
+		}
+		s = strings.TrimPrefix(s, ";")
+
+		if name, ok := specKeyAliases[key]; ok {
+			key = name
+		}
+		args = append(args, key, value)
+	}
+
+	return args, nil
+}
+
+// cutQuoted returns the value of the single quoted string at the start of s and the text after it. Two single quotes
+// are an escaped single quote. ok is false if the quote is not terminated.
+func cutQuoted(s string) (value, rest string, ok bool) {
+	sb := &strings.Builder{}
+	for i := 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '\'' {
+			sb.WriteByte('\'')
+			i++
+			continue
+		}
+		return sb.String(), s[i+1:], true
+	}
+	return "", "", false
+}
@@ -0,0 +1,47 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSpec(t *testing.T) {
+	for i, tt := range []struct {
+		spec     string
+		arg      interface{}
+		expected string
+	}{
+		{"", "1234.5", "1,234.5"},
+		{"group=' ';dec=',';round=2;tmpl=-$n", "-1234.567", "-$1 234,57"},
+		{"GroupSeparator=.;DecimalSeparator=,", "1234.5", "1.234,5"},
+		{"preset=usd; round=0; min=0", "1234.5", "$1,235"},
+		{"shift=2;tmpl=n%", "0.5", "50%"},
+		{"neg=(n)", "-5", "(5)"},
+		{"size=4", "12345678", "1234,5678"},
+		{"locale=de-DE", "1234.5", "1.234,5"},
+		{"tmpl='n;x'", "1", "1;x"},
+		{"group=''''", "1234567", "1'234'567"},
+		{"tmpl='''n'''", "1", "'1'"},
+		{"preset=swiss", "1234", "1'234"},
+	} {
+		f, err := numfmt.ParseSpec(tt.spec)
+		if assert.NoErrorf(t, err, "%d", i) {
+			assert.Equalf(t, tt.expected, f.Format(tt.arg), "%d", i)
+		}
+	}
+}
+
+func TestParseSpecError(t *testing.T) {
+	for i, spec := range []string{
+		"round",
+		"round=x",
+		"foo=bar",
+		"tmpl='n",
+		"tmpl='n'x",
+	} {
+		_, err := numfmt.ParseSpec(spec)
+		assert.Errorf(t, err, "%d", i)
+	}
+}
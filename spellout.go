@@ -0,0 +1,162 @@
+package numfmt
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/language"
+)
+
+// SpellOutRules are the rules for spelling out numbers as words in a language. Support for a language is added by
+// registering its rules with RegisterSpellOutRules.
+type SpellOutRules struct {
+	// Cardinal returns the words for the non-negative integer with the decimal digits num. num has no leading zeros
+	// other than "0" for zero. ok is false if num is too large to spell out.
+	Cardinal func(num string) (words string, ok bool)
+
+	Minus string // Word written before negative numbers. e.g. "minus"
+	Point string // Word written for the decimal separator. e.g. "point"
+	And   string // Word written between the integer and the cents of a cheque. e.g. "and"
+}
+
+var spellOutRegistry struct {
+	mux     sync.RWMutex
+	tags    []language.Tag
+	rules   []*SpellOutRules
+	matcher language.Matcher
+}
+
+// RegisterSpellOutRules makes rules available to NewSpellOutFormatter for tag. Registering a tag that is already
+// registered replaces the previous rules. rules must not be changed after they are registered. RegisterSpellOutRules
+// is concurrency safe.
+func RegisterSpellOutRules(tag language.Tag, rules *SpellOutRules) {
+	spellOutRegistry.mux.Lock()
+	defer spellOutRegistry.mux.Unlock()
+
+	for i, t := range spellOutRegistry.tags {
+		if t == tag {
+			spellOutRegistry.rules[i] = rules
+			return
+		}
+	}
+	spellOutRegistry.tags = append(spellOutRegistry.tags, tag)
+	spellOutRegistry.rules = append(spellOutRegistry.rules, rules)
+	spellOutRegistry.matcher = language.NewMatcher(spellOutRegistry.tags)
+}
+
+func init() {
+	// English must be registered first so it is the fallback for tags that do not match any rules.
+	RegisterSpellOutRules(language.English, EnglishSpellOutRules)
+	RegisterSpellOutRules(language.French, FrenchSpellOutRules)
+}
+
+// SpellOutFormatter formats numbers as words. e.g. 1234 => one thousand two hundred thirty-four. The zero value is
+// usable and formats English.
+type SpellOutFormatter struct {
+	// Rules are the rules of the language to spell out numbers in. Default: EnglishSpellOutRules
+	Rules *SpellOutRules
+
+	// Cheque formats a number rounded to cents with the cents as a fraction as written on cheques and legal documents.
+	// e.g. 1234.56 => one thousand two hundred thirty-four and 56/100. Otherwise decimal places are written digit by
+	// digit. e.g. 1.25 => one point two five.
+	Cheque bool
+}
+
+// NewSpellOutFormatter returns a SpellOutFormatter for the registered rules that best match tag. If no rules match
+// then English is used.
+func NewSpellOutFormatter(tag language.Tag) *SpellOutFormatter {
+	spellOutRegistry.mux.RLock()
+	defer spellOutRegistry.mux.RUnlock()
+
+	_, i, _ := spellOutRegistry.matcher.Match(tag)
+	return &SpellOutFormatter{Rules: spellOutRegistry.rules[i]}
+}
+
+// NewChequeFormatter returns a SpellOutFormatter that formats a number such as 1234.56 to one thousand two hundred
+// thirty-four and 56/100.
+func NewChequeFormatter() *SpellOutFormatter {
+	return &SpellOutFormatter{Cheque: true}
+}
+
+// Format formats v as words. v can be anything that fmt.Sprint can convert to a parsable number. Numbers too large to
+// spell out are formatted with digits.
+func (f *SpellOutFormatter) Format(v interface{}) string {
+	d, s, ok := toDecimal(v)
+	if !ok {
+		return s
+	}
+	return f.formatDecimal(d)
+}
+
+func (f *SpellOutFormatter) rules() *SpellOutRules {
+	if f.Rules == nil {
+		return EnglishSpellOutRules
+	}
+	return f.Rules
+}
+
+func (f *SpellOutFormatter) formatDecimal(d decimal.Decimal) string {
+	rules := f.rules()
+	if f.Cheque {
+		d = d.Round(2)
+	}
+
+	parts := strings.SplitN(d.Abs().String(), ".", 2)
+	intPart := parts[0]
+	var fracPart string
+	if len(parts) == 2 {
+		fracPart = parts[1]
+	}
+
+	words, ok := rules.Cardinal(intPart)
+	if !ok {
+		return d.String()
+	}
+
+	sb := &strings.Builder{}
+	if d.Sign() < 0 {
+		sb.WriteString(rules.Minus)
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(words)
+
+	if f.Cheque {
+		for len(fracPart) < 2 {
+			fracPart += "0"
+		}
+		sb.WriteByte(' ')
+		sb.WriteString(rules.And)
+		sb.WriteByte(' ')
+		sb.WriteString(fracPart)
+		sb.WriteString("/100")
+	} else if fracPart != "" {
+		sb.WriteByte(' ')
+		sb.WriteString(rules.Point)
+		for i := 0; i < len(fracPart); i++ {
+			digit, _ := rules.Cardinal(fracPart[i : i+1])
+			sb.WriteByte(' ')
+			sb.WriteString(digit)
+		}
+	}
+
+	return sb.String()
+}
+
+// spellOutGroups splits num into groups of three digits as integers. The first group is the least significant.
+func spellOutGroups(num string) []int {
+	groups := make([]int, (len(num)+2)/3)
+	for g := range groups {
+		end := len(num) - g*3
+		start := end - 3
+		if start < 0 {
+			start = 0
+		}
+		n := 0
+		for _, c := range num[start:end] {
+			n = n*10 + int(c-'0')
+		}
+		groups[g] = n
+	}
+	return groups
+}
@@ -0,0 +1,68 @@
+package numfmt
+
+import "strings"
+
+// EnglishSpellOutRules spells out numbers in English with the short scale. e.g. 1234 => one thousand two hundred
+// thirty-four.
+var EnglishSpellOutRules = &SpellOutRules{
+	Cardinal: englishCardinal,
+	Minus:    "minus",
+	Point:    "point",
+	And:      "and",
+}
+
+var englishOnes = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+}
+
+var englishTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+
+// englishScales are the short scale names of each group of three digits.
+var englishScales = []string{
+	"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion", "sextillion", "septillion",
+	"octillion", "nonillion", "decillion",
+}
+
+func englishCardinal(num string) (string, bool) {
+	if num == "0" {
+		return englishOnes[0], true
+	}
+
+	groups := spellOutGroups(num)
+	if len(groups) > len(englishScales) {
+		return "", false
+	}
+
+	var words []string
+	for g := len(groups) - 1; g >= 0; g-- {
+		if groups[g] == 0 {
+			continue
+		}
+		words = append(words, englishHundreds(groups[g]))
+		if englishScales[g] != "" {
+			words = append(words, englishScales[g])
+		}
+	}
+	return strings.Join(words, " "), true
+}
+
+// englishHundreds returns n between 1 and 999 as words.
+func englishHundreds(n int) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, englishOnes[n/100], "hundred")
+		n %= 100
+	}
+
+	switch {
+	case n == 0:
+	case n < 20:
+		words = append(words, englishOnes[n])
+	case n%10 == 0:
+		words = append(words, englishTens[n/10])
+	default:
+		words = append(words, englishTens[n/10]+"-"+englishOnes[n%10])
+	}
+	return strings.Join(words, " ")
+}
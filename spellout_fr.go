@@ -0,0 +1,113 @@
+package numfmt
+
+import "strings"
+
+// FrenchSpellOutRules spells out numbers in French with the long scale and traditional spelling. e.g. 1281 => mille
+// deux cent quatre-vingt-un.
+var FrenchSpellOutRules = &SpellOutRules{
+	Cardinal: frenchCardinal,
+	Minus:    "moins",
+	Point:    "virgule",
+	And:      "et",
+}
+
+var frenchOnes = []string{
+	"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf",
+	"dix", "onze", "douze", "treize", "quatorze", "quinze", "seize", "dix-sept", "dix-huit", "dix-neuf",
+}
+
+var frenchTens = []string{"", "", "vingt", "trente", "quarante", "cinquante", "soixante"}
+
+// frenchScales are the long scale names of each group of three digits. Names other than mille take a plural s.
+var frenchScales = []string{"", "mille", "million", "milliard", "billion", "billiard", "trillion", "trilliard"}
+
+func frenchCardinal(num string) (string, bool) {
+	if num == "0" {
+		return frenchOnes[0], true
+	}
+
+	groups := spellOutGroups(num)
+	if len(groups) > len(frenchScales) {
+		return "", false
+	}
+
+	var words []string
+	for g := len(groups) - 1; g >= 0; g-- {
+		n := groups[g]
+		if n == 0 {
+			continue
+		}
+		switch {
+		case g == 0:
+			words = append(words, frenchHundreds(n, true))
+		case g == 1:
+			// Mille is invariable and is not preceded by un.
+			if n != 1 {
+				words = append(words, frenchHundreds(n, false))
+			}
+			words = append(words, frenchScales[g])
+		default:
+			scale := frenchScales[g]
+			if n > 1 {
+				scale += "s"
+			}
+			words = append(words, frenchHundreds(n, true), scale)
+		}
+	}
+	return strings.Join(words, " "), true
+}
+
+// frenchHundreds returns n between 1 and 999 as words. plural is false when n precedes mille which removes the plural
+// s of cents and quatre-vingts.
+func frenchHundreds(n int, plural bool) string {
+	var words []string
+	if n >= 100 {
+		if n >= 200 {
+			words = append(words, frenchOnes[n/100])
+		}
+		if n%100 == 0 && n >= 200 && plural {
+			words = append(words, "cents")
+		} else {
+			words = append(words, "cent")
+		}
+		n %= 100
+	}
+
+	if n != 0 {
+		words = append(words, frenchTensAndOnes(n, plural))
+	}
+	return strings.Join(words, " ")
+}
+
+// frenchTensAndOnes returns n between 1 and 99 as words.
+func frenchTensAndOnes(n int, plural bool) string {
+	switch {
+	case n < 20:
+		return frenchOnes[n]
+	case n < 70:
+		tens := frenchTens[n/10]
+		switch n % 10 {
+		case 0:
+			return tens
+		case 1:
+			return tens + " et un"
+		default:
+			return tens + "-" + frenchOnes[n%10]
+		}
+	case n < 80:
+		// 70 to 79 count on from soixante.
+		if n == 71 {
+			return "soixante et onze"
+		}
+		return "soixante-" + frenchOnes[n-60]
+	default:
+		// 80 to 99 count on from quatre-vingts.
+		if n == 80 {
+			if plural {
+				return "quatre-vingts"
+			}
+			return "quatre-vingt"
+		}
+		return "quatre-vingt-" + frenchOnes[n-80]
+	}
+}
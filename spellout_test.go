@@ -0,0 +1,100 @@
+package numfmt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestSpellOutFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.SpellOutFormatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.SpellOutFormatter{}, 0, "zero"},
+		{&numfmt.SpellOutFormatter{}, 7, "seven"},
+		{&numfmt.SpellOutFormatter{}, 13, "thirteen"},
+		{&numfmt.SpellOutFormatter{}, 40, "forty"},
+		{&numfmt.SpellOutFormatter{}, 99, "ninety-nine"},
+		{&numfmt.SpellOutFormatter{}, 100, "one hundred"},
+		{&numfmt.SpellOutFormatter{}, 101, "one hundred one"},
+		{&numfmt.SpellOutFormatter{}, 1234, "one thousand two hundred thirty-four"},
+		{&numfmt.SpellOutFormatter{}, 1000000, "one million"},
+		{&numfmt.SpellOutFormatter{}, 2000017, "two million seventeen"},
+		{&numfmt.SpellOutFormatter{}, -5, "minus five"},
+		{&numfmt.SpellOutFormatter{}, "1.25", "one point two five"},
+		{&numfmt.SpellOutFormatter{}, "1e36", "1000000000000000000000000000000000000"},
+		{&numfmt.SpellOutFormatter{}, "abc", "abc"},
+		{numfmt.NewChequeFormatter(), "1234.56", "one thousand two hundred thirty-four and 56/100"},
+		{numfmt.NewChequeFormatter(), 5, "five and 00/100"},
+		{numfmt.NewChequeFormatter(), "0.5", "zero and 50/100"},
+		{numfmt.NewChequeFormatter(), "19.999", "twenty and 00/100"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestNewSpellOutFormatter(t *testing.T) {
+	for i, tt := range []struct {
+		tag      language.Tag
+		arg      interface{}
+		expected string
+	}{
+		{language.English, 21, "twenty-one"},
+		{language.AmericanEnglish, 21, "twenty-one"},
+		{language.Swahili, 21, "twenty-one"},
+		{language.French, 0, "zéro"},
+		{language.French, 17, "dix-sept"},
+		{language.French, 21, "vingt et un"},
+		{language.French, 22, "vingt-deux"},
+		{language.French, 70, "soixante-dix"},
+		{language.French, 71, "soixante et onze"},
+		{language.French, 79, "soixante-dix-neuf"},
+		{language.French, 80, "quatre-vingts"},
+		{language.French, 81, "quatre-vingt-un"},
+		{language.French, 91, "quatre-vingt-onze"},
+		{language.French, 100, "cent"},
+		{language.French, 200, "deux cents"},
+		{language.French, 201, "deux cent un"},
+		{language.French, 1000, "mille"},
+		{language.French, 1281, "mille deux cent quatre-vingt-un"},
+		{language.French, 80000, "quatre-vingt mille"},
+		{language.French, 200000, "deux cent mille"},
+		{language.French, 1000000, "un million"},
+		{language.French, 80000000, "quatre-vingts millions"},
+		{language.French, 2000000000, "deux milliards"},
+		{language.French, -1.5, "moins un virgule cinq"},
+		{language.MustParse("fr-CA"), 3, "trois"},
+	} {
+		actual := numfmt.NewSpellOutFormatter(tt.tag).Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	f := numfmt.NewSpellOutFormatter(language.French)
+	f.Cheque = true
+	assert.Equal(t, "mille deux cent trente-quatre et 56/100", f.Format("1234.56"))
+}
+
+func TestRegisterSpellOutRules(t *testing.T) {
+	tag := language.MustParse("x-binary")
+	numfmt.RegisterSpellOutRules(tag, &numfmt.SpellOutRules{
+		Cardinal: func(num string) (string, bool) {
+			words := make([]string, len(num))
+			for i := range num {
+				words[i] = map[byte]string{'0': "nought", '1': "one"}[num[i]]
+			}
+			return strings.Join(words, "-"), true
+		},
+		Minus: "negative",
+		Point: "dot",
+		And:   "and",
+	})
+
+	f := numfmt.NewSpellOutFormatter(tag)
+	assert.Equal(t, "negative one-nought dot one", f.Format("-10.1"))
+}
@@ -0,0 +1,79 @@
+package numfmt
+
+import (
+	"bufio"
+	"io"
+)
+
+// maxStreamWord is the length in bytes of the longest word FormatStream formats when InputLimits has no MaxLength.
+const maxStreamWord = 1000
+
+// FormatStream reads numbers separated by whitespace from r and writes them formatted by f to w. The whitespace
+// between numbers is copied unchanged so lines and columns are kept. Words that are not numbers are written as Format
+// writes them. Only ASCII whitespace separates numbers. Input and output are buffered so r and w need not be. Words
+// longer than InputLimits.MaxLength, or 1000 bytes if it is not set, are written as Format writes inputs that exceed
+// InputLimits and the rest of the word is discarded without being buffered so that memory use is bounded.
+func (f *Formatter) FormatStream(w io.Writer, r io.Reader) error {
+	f = f.compiled()
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+	maxWord := maxStreamWord
+	if f.InputLimits != nil && f.InputLimits.MaxLength > 0 {
+		maxWord = f.InputLimits.MaxLength
+	}
+	var word []byte
+	discarding := false
+
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+			break
+		}
+
+		if !isASCIISpace(b) {
+			if discarding {
+				continue
+			}
+			if len(word) == maxWord {
+				if _, err := bw.WriteString(f.exceededText()); err != nil {
+					return err
+				}
+				word = word[:0]
+				discarding = true
+				continue
+			}
+			word = append(word, b)
+			continue
+		}
+
+		discarding = false
+		if len(word) > 0 {
+			if _, err := bw.WriteString(f.Format(string(word))); err != nil {
+				return err
+			}
+			word = word[:0]
+		}
+		if err := bw.WriteByte(b); err != nil {
+			return err
+		}
+	}
+
+	if len(word) > 0 {
+		if _, err := bw.WriteString(f.Format(string(word))); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func isASCIISpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,54 @@
+package numfmt_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterFormatStream(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		input     string
+		expected  string
+	}{
+		{&numfmt.Formatter{}, "", ""},
+		{&numfmt.Formatter{}, "1234", "1,234"},
+		{&numfmt.Formatter{}, "1234 5678.5\n-1000000\n", "1,234 5,678.5\n-1,000,000\n"},
+		{&numfmt.Formatter{}, "  1234\t\t99999  \r\n", "  1,234\t\t99,999  \r\n"},
+		{numfmt.NewUSDFormatter(), "1.5\nabc\n-2", "$1.50\nabc\n-$2.00"},
+		{&numfmt.Formatter{Invalid: "n/a"}, "1 x 2", "1 n/a 2"},
+		{&numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxLength: 4}}, "1234 12345 6789", "1,234 — 6,789"},
+		{
+			&numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxLength: 4, Replacement: "#"}},
+			"1234\n" + strings.Repeat("1", 100000) + "\n5", "1,234\n#\n5",
+		},
+		{&numfmt.Formatter{Invalid: "n/a"}, strings.Repeat("9", 1001) + " 1000", "n/a 1,000"},
+		{&numfmt.Formatter{}, strings.Repeat("9", 1001) + " 1000", "— 1,000"},
+		{&numfmt.Formatter{}, strings.Repeat("9", 1000), "9" + strings.Repeat(",999", 333)},
+	} {
+		var buf bytes.Buffer
+		err := tt.formatter.FormatStream(&buf, iotest.OneByteReader(strings.NewReader(tt.input)))
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, buf.String(), "%d", i)
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+func TestFormatterFormatStreamError(t *testing.T) {
+	f := &numfmt.Formatter{}
+	errRead := errors.New("read failed")
+	err := f.FormatStream(&bytes.Buffer{}, errReader{err: errRead})
+	assert.True(t, errors.Is(err, errRead))
+}
@@ -0,0 +1,110 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// FormatStruct formats the numeric fields of the struct v or pointer to struct v. The result maps field names to
+// formatted values. Numeric fields are fields with a numeric kind and decimal.Decimal fields or pointers to them. nil
+// pointers are formatted as the Formatter's Nil.
+//
+// Fields are formatted by the zero value Formatter unless they have a numfmt struct tag. The tag is a spec as parsed by
+// ParseSpec. A field with any other type is formatted if it has a numfmt tag. A field with the tag "-" is skipped.
+//
+//   type Invoice struct {
+//       Total    decimal.Decimal `numfmt:"Preset=usd"`
+//       Discount float64         `numfmt:"Shift=2;RoundPlaces=1;Template=-n%"`
+//       Quantity int
+//       Internal int `numfmt:"-"`
+//   }
+func FormatStruct(v interface{}) (map[string]string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("cannot format nil %v", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot format %T: not a struct", v)
+	}
+
+	fields, err := structFieldsOf(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string, len(fields))
+	for _, sf := range fields {
+		m[sf.name] = sf.formatter.Format(rv.Field(sf.index).Interface())
+	}
+
+	return m, nil
+}
+
+type structField struct {
+	name      string
+	index     int
+	formatter *Formatter
+}
+
+// structFieldsCache maps a reflect.Type to the []structField for that type.
+var structFieldsCache sync.Map
+
+var decimalType = reflect.TypeOf(decimal.Decimal{})
+
+func structFieldsOf(t reflect.Type) ([]structField, error) {
+	if fields, ok := structFieldsCache.Load(t); ok {
+		return fields.([]structField), nil
+	}
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup("numfmt")
+		if tag == "-" {
+			continue
+		}
+		if !hasTag && !isNumericType(field.Type) {
+			continue
+		}
+
+		f, err := ParseSpec(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		fields = append(fields, structField{name: field.Name, index: i, formatter: f})
+	}
+
+	structFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+func isNumericType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == decimalType {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
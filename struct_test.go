@@ -0,0 +1,82 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatStruct(t *testing.T) {
+	type invoice struct {
+		Total     decimal.Decimal `numfmt:"Preset=usd"`
+		Discount  float64         `numfmt:"Shift=2; RoundPlaces=1; Template=-n%"`
+		Quantity  int
+		Weight    float32 `numfmt:"GroupSeparator=' '; MinDecimalPlaces=2"`
+		Code      string  `numfmt:"GroupSeparator=';'"`
+		Internal  int     `numfmt:"-"`
+		Name      string
+		unchecked int
+	}
+
+	v := invoice{
+		Total:    decimal.RequireFromString("-1234.5"),
+		Discount: 0.125,
+		Quantity: 12000,
+		Weight:   1234.5,
+		Code:     "1234567",
+		Internal: 7,
+		Name:     "foo",
+	}
+
+	expected := map[string]string{
+		"Total":    "-$1,234.50",
+		"Discount": "12.5%",
+		"Quantity": "12,000",
+		"Weight":   "1 234.50",
+		"Code":     "1;234;567",
+	}
+
+	m, err := numfmt.FormatStruct(v)
+	require.NoError(t, err)
+	assert.Equal(t, expected, m)
+
+	m, err = numfmt.FormatStruct(&v)
+	require.NoError(t, err)
+	assert.Equal(t, expected, m)
+}
+
+func TestFormatStructPointers(t *testing.T) {
+	type account struct {
+		Balance *decimal.Decimal `numfmt:"Preset=usd"`
+		Limit   *decimal.Decimal `numfmt:"Preset=usd; Nil=none"`
+		Count   *int
+	}
+
+	balance := decimal.RequireFromString("1234.5")
+	count := 1000
+	m, err := numfmt.FormatStruct(account{Balance: &balance, Count: &count})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Balance": "$1,234.50", "Limit": "none", "Count": "1,000"}, m)
+}
+
+func TestFormatStructError(t *testing.T) {
+	for i, v := range []interface{}{
+		42,
+		(*struct{})(nil),
+		struct {
+			A int `numfmt:"Unknown=1"`
+		}{},
+		struct {
+			A int `numfmt:"RoundPlaces"`
+		}{},
+		struct {
+			A int `numfmt:"GroupSeparator='"`
+		}{},
+	} {
+		_, err := numfmt.FormatStruct(v)
+		assert.Errorf(t, err, "%d", i)
+	}
+}
@@ -0,0 +1,86 @@
+package numfmt
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// TableWriter writes rows of values to a tabwriter.Writer formatting each column with its own Formatter. Numbers are
+// right-aligned and text is left-aligned. Rows are buffered until Flush so that numbers can be aligned.
+type TableWriter struct {
+	tw         *tabwriter.Writer
+	formatters []*Formatter
+	rows       [][]tableCell
+}
+
+type tableCell struct {
+	s      string
+	number bool
+}
+
+// NewTableWriter returns a TableWriter that writes to tw. formatters[i] formats column i. Values in columns without a
+// Formatter or with a nil Formatter are written with fmt.Sprint as text. tw should not use tabwriter.AlignRight.
+func NewTableWriter(tw *tabwriter.Writer, formatters ...*Formatter) *TableWriter {
+	return &TableWriter{tw: tw, formatters: formatters}
+}
+
+// WriteRow formats values and buffers them as a row. nil values are written as empty cells. Values in a column with a
+// Formatter that cannot be converted to a number such as a header are written as text.
+func (t *TableWriter) WriteRow(values ...interface{}) {
+	row := make([]tableCell, len(values))
+	for i, v := range values {
+		row[i] = t.formatCell(i, v)
+	}
+	t.rows = append(t.rows, row)
+}
+
+func (t *TableWriter) formatCell(i int, v interface{}) tableCell {
+	if v == nil {
+		return tableCell{}
+	}
+	if i < len(t.formatters) && t.formatters[i] != nil {
+		if _, s, ok := t.formatters[i].toDecimal(v); !ok {
+			return tableCell{s: s}
+		}
+		return tableCell{s: t.formatters[i].Format(v), number: true}
+	}
+	return tableCell{s: fmt.Sprint(v)}
+}
+
+// Flush writes the buffered rows and flushes the tabwriter.Writer.
+func (t *TableWriter) Flush() error {
+	var widths []int
+	for _, row := range t.rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if n := utf8.RuneCountInString(cell.s); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	sb := &strings.Builder{}
+	for _, row := range t.rows {
+		sb.Reset()
+		for i, cell := range row {
+			if i > 0 {
+				sb.WriteByte('\t')
+			}
+			if cell.number {
+				writePadding(sb, " ", widths[i]-utf8.RuneCountInString(cell.s))
+			}
+			sb.WriteString(cell.s)
+		}
+		sb.WriteByte('\n')
+		if _, err := t.tw.Write([]byte(sb.String())); err != nil {
+			return err
+		}
+	}
+	t.rows = t.rows[:0]
+
+	return t.tw.Flush()
+}
@@ -0,0 +1,33 @@
+package numfmt_test
+
+import (
+	"bytes"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := numfmt.NewTableWriter(tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0),
+		nil,
+		&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 2}, MinDecimalPlaces: 2},
+		numfmt.NewPercentFormatter(),
+	)
+
+	w.WriteRow("Item", "Price", "Share")
+	w.WriteRow("Widget", "1234.5", "0.25")
+	w.WriteRow("Gadget", 7, "1")
+	w.WriteRow("Other", nil, "n/a")
+	err := w.Flush()
+	assert.NoError(t, err)
+
+	expected := "" +
+		"Item    Price     Share\n" +
+		"Widget  1,234.50    25%\n" +
+		"Gadget      7.00   100%\n" +
+		"Other             n/a\n"
+	assert.Equal(t, expected, buf.String())
+}
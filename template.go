@@ -0,0 +1,335 @@
+package numfmt
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// templateVerbs are the characters that are verbs in a template. Other verbs are written as a name in braces such as
+// {fill} so that letters and symbols in templates written before the verb was added remain literal text.
+const templateVerbs = "n-+"
+
+// templateVerbParts are the compiled template parts of templateVerbs and of the verbs in braces.
+var templateVerbParts = map[string]compiledTemplatePart{
+	"n":         compiledTemplatePartNumber{},
+	"-":         compiledTemplatePartOptionalSign{},
+	"+":         compiledTemplatePartForceSign{},
+	"{fill}":    compiledTemplatePartFill{},
+	"{trend}":   compiledTemplatePartTrend{},
+	"{int}":     compiledTemplatePartInteger{},
+	"{frac}":    compiledTemplatePartFraction{},
+	"{ordinal}": compiledTemplatePartOrdinal{},
+}
+
+// bracedVerb returns the verb in braces at the start of s such as "{fill}" or "" if s does not start with one.
+func bracedVerb(s string) string {
+	end := strings.IndexByte(s, '}')
+	if len(s) == 0 || s[0] != '{' || end < 0 {
+		return ""
+	}
+	if _, ok := templateVerbParts[s[:end+1]]; !ok {
+		return ""
+	}
+	return s[:end+1]
+}
+
+// Reasons for a TemplateError. Use errors.Is to check the reason of an error returned by ValidateTemplate.
+var (
+	ErrTrailingBackslash = errors.New("trailing backslash")
+	ErrInvalidUTF8       = errors.New("invalid UTF-8")
+	ErrInvalidEscape     = errors.New("invalid escape sequence")
+)
+
+// TemplateError is the error returned by ValidateTemplate.
+type TemplateError struct {
+	Template string // The template being compiled.
+	Offset   int    // Byte offset in Template of the invalid character.
+	Err      error  // The reason the template is invalid such as ErrTrailingBackslash.
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("invalid template %q: %v at offset %d", e.Template, e.Err, e.Offset)
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateTemplate returns an error if s is not a valid template. If s is invalid the error is a *TemplateError.
+// Formatter does not require a valid template. Invalid bytes are written literally, a trailing backslash is ignored,
+// and a \x, \u, or \U escape without valid hex digits is written as the letter and the text that follows it.
+func ValidateTemplate(s string) error {
+	_, err := compileTemplate(s)
+	return err
+}
+
+// EscapeTemplate returns s with backslashes added so that it is written literally when used in a template. '{' is
+// escaped so that text such as "{fill}" is not a verb, an include, or a placeholder.
+func EscapeTemplate(s string) string {
+	sb := &strings.Builder{}
+	for _, r := range s {
+		if r == '\\' || r == '{' || strings.ContainsRune(templateVerbs, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// TemplatePart is a verb or literal text of a template.
+type TemplatePart struct {
+	Verb    string // The verb such as "n", "-", or "{fill}". Empty for literal text.
+	Literal string // The text written if Verb is empty. Escape sequences are decoded.
+}
+
+// String returns p as template source. Literal text is escaped with EscapeTemplate.
+func (p TemplatePart) String() string {
+	if p.Verb != "" {
+		return p.Verb
+	}
+	return EscapeTemplate(p.Literal)
+}
+
+// ParseTemplate returns the verbs and literal text of the template s in order. {@name} includes of registered
+// templates are expanded. It allows tools to validate templates, preview them, or translate them to other formats.
+// e.g. "-$n" => verb "-", literal "$", verb "n". If s is not valid according to ValidateTemplate the error is a *TemplateError.
+func ParseTemplate(s string) ([]TemplatePart, error) {
+	ct, err := compileTemplate(expandIncludes(s, 0))
+	if err != nil {
+		return nil, err
+	}
+	return ct.parts(), nil
+}
+
+// TemplateParts returns the parts of the templates f uses for positive and negative numbers after Placeholders,
+// includes, Currency, and NegativeStyle are applied. negative is nil if negative numbers use template with the "-"
+// and "+" verbs writing the sign.
+func (f *Formatter) TemplateParts() (template, negative []TemplatePart) {
+	f = f.compiled()
+	return f.compiledTemplate.parts(), f.compiledNegativeTemplate.parts()
+}
+
+// parts returns the TemplateParts of ct. Adjacent literals are joined.
+func (ct compiledTemplate) parts() []TemplatePart {
+	if ct == nil {
+		return nil
+	}
+	parts := make([]TemplatePart, 0, len(ct))
+	for _, part := range ct {
+		if literal, ok := part.(compiledTemplatePartLiteral); ok {
+			if n := len(parts); n > 0 && parts[n-1].Verb == "" {
+				parts[n-1].Literal += string(literal)
+			} else {
+				parts = append(parts, TemplatePart{Literal: string(literal)})
+			}
+			continue
+		}
+		for verb, verbPart := range templateVerbParts {
+			if part == verbPart {
+				parts = append(parts, TemplatePart{Verb: verb})
+				break
+			}
+		}
+	}
+	return parts
+}
+
+// maxTemplateIncludeDepth is the maximum nesting of registered templates included with {@name}. It stops templates
+// that include themselves.
+const maxTemplateIncludeDepth = 8
+
+var templateRegistry struct {
+	mux       sync.RWMutex
+	templates map[string]string
+}
+
+// RegisterTemplate makes the template t available to other templates as {@name} so a base style can be shared by
+// Formatters that add their own text around it. e.g. with RegisterTemplate("money", "-$n") the Template
+// "Total: {@money}" writes Total: -$9.45. A registered template can include other registered templates. An error is
+// returned if t is not valid according to ValidateTemplate. Registering a name again replaces the template for
+// Formatters that have not been used yet. RegisterTemplate is concurrency safe.
+func RegisterTemplate(name string, t string) error {
+	if err := ValidateTemplate(t); err != nil {
+		return err
+	}
+
+	templateRegistry.mux.Lock()
+	if templateRegistry.templates == nil {
+		templateRegistry.templates = make(map[string]string)
+	}
+	templateRegistry.templates[name] = t
+	templateRegistry.mux.Unlock()
+
+	resetArgFormatters()
+	return nil
+}
+
+// expandIncludes returns t with the {@name} references to registered templates replaced by the templates. References
+// to templates that are not registered or that are nested too deeply are not replaced.
+func expandIncludes(t string, depth int) string {
+	if depth >= maxTemplateIncludeDepth || !strings.Contains(t, "{@") {
+		return t
+	}
+
+	sb := &strings.Builder{}
+	for i := 0; i < len(t); i++ {
+		switch t[i] {
+		case '\\':
+			sb.WriteByte('\\')
+			if i+1 < len(t) {
+				i++
+				sb.WriteByte(t[i])
+			}
+		case '{':
+			end := strings.IndexByte(t[i:], '}')
+			if end > 1 && t[i+1] == '@' {
+				templateRegistry.mux.RLock()
+				included, ok := templateRegistry.templates[t[i+2:i+end]]
+				templateRegistry.mux.RUnlock()
+				if ok {
+					sb.WriteString(expandIncludes(included, depth+1))
+					i += end
+					continue
+				}
+			}
+			sb.WriteByte('{')
+		default:
+			sb.WriteByte(t[i])
+		}
+	}
+	return sb.String()
+}
+
+// maxCachedTemplates is the maximum number of templates kept by cachedTemplate. Templates are usually a handful of
+// constants but they can also come from data such as TemplateFunc arguments so the cache is bounded.
+const maxCachedTemplates = 1024
+
+var (
+	// templateCache maps template strings to their compiledTemplate so Formatters using the same template share it.
+	templateCache      sync.Map
+	templateCacheCount int32
+)
+
+// cachedTemplate returns the compiledTemplate for s from templateCache, compiling and adding it if it is not there
+// and the cache is not full. A compiledTemplate is never modified so it can be shared.
+func cachedTemplate(s string) compiledTemplate {
+	if ct, ok := templateCache.Load(s); ok {
+		return ct.(compiledTemplate)
+	}
+	ct, _ := compileTemplate(s)
+	if atomic.LoadInt32(&templateCacheCount) < maxCachedTemplates {
+		if _, loaded := templateCache.LoadOrStore(s, ct); !loaded {
+			atomic.AddInt32(&templateCacheCount, 1)
+		}
+	}
+	return ct
+}
+
+// compileTemplate compiles s. The returned template is always usable. err is a *TemplateError for the first problem
+// found in s.
+func compileTemplate(s string) (compiledTemplate, error) {
+	ct := compiledTemplate{}
+	var err error
+	fail := func(offset int, reason error) {
+		if err == nil {
+			err = &TemplateError{Template: s, Offset: offset, Err: reason}
+		}
+	}
+
+	literal := &strings.Builder{}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			fail(i, ErrInvalidUTF8)
+			literal.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		if r == '\\' {
+			if i+size == len(s) {
+				fail(i, ErrTrailingBackslash)
+				break
+			}
+			backslash := i
+			i += size
+
+			if ur, n, ok := unicodeEscape(s[i:]); ok {
+				literal.WriteRune(ur)
+				i += n
+				continue
+			}
+			if strings.IndexByte("xuU", s[i]) >= 0 {
+				fail(backslash, ErrInvalidEscape)
+			}
+
+			r, size = utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				fail(i, ErrInvalidUTF8)
+				literal.WriteByte(s[i])
+			} else {
+				literal.WriteRune(r)
+			}
+			i += size
+			continue
+		}
+		verb := bracedVerb(s[i:])
+		if verb == "" && strings.ContainsRune(templateVerbs, r) {
+			verb = string(r)
+		}
+		if verb == "" {
+			literal.WriteRune(r)
+			i += size
+			continue
+		}
+		i += len(verb)
+
+		if literal.Len() > 0 {
+			ct = append(ct, compiledTemplatePartLiteral(literal.String()))
+			literal.Reset()
+		}
+
+		ct = append(ct, templateVerbParts[verb])
+	}
+
+	if literal.Len() > 0 {
+		ct = append(ct, compiledTemplatePartLiteral(literal.String()))
+	}
+
+	return ct, err
+}
+
+// unicodeEscape decodes the escape sequence at the start of s that follows a backslash. s must start with 'x' and 2
+// hex digits, 'u' and 4 hex digits, or 'U' and 8 hex digits. n is the length of the sequence.
+func unicodeEscape(s string) (r rune, n int, ok bool) {
+	if len(s) == 0 {
+		return 0, 0, false
+	}
+
+	var digits int
+	switch s[0] {
+	case 'x':
+		digits = 2
+	case 'u':
+		digits = 4
+	case 'U':
+		digits = 8
+	default:
+		return 0, 0, false
+	}
+	if len(s) < 1+digits {
+		return 0, 0, false
+	}
+
+	code, err := strconv.ParseUint(s[1:1+digits], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, 0, false
+	}
+
+	return rune(code), 1 + digits, true
+}
@@ -0,0 +1,197 @@
+package numfmt_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatterMultiByteTemplate(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Template: "→n←"}, "1234", "→1,234←"},
+		{&numfmt.Formatter{Template: `\€n`}, "5", "€5"},
+		{&numfmt.Formatter{Template: `n \日本`}, "5", "5 日本"},
+		{&numfmt.Formatter{Template: "n ñ"}, "5", "5 ñ"},
+		{&numfmt.Formatter{Template: `€n\`}, "5", "€5"},
+		{&numfmt.Formatter{Template: "n\xff"}, "5", "5\xff"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+// TestTemplateLiteralLetters checks that templates written when the only verbs were n, -, and + are unchanged. Verbs
+// added since are written in braces.
+func TestTemplateLiteralLetters(t *testing.T) {
+	for i, tt := range []struct {
+		template string
+		arg      interface{}
+		expected string
+	}{
+		{"Total: -$n", -12, "Total: -$12"},
+		{"n oz", 12, "12 oz"},
+		{"n ft", 12, "12 ft"},
+		{"-n°F", -3, "-3°F"},
+		{"<div>-n</div>", -12, "<div>-12</div>"},
+		{"-$n USD", 1234, "$1,234 USD"},
+		{"-n * 2", 12, "12 * 2"},
+		{"-n ^2", -3, "-3 ^2"},
+		{"(-n) [i]", 1.5, "(1.5) [i]"},
+		{"{x} -n", 7, "{x} 7"},
+	} {
+		f := &numfmt.Formatter{Template: tt.template}
+		assert.Equalf(t, tt.expected, f.Format(tt.arg), "%d", i)
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	for i, s := range []string{"", "n", "-$n", `n °C`, "→n←", `\\n`} {
+		assert.NoErrorf(t, numfmt.ValidateTemplate(s), "%d", i)
+	}
+
+	for i, tt := range []struct {
+		template string
+		err      error
+		offset   int
+	}{
+		{`n\`, numfmt.ErrTrailingBackslash, 1},
+		{`€n\`, numfmt.ErrTrailingBackslash, 4},
+		{"n\xff", numfmt.ErrInvalidUTF8, 1},
+		{"€\\\xffn", numfmt.ErrInvalidUTF8, 4},
+		{`\u00a n`, numfmt.ErrInvalidEscape, 0},
+		{`n\xZZ`, numfmt.ErrInvalidEscape, 1},
+		{`€n\U0011FFFF`, numfmt.ErrInvalidEscape, 4},
+		{`n\x`, numfmt.ErrInvalidEscape, 1},
+	} {
+		err := numfmt.ValidateTemplate(tt.template)
+		assert.Truef(t, errors.Is(err, tt.err), "%d: %v", i, err)
+		var templateErr *numfmt.TemplateError
+		if assert.Truef(t, errors.As(err, &templateErr), "%d", i) {
+			assert.Equalf(t, tt.offset, templateErr.Offset, "%d", i)
+			assert.Equalf(t, tt.template, templateErr.Template, "%d", i)
+		}
+	}
+
+	_, err := numfmt.TemplateFunc("Template", `n\`)
+	assert.True(t, errors.Is(err, numfmt.ErrTrailingBackslash))
+}
+
+func TestFormatterSharedTemplates(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1500; j++ {
+				f := &numfmt.Formatter{Template: "-$n", NegativeTemplate: "(n)"}
+				assert.Equal(t, "$5", f.Format(5))
+				assert.Equal(t, "(5)", f.Format(-5))
+
+				// Enough distinct templates to fill the cache.
+				prefix := strconv.Itoa(i*1500 + j)
+				f = &numfmt.Formatter{Template: prefix + " n"}
+				assert.Equal(t, prefix+" 5", f.Format(5))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestRegisterTemplate(t *testing.T) {
+	require.NoError(t, numfmt.RegisterTemplate("tbase", "-n"))
+	require.NoError(t, numfmt.RegisterTemplate("tmoney", "{@tbase} {sym}"))
+	require.NoError(t, numfmt.RegisterTemplate("tcycle", "{@tcycle}n"))
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Template: "Sum: {@tbase}"}, "-9.45", "Sum: -9.45"},
+		{&numfmt.Formatter{Template: "[{@tmoney}]", Placeholders: map[string]string{"sym": "EUR"}}, "1234", "[1,234 EUR]"},
+		{&numfmt.Formatter{Template: "-n", NegativeTemplate: "({@tbase})"}, "-5", "(-5)"},
+		{&numfmt.Formatter{Template: `\{@tbase}n`}, "5", "{@tbase}5"},
+		{&numfmt.Formatter{Template: "{@tzzz}n"}, "5", "{@tzzz}5"},
+		{&numfmt.Formatter{Template: "{@tcycle}"}, "5", "{@tcycle}55555555"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	assert.Error(t, numfmt.RegisterTemplate("tbad", `n\`))
+}
+
+func TestParseTemplate(t *testing.T) {
+	lit := func(s string) numfmt.TemplatePart { return numfmt.TemplatePart{Literal: s} }
+	verb := func(v string) numfmt.TemplatePart { return numfmt.TemplatePart{Verb: v} }
+
+	for i, tt := range []struct {
+		template string
+		expected []numfmt.TemplatePart
+	}{
+		{"n", []numfmt.TemplatePart{verb("n")}},
+		{"-$n", []numfmt.TemplatePart{verb("-"), lit("$"), verb("n")}},
+		{"{trend} +n", []numfmt.TemplatePart{verb("{trend}"), lit(" "), verb("+"), verb("n")}},
+		{"${fill}{int}<sup>{frac}</sup>", []numfmt.TemplatePart{lit("$"), verb("{fill}"), verb("{int}"), lit("<sup>"), verb("{frac}"), lit("</sup>")}},
+		{"#n{ordinal}", []numfmt.TemplatePart{lit("#"), verb("n"), verb("{ordinal}")}},
+		{`-n \n kg`, []numfmt.TemplatePart{verb("-"), verb("n"), lit(" n kg")}},
+		{"", []numfmt.TemplatePart{}},
+	} {
+		actual, err := numfmt.ParseTemplate(tt.template)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	require.NoError(t, numfmt.RegisterTemplate("tparts", "-$n"))
+	actual, err := numfmt.ParseTemplate("{@tparts} USD")
+	require.NoError(t, err)
+	assert.Equal(t, []numfmt.TemplatePart{verb("-"), lit("$"), verb("n"), lit(" USD")}, actual)
+
+	_, err = numfmt.ParseTemplate(`n\`)
+	var te *numfmt.TemplateError
+	require.True(t, errors.As(err, &te))
+	assert.Equal(t, numfmt.ErrTrailingBackslash, te.Err)
+}
+
+func TestTemplatePartString(t *testing.T) {
+	for _, s := range []string{"-$n", `+n \n`, "{trend} +n%", `\\n\*`} {
+		parts, err := numfmt.ParseTemplate(s)
+		require.NoError(t, err)
+		source := ""
+		for _, p := range parts {
+			source += p.String()
+		}
+		roundTrip, err := numfmt.ParseTemplate(source)
+		require.NoError(t, err)
+		assert.Equalf(t, parts, roundTrip, "%s", s)
+	}
+}
+
+func TestFormatterTemplateParts(t *testing.T) {
+	lit := func(s string) numfmt.TemplatePart { return numfmt.TemplatePart{Literal: s} }
+	verb := func(v string) numfmt.TemplatePart { return numfmt.TemplatePart{Verb: v} }
+
+	template, negative := (&numfmt.Formatter{}).TemplateParts()
+	assert.Equal(t, []numfmt.TemplatePart{verb("-"), verb("n")}, template)
+	assert.Nil(t, negative)
+
+	template, negative = (&numfmt.Formatter{Currency: "USD", NegativeStyle: numfmt.NegativeParentheses}).TemplateParts()
+	assert.Equal(t, []numfmt.TemplatePart{verb("-"), lit("$"), verb("n")}, template)
+	assert.Equal(t, []numfmt.TemplatePart{lit("($"), verb("n"), lit(")")}, negative)
+
+	template, negative = (&numfmt.Formatter{NegativeStyle: numfmt.NegativeDebitCredit}).TemplateParts()
+	assert.Equal(t, []numfmt.TemplatePart{verb("n"), lit(" CR")}, template)
+	assert.Equal(t, []numfmt.TemplatePart{verb("n"), lit(" DR")}, negative)
+
+	template, _ = (&numfmt.Formatter{Template: "n {u}", Placeholders: map[string]string{"u": "kg"}}).TemplateParts()
+	assert.Equal(t, []numfmt.TemplatePart{verb("n"), lit(" kg")}, template)
+}
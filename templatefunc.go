@@ -0,0 +1,256 @@
+//go:build !numfmt_noreflect
+// +build !numfmt_noreflect
+
+package numfmt
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// TemplateFunc is a helper method for use with text/template and html/template. args is a sequence of key-value pairs
+// configuring the formatting. If len(args) is even a formatting function is returned. If len(args) is odd the final
+// value is formatted and returned.
+//
+// Keys are generally named the same as matching the Formatter fields:
+//   GroupSeparator
+//   GroupSize
+//   Grouping (pattern such as "3,2")
+//   MinGroupingDigits
+//   NoGrouping
+//   DecimalSeparator
+//   Digits
+//   MinusSign
+//   PlusSign
+//   ZeroSign (plus, minus, plusminus, blank, or none)
+//   TrendUp
+//   TrendDown
+//   TrendFlat
+//   BidiIsolation (none, FSI, LRI, or LRM)
+//   Humanizer (compact, finance, si, bytes, iec, bitrate, duration, indian, thousands, millions, billions, ja-myriad,
+//     or zh-myriad)
+//   MinWidth
+//   MaxWidth
+//   MaxIntegerDigits
+//   IntegerOverflow (clamp, scientific, compact, or error)
+//   Undefined
+//   ZeroDenominator (undefined, zero, or blank)
+//   ZeroDenominatorTolerance
+//   Nil
+//   Invalid
+//   Fallback (name of a preset)
+//   Alignment (left, right, or decimal)
+//   Fill
+//   PositivePlaceholder
+//   ScientificAbove
+//   ScientificBelow
+//   ScientificStyle (e, superscript, or caret)
+//   PreserveExponent
+//   SanitizeInput
+//   ScientificUppercaseE
+//   ScientificForceExponentSign
+//   ScientificMinExponentDigits
+//   RoundPlaces
+//   SignificantDigits
+//   Shift
+//   RoundBeforeShift
+//   Scale
+//   MinDecimalPlaces
+//   PreserveScale
+//   AlwaysShowDecimalSeparator
+//   PipSeparator
+//   DisplayMax
+//   DisplayMaxSuffix
+//   ClampMin
+//   ClampMax
+//   ClampBelowMarker
+//   ClampAboveMarker
+//   MarkApproximate
+//   ApproximateSign
+//   ApproximateAfter
+//   Currency (ISO 4217 code as used by NewCurrencyFormatter)
+//   CurrencySymbol
+//   CurrencySuffix
+//   CurrencySeparator
+//   CurrencySign (before, after, or parentheses)
+//   Unit (symbol of a Unit)
+//   UnitPlural (Plural of a Unit)
+//   UnitSeparator
+//   Ordinal
+//   Base
+//   BasePrefix
+//   UppercaseDigits
+//   Template
+//   NegativeTemplate
+//   NegativeStyle (sign, parentheses, or drcr)
+//   DebitLabel
+//   CreditLabel
+//   MinSignificantDigits
+//   ConciseUncertainty
+//   Repeating (none, parentheses, or overline)
+//   LeadingZeros (drop, keep, or ungrouped)
+//   FloatConversion (shortest, exact, or significant)
+//   FloatDigits
+//   FractionDenominators (comma separated list such as "2,4,8")
+//   FractionTolerance
+//   FractionRound
+//   FractionGlyphs
+//   SexagesimalPlaces
+//   SexagesimalOmitSeconds
+//   SexagesimalZeroPad
+//   SexagesimalSymbols (comma separated list such as "°,′,″")
+//   SexagesimalNoSpace
+//   ClockUnit (duration such as "1s" or "1m")
+//   ClockSmallest (duration such as "1s" or "1m")
+//   ClockPlaces
+//   ClockAlwaysHours
+//   ClockPadFirst
+//   InputMaxLength
+//   InputMaxIntegerDigits
+//   InputMaxExponent
+//   InputReplacement
+//   MyriadUnits (ja or zh)
+//   UnitNoSpace
+//   UnitPrefixes
+//   Colors (default or none)
+//   Mask
+//   Placeholders (map[string]string or map[string]interface{} such as from the dict function of sprig)
+//
+// Rounder.Rules, Unit.Forms, MagnitudeRules, PreFormat, and PostFormat can only be set from Go. Register a Formatter
+// using them and use it with the Preset key.
+//
+// The Preset key takes the name of a Formatter registered with Register or of a built-in preset such as "usd" or
+// "percent" and initializes the formatter with a copy of it. The Locale key takes a BCP 47 language tag such as
+// "fr-FR" and initializes the separators from the locale data used by NewLocaleFormatter. Preset and then Locale are
+// applied before all other keys regardless of their position in args.
+//
+// Keys are case-insensitive and may be written in snake_case or kebab-case. e.g. GroupSeparator, group_separator,
+// group-separator, and groupseparator are the same key.
+//
+// The keys and values can also be given as a map[string]interface{} such as one built by the dict function of sprig
+// instead of as alternating arguments. The number to format may follow the map. e.g.
+//   {{numfmt (dict "RoundPlaces" 2 "Template" "$n") .Price}}
+//
+// The Formatter for a list of keys and values is cached so calling TemplateFunc with the same arguments for each row
+// of a table compiles it only once. Register and RegisterLocale clear the cache.
+func TemplateFunc(args ...interface{}) (interface{}, error) {
+	args = expandMapArgs(args)
+	f, err := cachedFormatterFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args)%2 == 1 {
+		return f.Format(args[len(args)-1]), nil
+	}
+
+	return f.Format, nil
+}
+
+// newFormatterFromArgs returns a Formatter configured by the key-value pairs in args as described by TemplateFunc. If
+// len(args) is odd the final value is ignored.
+func newFormatterFromArgs(args []interface{}) (*Formatter, error) {
+	var o FormatterOptions
+	explicit := make(map[string]bool, len(args)/2)
+	for i := 0; i < len(args)-1; i += 2 {
+		key, err := templateKey(args[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := o.set(key, args[i+1]); err != nil {
+			return nil, err
+		}
+		explicit[key] = true
+	}
+
+	f := &Formatter{}
+	if err := o.apply(f, explicit); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// templateKeys maps the normalized TemplateFunc keys to their canonical names. The keys are the fields of
+// FormatterOptions.
+var templateKeys = func() map[string]string {
+	m := make(map[string]string)
+	t := reflect.TypeOf(FormatterOptions{})
+	for i := 0; i < t.NumField(); i++ {
+		m[normalizeTemplateKey(t.Field(i).Name)] = t.Field(i).Name
+	}
+	return m
+}()
+
+// expandMapArgs returns args with a map[string]interface{} or map[string]string of keys and values as the first
+// argument replaced by alternating keys and values. Other args are returned unchanged.
+func expandMapArgs(args []interface{}) []interface{} {
+	if len(args) == 0 || len(args) > 2 {
+		return args
+	}
+
+	var expanded []interface{}
+	switch m := args[0].(type) {
+	case map[string]interface{}:
+		expanded = mapArgs(m)
+	case map[string]string:
+		im := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			im[k] = v
+		}
+		expanded = mapArgs(im)
+	default:
+		return args
+	}
+	return append(expanded, args[1:]...)
+}
+
+// mapArgs returns the keys and values of m as alternating arguments sorted by key.
+func mapArgs(m map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	args := make([]interface{}, 0, len(m)*2)
+	for _, key := range keys {
+		args = append(args, key, m[key])
+	}
+	return args
+}
+
+// templateKey returns the canonical name of the TemplateFunc key k.
+func templateKey(k interface{}) (string, error) {
+	s := fmt.Sprint(k)
+	if key, ok := templateKeys[normalizeTemplateKey(s)]; ok {
+		return key, nil
+	}
+	return "", fmt.Errorf("unknown key: %s", s)
+}
+
+// normalizeTemplateKey lowercases s and removes underscores and hyphens.
+func normalizeTemplateKey(s string) string {
+	s = strings.ToLower(s)
+	return strings.NewReplacer("_", "", "-", "").Replace(s)
+}
+
+// FuncMap returns a template.FuncMap with TemplateFunc and formatting functions for the built-in presets:
+//   numfmt         TemplateFunc
+//   numfmtUSD      NewUSDFormatter
+//   numfmtPercent  NewPercentFormatter
+//   numfmtBytes    NewBytesFormatter
+//   numfmtCompact  NewCompactFormatter
+//
+// It can be used with html/template by converting it to html/template.FuncMap.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"numfmt":        TemplateFunc,
+		"numfmtUSD":     NewUSDFormatter().Format,
+		"numfmtPercent": NewPercentFormatter().Format,
+		"numfmtBytes":   NewBytesFormatter().Format,
+		"numfmtCompact": NewCompactFormatter().Format,
+	}
+}
@@ -0,0 +1,77 @@
+package numfmt
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// FormatInt formats i. It writes the same as Format(i). The typed methods FormatInt, FormatUint, FormatFloat, and
+// FormatDecimal go straight to rendering without the conversions of Format for interface{} inputs, so they avoid that
+// cost in hot paths. They are the methods available when numfmt is built with the numfmt_noreflect tag which leaves
+// out TemplateFunc, FormatterOptions, ParseSpec, LoadFormatters, FormatStruct, FormatHTML, LocaleFuncMap, the String and
+// GoString methods, and the conversion of pointers and other types with fmt.Sprint. The package then does not import
+// reflect itself, though its dependencies such as math/big and github.com/shopspring/decimal still do through fmt.
+func (f *Formatter) FormatInt(i int64) string {
+	f = f.compiled()
+	if f.intFastPath {
+		return f.finish(f.intState(i), i)
+	}
+	return f.formatTyped(decimal.NewFromInt(i), i)
+}
+
+// FormatUint formats u. It writes the same as Format(u).
+func (f *Formatter) FormatUint(u uint64) string {
+	return f.compiled().formatTyped(uintDecimal(u), u)
+}
+
+// FormatFloat formats x according to FloatConversion. It writes the same as Format(x).
+func (f *Formatter) FormatFloat(x float64) string {
+	fs, s, ok := f.floatState(x)
+	if !ok {
+		return s
+	}
+	return f.finish(fs, x)
+}
+
+// FormatDecimal formats d. It writes the same as Format(d).
+func (f *Formatter) FormatDecimal(d decimal.Decimal) string {
+	return f.compiled().formatTyped(d, d)
+}
+
+// formatTyped formats d that was converted from v by a typed method. InputLimits are checked as Format does.
+func (f *Formatter) formatTyped(d decimal.Decimal, v interface{}) string {
+	if !f.InputLimits.allows(d) {
+		return f.exceededText()
+	}
+	return f.finish(f.decimalState(d), v)
+}
+
+// floatState is state for a float64. Non-finite x is passed to Fallback and written as Invalid like other inputs that
+// are not numbers.
+func (f *Formatter) floatState(x float64) (fs *formatState, s string, ok bool) {
+	f = f.compiled()
+
+	var d decimal.Decimal
+	if f.FloatConversion != FloatShortest {
+		d, ok = f.floatToDecimal(x)
+	}
+	if !ok {
+		d, s, ok = floatDecimal(x, 64)
+	}
+	if !ok {
+		if f.Fallback != nil {
+			fs, fallbackS, ok := f.Fallback.floatState(x)
+			if ok || f.Invalid == "" {
+				return fs, fallbackS, ok
+			}
+		}
+		if f.Invalid != "" {
+			s = f.Invalid
+		}
+		return nil, s, false
+	}
+
+	if !f.InputLimits.allows(d) {
+		return nil, f.exceededText(), false
+	}
+	return f.decimalState(d), "", true
+}
@@ -0,0 +1,92 @@
+package numfmt_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterTypedMethods(t *testing.T) {
+	for i, f := range []*numfmt.Formatter{
+		{},
+		numfmt.NewUSDFormatter(),
+		{Rounder: &numfmt.Rounder{Places: 1}, Template: "-n%", Shift: 2},
+		{FloatConversion: numfmt.FloatExact},
+		{InputLimits: &numfmt.InputLimits{MaxIntegerDigits: 5}},
+		{Invalid: "x", Fallback: &numfmt.Formatter{}},
+		{Fallback: &numfmt.Formatter{Invalid: "n/a"}},
+	} {
+		assert.Equalf(t, f.Format(int64(-1234567)), f.FormatInt(-1234567), "%d", i)
+		assert.Equalf(t, f.Format(uint64(math.MaxUint64)), f.FormatUint(math.MaxUint64), "%d", i)
+		assert.Equalf(t, f.Format(1234.5678), f.FormatFloat(1234.5678), "%d", i)
+		assert.Equalf(t, f.Format(math.Inf(1)), f.FormatFloat(math.Inf(1)), "%d", i)
+		assert.Equalf(t, f.Format(math.NaN()), f.FormatFloat(math.NaN()), "%d", i)
+		assert.Equalf(t, f.Format(int64(123)), f.FormatInt(123), "%d", i)
+		assert.Equalf(t, f.Format("1234.5678"), f.FormatDecimal(decimal.RequireFromString("1234.5678")), "%d", i)
+	}
+
+	f := &numfmt.Formatter{}
+	assert.Equal(t, "18,446,744,073,709,551,615", f.FormatUint(math.MaxUint64))
+	assert.Equal(t, "1,000,000,000,000,000,000,000", f.FormatFloat(1e21))
+	assert.Equal(t, "0.1", f.FormatFloat(0.1))
+	assert.Equal(t, "0.1", f.Format(float32(0.1)))
+	assert.Equal(t, "+Inf", f.FormatFloat(math.Inf(1)))
+
+	f = &numfmt.Formatter{InputLimits: &numfmt.InputLimits{MaxIntegerDigits: 5, Replacement: "#"}}
+	assert.Equal(t, "#", f.FormatInt(-1234567))
+	assert.Equal(t, "#", f.FormatUint(math.MaxUint64))
+	assert.Equal(t, "#", f.FormatFloat(1e21))
+	assert.Equal(t, "12,345", f.FormatFloat(12345))
+
+	f = &numfmt.Formatter{Invalid: "x", Fallback: &numfmt.Formatter{}}
+	assert.Equal(t, "x", f.FormatFloat(math.NaN()))
+}
+
+// TestFormatterCopiesAllFields checks that copies of a Formatter such as CompiledFormatter.Formatter keep every
+// exported field as the fields are copied one by one.
+func TestFormatterCopiesAllFields(t *testing.T) {
+	f := &numfmt.Formatter{}
+	rv := reflect.ValueOf(f).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fv := rv.Field(i)
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString("x")
+		case reflect.Int, reflect.Int32:
+			fv.SetInt(1)
+		case reflect.Bool:
+			fv.SetBool(true)
+		case reflect.Ptr:
+			fv.Set(reflect.New(field.Type.Elem()))
+		case reflect.Slice:
+			fv.Set(reflect.MakeSlice(field.Type, 1, 1))
+		case reflect.Map:
+			fv.Set(reflect.MakeMap(field.Type))
+		case reflect.Func:
+			fv.Set(reflect.MakeFunc(field.Type, func(args []reflect.Value) []reflect.Value { return args }))
+		case reflect.Interface:
+			fv.Set(reflect.ValueOf(numfmt.GroupingPattern{3}))
+		case reflect.Struct:
+			fv.Set(reflect.ValueOf(decimal.New(1, 0)))
+		default:
+			t.Fatalf("unhandled field %s", field.Name)
+		}
+	}
+
+	c := reflect.ValueOf(f.Compile().Formatter()).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		assert.Falsef(t, c.Field(i).IsZero(), "%s not copied", field.Name)
+	}
+}
@@ -0,0 +1,67 @@
+package numfmt
+
+// FormatWithUncertainty formats v with the uncertainty err. err is rounded to two significant figures if its leading
+// digit is 1 and to one significant figure otherwise. v is rounded to the same decimal place. e.g. 1.23449 and
+// 0.0042 => 1.234 ± 0.004 and 1.23449 and 0.0096 => 1.23 ± 0.01. If ConciseUncertainty is set the uncertainty is
+// written in parentheses as a number of units in the last place. e.g. 1.234(4). Rounder, Scientific, and Humanizer
+// are not used. v and err can be anything Format accepts. If err cannot be converted or is zero v is formatted with
+// Format.
+func (f *Formatter) FormatWithUncertainty(v, err interface{}) string {
+	d, s, ok := f.toDecimal(v)
+	if !ok {
+		return s
+	}
+	e, _, ok := f.toDecimal(err)
+	if !ok || e.IsZero() {
+		return f.Format(v)
+	}
+
+	e = f.scale(e.Abs()).Abs()
+
+	sigFigs := int32(1)
+	if e.Coefficient().String()[0] == '1' {
+		sigFigs = 2
+	}
+	places := sigFigs - 1 - magnitude(e)
+	rounded := e.Round(places)
+	// Rounding up to the next power of ten such as 0.0096 => 0.010 adds a digit. Keep the same number of significant
+	// figures at the new magnitude. e.g. 0.01
+	if grown := magnitude(rounded) - magnitude(e); grown > 0 {
+		places -= grown
+		rounded = e.Round(places)
+	}
+	e = rounded
+
+	uf := f.clone()
+	uf.Rounder = &Rounder{Places: places}
+	uf.MinDecimalPlaces = 0
+	if places > 0 {
+		uf.MinDecimalPlaces = places
+	}
+	uf.PreserveScale = false
+	uf.Scientific = nil
+	uf.Humanizer = nil
+	if uf.Unit != nil {
+		unit := *uf.Unit
+		unit.Prefixes = false
+		uf.Unit = &unit
+	}
+
+	ef := &Formatter{
+		GroupSeparator:   f.GroupSeparator,
+		GroupSize:        f.GroupSize,
+		DecimalSeparator: f.DecimalSeparator,
+		Digits:           f.Digits,
+	}
+	if f.ConciseUncertainty {
+		if places > 0 {
+			e = e.Shift(places)
+		}
+		uf.uncertainty = "(" + ef.formatDecimal(e) + ")"
+	} else {
+		ef.MinDecimalPlaces = uf.MinDecimalPlaces
+		uf.uncertainty = " ± " + ef.formatDecimal(e)
+	}
+
+	return uf.formatDecimal(d)
+}
@@ -0,0 +1,40 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterFormatWithUncertainty(t *testing.T) {
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		err       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{}, "1.23449", "0.0042", "1.234 ± 0.004"},
+		{&numfmt.Formatter{}, "1.23449", "0.0149", "1.234 ± 0.015"},
+		{&numfmt.Formatter{}, "1.2", "0.0042", "1.200 ± 0.004"},
+		{&numfmt.Formatter{}, "1234.5", "42", "1,230 ± 40"},
+		{&numfmt.Formatter{}, "1.23449", "0.0096", "1.23 ± 0.01"},
+		{&numfmt.Formatter{}, "1234.5", "96", "1,200 ± 100"},
+		{&numfmt.Formatter{}, "1.23449", "0.0196", "1.234 ± 0.020"},
+		{&numfmt.Formatter{}, "-9.81", "-0.03", "-9.81 ± 0.03"},
+		{&numfmt.Formatter{}, "9.81", "0", "9.81"},
+		{&numfmt.Formatter{}, "9.81", "abc", "9.81"},
+		{&numfmt.Formatter{}, "abc", "0.1", "abc"},
+		{&numfmt.Formatter{ConciseUncertainty: true}, "1.23449", "0.0042", "1.234(4)"},
+		{&numfmt.Formatter{ConciseUncertainty: true}, "6.6743", "0.00015", "6.67430(15)"},
+		{&numfmt.Formatter{ConciseUncertainty: true}, "1234.5", "42", "1,230(40)"},
+		{&numfmt.Formatter{ConciseUncertainty: true}, "1.23449", "0.0096", "1.23(1)"},
+		{&numfmt.Formatter{DecimalSeparator: ","}, "1.23449", "0.0042", "1,234 ± 0,004"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "kg"}}, "12.34", "0.2", "12.3 ± 0.2 kg"},
+		{&numfmt.Formatter{Shift: 2, Template: "-n%"}, "0.1234", "0.005", "12.3 ± 0.5%"},
+		{&numfmt.Formatter{Rounder: &numfmt.Rounder{Places: 0}}, "1.23449", "0.0042", "1.234 ± 0.004"},
+	} {
+		actual := tt.formatter.FormatWithUncertainty(tt.arg, tt.err)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
@@ -0,0 +1,89 @@
+package numfmt
+
+import (
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// Unit is a unit of measurement written after a number. e.g. kg or °F.
+type Unit struct {
+	Symbol string // e.g. "kg"
+
+	// NoSpace writes Symbol immediately after the number instead of after Formatter.UnitSeparator. e.g. 45° or 12%.
+	NoSpace bool
+
+	// Prefixes scales the number with the SI prefix for its magnitude written before Symbol. e.g. 12300 => 12.3 kg
+	// with Symbol "g". Prefixes from pico to exa are used.
+	Prefixes bool
+
+	// Plural is written instead of Symbol when the number is not exactly 1 as displayed. e.g. 1 item and 2 items with
+	// Symbol "item" and Plural "items". It is ignored if Forms is set.
+	Plural string
+
+	// Forms maps the CLDR plural forms of Language to the text written instead of Symbol. e.g. 1 Tag and 2 Tage with
+	// Language German and Forms {plural.One: "Tag", plural.Other: "Tage"}. A missing form falls back to plural.Other and
+	// then Symbol.
+	Forms map[plural.Form]string
+
+	// Language selects the CLDR plural rules used with Forms. Default: English.
+	Language language.Tag
+}
+
+// siPrefixHumanizer scales numbers with SI prefixes including the prefixes for submultiples.
+var siPrefixHumanizer = func() *Humanizer {
+	prefixes := []string{"p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E"}
+	h := &Humanizer{units: make([]humanizeUnit, len(prefixes))}
+	for i, prefix := range prefixes {
+		divisor := decimal.New(1, int32(i*3-12))
+		h.units[i] = humanizeUnit{min: divisor, divisor: divisor, suffix: prefix}
+	}
+	return h
+}()
+
+func (f *Formatter) unitSeparator() string {
+	if f.Unit.NoSpace {
+		return ""
+	}
+	if f.UnitSeparator != "" {
+		return f.UnitSeparator
+	}
+	return " "
+}
+
+// unitSuffix returns the suffix written after a number with the SI prefix prefix. intPart and fracPart are the digits
+// of the number as displayed and select the plural form.
+func (f *Formatter) unitSuffix(prefix, intPart, fracPart string) string {
+	return f.unitSeparator() + prefix + f.Unit.symbol(intPart, fracPart)
+}
+
+// symbol returns the text for the number with the displayed digits intPart and fracPart.
+func (u *Unit) symbol(intPart, fracPart string) string {
+	if len(u.Forms) > 0 {
+		lang := u.Language
+		if lang == language.Und {
+			lang = language.English
+		}
+
+		digits := make([]byte, 0, len(intPart)+len(fracPart))
+		for _, s := range [...]string{intPart, fracPart} {
+			for i := 0; i < len(s); i++ {
+				digits = append(digits, s[i]-'0')
+			}
+		}
+
+		form := plural.Cardinal.MatchDigits(lang, digits, len(intPart), len(fracPart))
+		if s, ok := u.Forms[form]; ok {
+			return s
+		}
+		if s, ok := u.Forms[plural.Other]; ok {
+			return s
+		}
+		return u.Symbol
+	}
+
+	if u.Plural != "" && (intPart != "1" || fracPart != "") {
+		return u.Plural
+	}
+	return u.Symbol
+}
@@ -0,0 +1,86 @@
+package numfmt_test
+
+import (
+	"testing"
+
+	"github.com/jackc/numfmt"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+func TestFormatterUnit(t *testing.T) {
+	fr := numfmt.NewLocaleFormatter(language.French)
+	fr.Unit = &numfmt.Unit{Symbol: "m"}
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "kg"}}, "12.3", "12.3 kg"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "°F"}, Rounder: &numfmt.Rounder{Places: 1}}, "98.64", "98.6 °F"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "°", NoSpace: true}}, "45", "45°"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "m"}, UnitSeparator: numfmt.ThinSpace}, "4", "4 m"},
+		{fr, "4.5", "4,5 m"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "g", Prefixes: true}, Rounder: &numfmt.Rounder{Places: 1}}, "12300", "12.3 kg"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "g", Prefixes: true}, Rounder: &numfmt.Rounder{Places: 1}}, "999", "999 g"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "g", Prefixes: true}, Rounder: &numfmt.Rounder{Places: 1}}, "999.99", "1 kg"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "s", Prefixes: true}}, "0.0042", "4.2 ms"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "s", Prefixes: true}}, "0.0000015", "1.5 µs"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "s", Prefixes: true}}, "0", "0 s"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "W", Prefixes: true}}, "-2500000", "-2.5 MW"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "m"}, Template: "(n)"}, "3", "(3 m)"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+}
+
+func TestFormatterUnitPlural(t *testing.T) {
+	items := &numfmt.Unit{Symbol: "item", Plural: "items"}
+	days := &numfmt.Unit{Language: language.German, Forms: map[plural.Form]string{plural.One: "Tag", plural.Other: "Tage"}}
+	files := &numfmt.Unit{
+		Language: language.Polish,
+		Forms:    map[plural.Form]string{plural.One: "plik", plural.Few: "pliki", plural.Many: "plików"},
+		Symbol:   "pliku",
+	}
+	english := &numfmt.Unit{Forms: map[plural.Form]string{plural.One: "file", plural.Other: "files"}}
+
+	for i, tt := range []struct {
+		formatter *numfmt.Formatter
+		arg       interface{}
+		expected  string
+	}{
+		{&numfmt.Formatter{Unit: items}, 1, "1 item"},
+		{&numfmt.Formatter{Unit: items}, -1, "-1 item"},
+		{&numfmt.Formatter{Unit: items}, 0, "0 items"},
+		{&numfmt.Formatter{Unit: items}, 2, "2 items"},
+		{&numfmt.Formatter{Unit: items}, "1.5", "1.5 items"},
+		{&numfmt.Formatter{Unit: items, MinDecimalPlaces: 1}, 1, "1.0 items"},
+		{&numfmt.Formatter{Unit: items, Rounder: &numfmt.Rounder{Places: 0}}, "1.2", "1 item"},
+		{&numfmt.Formatter{Unit: &numfmt.Unit{Symbol: "item"}}, 2, "2 item"},
+		{&numfmt.Formatter{Unit: days}, 1, "1 Tag"},
+		{&numfmt.Formatter{Unit: days}, 3, "3 Tage"},
+		{&numfmt.Formatter{Unit: files}, 1, "1 plik"},
+		{&numfmt.Formatter{Unit: files}, 3, "3 pliki"},
+		{&numfmt.Formatter{Unit: files}, 5, "5 plików"},
+		{&numfmt.Formatter{Unit: files}, 22, "22 pliki"},
+		{&numfmt.Formatter{Unit: files}, 1012, "1,012 plików"},
+		{&numfmt.Formatter{Unit: files}, "1.5", "1.5 pliku"},
+		{&numfmt.Formatter{Unit: english}, 1, "1 file"},
+		{&numfmt.Formatter{Unit: english}, 1000000001, "1,000,000,001 files"},
+	} {
+		actual := tt.formatter.Format(tt.arg)
+		assert.Equalf(t, tt.expected, actual, "%d", i)
+	}
+
+	s, err := numfmt.TemplateFunc("UnitPlural", "items", "Unit", "item", 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1 item", s)
+	}
+	s, err = numfmt.TemplateFunc("Unit", "item", "UnitPlural", "items", 3)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "3 items", s)
+	}
+}